@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: splitwise/v1/bills.proto
+
+package splitwisev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BillService_ListBills_FullMethodName  = "/splitwise.v1.BillService/ListBills"
+	BillService_UpdateBill_FullMethodName = "/splitwise.v1.BillService/UpdateBill"
+)
+
+// BillServiceClient is the client API for BillService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BillServiceClient interface {
+	// ListBills returns the bills of a group the caller is a member of, with the payments made.
+	ListBills(ctx context.Context, in *ListBillsRequest, opts ...grpc.CallOption) (*ListBillsResponse, error)
+	// UpdateBill renames or re-categorises a bill. Only the group owner can.
+	UpdateBill(ctx context.Context, in *UpdateBillRequest, opts ...grpc.CallOption) (*UpdateBillResponse, error)
+}
+
+type billServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBillServiceClient(cc grpc.ClientConnInterface) BillServiceClient {
+	return &billServiceClient{cc}
+}
+
+func (c *billServiceClient) ListBills(ctx context.Context, in *ListBillsRequest, opts ...grpc.CallOption) (*ListBillsResponse, error) {
+	out := new(ListBillsResponse)
+	err := c.cc.Invoke(ctx, BillService_ListBills_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billServiceClient) UpdateBill(ctx context.Context, in *UpdateBillRequest, opts ...grpc.CallOption) (*UpdateBillResponse, error) {
+	out := new(UpdateBillResponse)
+	err := c.cc.Invoke(ctx, BillService_UpdateBill_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillServiceServer is the server API for BillService service.
+// All implementations must embed UnimplementedBillServiceServer
+// for forward compatibility
+type BillServiceServer interface {
+	// ListBills returns the bills of a group the caller is a member of, with the payments made.
+	ListBills(context.Context, *ListBillsRequest) (*ListBillsResponse, error)
+	// UpdateBill renames or re-categorises a bill. Only the group owner can.
+	UpdateBill(context.Context, *UpdateBillRequest) (*UpdateBillResponse, error)
+	mustEmbedUnimplementedBillServiceServer()
+}
+
+// UnimplementedBillServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBillServiceServer struct {
+}
+
+func (UnimplementedBillServiceServer) ListBills(context.Context, *ListBillsRequest) (*ListBillsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBills not implemented")
+}
+func (UnimplementedBillServiceServer) UpdateBill(context.Context, *UpdateBillRequest) (*UpdateBillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBill not implemented")
+}
+func (UnimplementedBillServiceServer) mustEmbedUnimplementedBillServiceServer() {}
+
+// UnsafeBillServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BillServiceServer will
+// result in compilation errors.
+type UnsafeBillServiceServer interface {
+	mustEmbedUnimplementedBillServiceServer()
+}
+
+func RegisterBillServiceServer(s grpc.ServiceRegistrar, srv BillServiceServer) {
+	s.RegisterService(&BillService_ServiceDesc, srv)
+}
+
+func _BillService_ListBills_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBillsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillServiceServer).ListBills(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillService_ListBills_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillServiceServer).ListBills(ctx, req.(*ListBillsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillService_UpdateBill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillServiceServer).UpdateBill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillService_UpdateBill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillServiceServer).UpdateBill(ctx, req.(*UpdateBillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BillService_ServiceDesc is the grpc.ServiceDesc for BillService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BillService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "splitwise.v1.BillService",
+	HandlerType: (*BillServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBills",
+			Handler:    _BillService_ListBills_Handler,
+		},
+		{
+			MethodName: "UpdateBill",
+			Handler:    _BillService_UpdateBill_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "splitwise/v1/bills.proto",
+}
@@ -0,0 +1,4 @@
+// Package splitwisev1 holds the messages and gRPC stubs generated from proto/splitwise/v1.
+package splitwisev1
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=github.com/mohdjishin/SplitWise --go-grpc_out=../../.. --go-grpc_opt=module=github.com/mohdjishin/SplitWise splitwise/v1/bills.proto splitwise/v1/groups.proto splitwise/v1/members.proto splitwise/v1/payments.proto splitwise/v1/reports.proto
@@ -0,0 +1,493 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: splitwise/v1/payments.proto
+
+package splitwisev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MarkPaymentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId uint64 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Remarks string `protobuf:"bytes,2,opt,name=remarks,proto3" json:"remarks,omitempty"`
+}
+
+func (x *MarkPaymentRequest) Reset() {
+	*x = MarkPaymentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_splitwise_v1_payments_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarkPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkPaymentRequest) ProtoMessage() {}
+
+func (x *MarkPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_splitwise_v1_payments_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkPaymentRequest.ProtoReflect.Descriptor instead.
+func (*MarkPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_splitwise_v1_payments_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MarkPaymentRequest) GetGroupId() uint64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *MarkPaymentRequest) GetRemarks() string {
+	if x != nil {
+		return x.Remarks
+	}
+	return ""
+}
+
+type MarkPaymentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Member *Member `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+}
+
+func (x *MarkPaymentResponse) Reset() {
+	*x = MarkPaymentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_splitwise_v1_payments_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MarkPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkPaymentResponse) ProtoMessage() {}
+
+func (x *MarkPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_splitwise_v1_payments_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkPaymentResponse.ProtoReflect.Descriptor instead.
+func (*MarkPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_splitwise_v1_payments_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MarkPaymentResponse) GetMember() *Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type PendingPayment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId   uint64 `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupName string `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	BillId    uint64 `protobuf:"varint,3,opt,name=bill_id,json=billId,proto3" json:"bill_id,omitempty"`
+	// The caller's share.
+	Amount float64 `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// The whole bill.
+	BillAmount float64                `protobuf:"fixed64,5,opt,name=bill_amount,json=billAmount,proto3" json:"bill_amount,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+}
+
+func (x *PendingPayment) Reset() {
+	*x = PendingPayment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_splitwise_v1_payments_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingPayment) ProtoMessage() {}
+
+func (x *PendingPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_splitwise_v1_payments_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingPayment.ProtoReflect.Descriptor instead.
+func (*PendingPayment) Descriptor() ([]byte, []int) {
+	return file_splitwise_v1_payments_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PendingPayment) GetGroupId() uint64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *PendingPayment) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *PendingPayment) GetBillId() uint64 {
+	if x != nil {
+		return x.BillId
+	}
+	return 0
+}
+
+func (x *PendingPayment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PendingPayment) GetBillAmount() float64 {
+	if x != nil {
+		return x.BillAmount
+	}
+	return 0
+}
+
+func (x *PendingPayment) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type ListPendingPaymentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPendingPaymentsRequest) Reset() {
+	*x = ListPendingPaymentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_splitwise_v1_payments_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPendingPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_splitwise_v1_payments_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_splitwise_v1_payments_proto_rawDescGZIP(), []int{3}
+}
+
+type ListPendingPaymentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payments    []*PendingPayment `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	TotalAmount float64           `protobuf:"fixed64,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+}
+
+func (x *ListPendingPaymentsResponse) Reset() {
+	*x = ListPendingPaymentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_splitwise_v1_payments_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPendingPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_splitwise_v1_payments_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_splitwise_v1_payments_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPendingPaymentsResponse) GetPayments() []*PendingPayment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPendingPaymentsResponse) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+var File_splitwise_v1_payments_proto protoreflect.FileDescriptor
+
+var file_splitwise_v1_payments_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x70,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x73,
+	0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x73, 0x70,
+	0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x49, 0x0a, 0x12, 0x4d, 0x61, 0x72, 0x6b,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d,
+	0x61, 0x72, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x61,
+	0x72, 0x6b, 0x73, 0x22, 0x43, 0x0a, 0x13, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x6c,
+	0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xd9, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6c, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x69, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x69, 0x6c, 0x6c, 0x5f, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x62, 0x69, 0x6c,
+	0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x7a, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0xd0,
+	0x01, 0x0a, 0x0e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x52, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x20, 0x2e, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x72, 0x6b, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x2e, 0x73,
+	0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69,
+	0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x6f, 0x68, 0x64, 0x6a, 0x69, 0x73, 0x68, 0x69, 0x6e, 0x2f, 0x53, 0x70, 0x6c, 0x69, 0x74,
+	0x57, 0x69, 0x73, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69,
+	0x73, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x77, 0x69, 0x73, 0x65, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_splitwise_v1_payments_proto_rawDescOnce sync.Once
+	file_splitwise_v1_payments_proto_rawDescData = file_splitwise_v1_payments_proto_rawDesc
+)
+
+func file_splitwise_v1_payments_proto_rawDescGZIP() []byte {
+	file_splitwise_v1_payments_proto_rawDescOnce.Do(func() {
+		file_splitwise_v1_payments_proto_rawDescData = protoimpl.X.CompressGZIP(file_splitwise_v1_payments_proto_rawDescData)
+	})
+	return file_splitwise_v1_payments_proto_rawDescData
+}
+
+var file_splitwise_v1_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_splitwise_v1_payments_proto_goTypes = []interface{}{
+	(*MarkPaymentRequest)(nil),          // 0: splitwise.v1.MarkPaymentRequest
+	(*MarkPaymentResponse)(nil),         // 1: splitwise.v1.MarkPaymentResponse
+	(*PendingPayment)(nil),              // 2: splitwise.v1.PendingPayment
+	(*ListPendingPaymentsRequest)(nil),  // 3: splitwise.v1.ListPendingPaymentsRequest
+	(*ListPendingPaymentsResponse)(nil), // 4: splitwise.v1.ListPendingPaymentsResponse
+	(*Member)(nil),                      // 5: splitwise.v1.Member
+	(*timestamppb.Timestamp)(nil),       // 6: google.protobuf.Timestamp
+}
+var file_splitwise_v1_payments_proto_depIdxs = []int32{
+	5, // 0: splitwise.v1.MarkPaymentResponse.member:type_name -> splitwise.v1.Member
+	6, // 1: splitwise.v1.PendingPayment.create_time:type_name -> google.protobuf.Timestamp
+	2, // 2: splitwise.v1.ListPendingPaymentsResponse.payments:type_name -> splitwise.v1.PendingPayment
+	0, // 3: splitwise.v1.PaymentService.MarkPayment:input_type -> splitwise.v1.MarkPaymentRequest
+	3, // 4: splitwise.v1.PaymentService.ListPendingPayments:input_type -> splitwise.v1.ListPendingPaymentsRequest
+	1, // 5: splitwise.v1.PaymentService.MarkPayment:output_type -> splitwise.v1.MarkPaymentResponse
+	4, // 6: splitwise.v1.PaymentService.ListPendingPayments:output_type -> splitwise.v1.ListPendingPaymentsResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_splitwise_v1_payments_proto_init() }
+func file_splitwise_v1_payments_proto_init() {
+	if File_splitwise_v1_payments_proto != nil {
+		return
+	}
+	file_splitwise_v1_members_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_splitwise_v1_payments_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkPaymentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_splitwise_v1_payments_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MarkPaymentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_splitwise_v1_payments_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PendingPayment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_splitwise_v1_payments_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingPaymentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_splitwise_v1_payments_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingPaymentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_splitwise_v1_payments_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_splitwise_v1_payments_proto_goTypes,
+		DependencyIndexes: file_splitwise_v1_payments_proto_depIdxs,
+		MessageInfos:      file_splitwise_v1_payments_proto_msgTypes,
+	}.Build()
+	File_splitwise_v1_payments_proto = out.File
+	file_splitwise_v1_payments_proto_rawDesc = nil
+	file_splitwise_v1_payments_proto_goTypes = nil
+	file_splitwise_v1_payments_proto_depIdxs = nil
+}
@@ -0,0 +1,256 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: splitwise/v1/reports.proto
+
+package splitwisev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ReportService_CreateReport_FullMethodName   = "/splitwise.v1.ReportService/CreateReport"
+	ReportService_GetReport_FullMethodName      = "/splitwise.v1.ReportService/GetReport"
+	ReportService_CancelReport_FullMethodName   = "/splitwise.v1.ReportService/CancelReport"
+	ReportService_DownloadReport_FullMethodName = "/splitwise.v1.ReportService/DownloadReport"
+)
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReportServiceClient interface {
+	// CreateReport queues a report. Poll GetReport until it is done, then download it.
+	CreateReport(ctx context.Context, in *CreateReportRequest, opts ...grpc.CallOption) (*CreateReportResponse, error)
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+	// CancelReport cancels a queued or running report.
+	CancelReport(ctx context.Context, in *CancelReportRequest, opts ...grpc.CallOption) (*CancelReportResponse, error)
+	// DownloadReport streams the file of a finished report. The first message carries the file name
+	// and content type.
+	DownloadReport(ctx context.Context, in *DownloadReportRequest, opts ...grpc.CallOption) (ReportService_DownloadReportClient, error)
+}
+
+type reportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportServiceClient(cc grpc.ClientConnInterface) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) CreateReport(ctx context.Context, in *CreateReportRequest, opts ...grpc.CallOption) (*CreateReportResponse, error) {
+	out := new(CreateReportResponse)
+	err := c.cc.Invoke(ctx, ReportService_CreateReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error) {
+	out := new(GetReportResponse)
+	err := c.cc.Invoke(ctx, ReportService_GetReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) CancelReport(ctx context.Context, in *CancelReportRequest, opts ...grpc.CallOption) (*CancelReportResponse, error) {
+	out := new(CancelReportResponse)
+	err := c.cc.Invoke(ctx, ReportService_CancelReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) DownloadReport(ctx context.Context, in *DownloadReportRequest, opts ...grpc.CallOption) (ReportService_DownloadReportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ReportService_ServiceDesc.Streams[0], ReportService_DownloadReport_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &reportServiceDownloadReportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReportService_DownloadReportClient interface {
+	Recv() (*DownloadReportResponse, error)
+	grpc.ClientStream
+}
+
+type reportServiceDownloadReportClient struct {
+	grpc.ClientStream
+}
+
+func (x *reportServiceDownloadReportClient) Recv() (*DownloadReportResponse, error) {
+	m := new(DownloadReportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReportServiceServer is the server API for ReportService service.
+// All implementations must embed UnimplementedReportServiceServer
+// for forward compatibility
+type ReportServiceServer interface {
+	// CreateReport queues a report. Poll GetReport until it is done, then download it.
+	CreateReport(context.Context, *CreateReportRequest) (*CreateReportResponse, error)
+	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	// CancelReport cancels a queued or running report.
+	CancelReport(context.Context, *CancelReportRequest) (*CancelReportResponse, error)
+	// DownloadReport streams the file of a finished report. The first message carries the file name
+	// and content type.
+	DownloadReport(*DownloadReportRequest, ReportService_DownloadReportServer) error
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+// UnimplementedReportServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReportServiceServer struct {
+}
+
+func (UnimplementedReportServiceServer) CreateReport(context.Context, *CreateReportRequest) (*CreateReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReport not implemented")
+}
+func (UnimplementedReportServiceServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedReportServiceServer) CancelReport(context.Context, *CancelReportRequest) (*CancelReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReport not implemented")
+}
+func (UnimplementedReportServiceServer) DownloadReport(*DownloadReportRequest, ReportService_DownloadReportServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadReport not implemented")
+}
+func (UnimplementedReportServiceServer) mustEmbedUnimplementedReportServiceServer() {}
+
+// UnsafeReportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportServiceServer will
+// result in compilation errors.
+type UnsafeReportServiceServer interface {
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+func RegisterReportServiceServer(s grpc.ServiceRegistrar, srv ReportServiceServer) {
+	s.RegisterService(&ReportService_ServiceDesc, srv)
+}
+
+func _ReportService_CreateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).CreateReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_CreateReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).CreateReport(ctx, req.(*CreateReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_CancelReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).CancelReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_CancelReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).CancelReport(ctx, req.(*CancelReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_DownloadReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadReportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReportServiceServer).DownloadReport(m, &reportServiceDownloadReportServer{stream})
+}
+
+type ReportService_DownloadReportServer interface {
+	Send(*DownloadReportResponse) error
+	grpc.ServerStream
+}
+
+type reportServiceDownloadReportServer struct {
+	grpc.ServerStream
+}
+
+func (x *reportServiceDownloadReportServer) Send(m *DownloadReportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ReportService_ServiceDesc is the grpc.ServiceDesc for ReportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "splitwise.v1.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateReport",
+			Handler:    _ReportService_CreateReport_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _ReportService_GetReport_Handler,
+		},
+		{
+			MethodName: "CancelReport",
+			Handler:    _ReportService_CancelReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadReport",
+			Handler:       _ReportService_DownloadReport_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "splitwise/v1/reports.proto",
+}
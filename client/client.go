@@ -0,0 +1,191 @@
+// Package client is a Go client for the SplitWise REST API. It covers logging in, groups, bills,
+// members, payments, balances and background reports.
+//
+//	c := client.New("http://localhost:8080", "")
+//	token, err := c.Login(ctx, "jis@jish.com", "Passw0rd@123")
+//	c.Token = token
+//	groups, err := c.MemberGroups(ctx, client.ListOptions{Status: "PENDING"})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of a server started locally with the default config.
+const DefaultBaseURL = "http://localhost:8080"
+
+// Client calls the REST API with the bearer token of a user.
+type Client struct {
+	BaseURL    string
+	Token      string
+	Language   string // sent as Accept-Language, so error messages come back translated
+	HTTPClient *http.Client
+}
+
+// New returns a client for the server at baseURL. The token may be empty to log in first.
+func New(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Error is an error answered by the server, with the code and message of the API error.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d %s: %s", e.Status, http.StatusText(e.Status), e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// newRequest builds a request to path, with body encoded as JSON when it is not nil.
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(buf)
+	}
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if c.Language != "" {
+		req.Header.Set("Accept-Language", c.Language)
+	}
+	return req, nil
+}
+
+// send sends the request and returns the response when its status is 2xx, or the API error.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	apiErr := &Error{Status: resp.StatusCode}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if json.Unmarshal(raw, apiErr) != nil || apiErr.Message == "" {
+		apiErr.Code = ""
+		apiErr.Message = strings.TrimSpace(string(raw))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	return nil, apiErr
+}
+
+// do sends a request and decodes the JSON response into out, unless out is nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// Login exchanges the email and password of a user for a token. The client keeps using its own
+// token, set Token to use the new one.
+func (c *Client) Login(ctx context.Context, email, password string) (string, error) {
+	var resp struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"email": email, "password": password}
+	if err := c.do(ctx, http.MethodPost, "/auth/login", nil, body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// Me returns the account of the user of the token.
+func (c *Client) Me(ctx context.Context) (User, error) {
+	var user User
+	err := c.do(ctx, http.MethodGet, "/v1/me", nil, nil, &user)
+	return user, err
+}
+
+// ListOptions pages and filters the group and pending payment lists.
+type ListOptions struct {
+	Limit  int    // page size, 20 by default and at most 100
+	Cursor string // NextCursor of the previous page
+	Sort   string // createdAt, amount or name, with a leading - for descending order
+	Status string // PENDING or DONE, groups only
+	Query  string // part of the group name
+}
+
+func (o ListOptions) values() url.Values {
+	q := url.Values{}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	for key, value := range map[string]string{"cursor": o.Cursor, "sort": o.Sort, "status": o.Status, "q": o.Query} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	return q
+}
+
+func id(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// fileName reads the file name of a download from its Content-Disposition header.
+func fileName(resp *http.Response) string {
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+	if err != nil {
+		return ""
+	}
+	return params["filename"]
+}
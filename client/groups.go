@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateGroup creates a group with its bill.
+func (c *Client) CreateGroup(ctx context.Context, input CreateGroupRequest) (CreateGroupResponse, error) {
+	var resp CreateGroupResponse
+	err := c.do(ctx, http.MethodPost, "/v1/groups", nil, input, &resp)
+	return resp, err
+}
+
+// Group returns a group the user is a member of, with its bills and members.
+func (c *Client) Group(ctx context.Context, groupID uint) (GroupDetail, error) {
+	var group GroupDetail
+	err := c.do(ctx, http.MethodGet, "/v1/groups/"+id(groupID), nil, nil, &group)
+	return group, err
+}
+
+// OwnedGroups returns a page of the groups created by the user.
+func (c *Client) OwnedGroups(ctx context.Context, opts ListOptions) (GroupPage, error) {
+	var page GroupPage
+	err := c.do(ctx, http.MethodGet, "/v1/groups/owned", opts.values(), nil, &page)
+	return page, err
+}
+
+// MemberGroups returns a page of the groups the user is a member of.
+func (c *Client) MemberGroups(ctx context.Context, opts ListOptions) (GroupPage, error) {
+	var page GroupPage
+	err := c.do(ctx, http.MethodGet, "/v1/groups/member-groups", opts.values(), nil, &page)
+	return page, err
+}
+
+// DeleteGroup deletes a group created by the user.
+func (c *Client) DeleteGroup(ctx context.Context, groupID uint) error {
+	return c.do(ctx, http.MethodDelete, "/v1/groups/"+id(groupID), nil, nil, nil)
+}
+
+// AddMembers adds the users with the given emails to a group created by the user.
+func (c *Client) AddMembers(ctx context.Context, groupID uint, emails []string) error {
+	body := map[string][]string{"userEmailIds": emails}
+	return c.do(ctx, http.MethodPost, "/v1/groups/"+id(groupID)+"/addMembers", nil, body, nil)
+}
+
+// UpdateBill changes the name or category of a bill of a group created by the user.
+func (c *Client) UpdateBill(ctx context.Context, groupID, billID uint, input UpdateBillRequest) (Bill, error) {
+	var bill Bill
+	err := c.do(ctx, http.MethodPatch, "/v1/groups/"+id(groupID)+"/bills/"+id(billID), nil, input, &bill)
+	return bill, err
+}
+
+// AllGroups walks every page of a group list, owned or member groups, with the filters of opts.
+func (c *Client) AllGroups(ctx context.Context, owned bool, opts ListOptions) ([]GroupListItem, error) {
+	list := c.MemberGroups
+	if owned {
+		list = c.OwnedGroups
+	}
+	opts.Cursor = ""
+	if opts.Limit == 0 {
+		opts.Limit = maxPageSize
+	}
+	var groups []GroupListItem
+	for {
+		page, err := list(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, page.Groups...)
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == "" {
+			return groups, nil
+		}
+		opts.Cursor = page.Pagination.NextCursor
+	}
+}
+
+// Categories returns the names of the predefined categories and of the custom categories of the user.
+func (c *Client) Categories(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Predefined []string `json:"predefined"`
+		Custom     []struct {
+			Name string `json:"name"`
+		} `json:"custom"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v1/categories", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	names := resp.Predefined
+	for _, custom := range resp.Custom {
+		names = append(names, custom.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// maxPageSize is the largest page the list endpoints return.
+const maxPageSize = 100
+
+// MarkPayment marks the share of the user in a group as paid.
+func (c *Client) MarkPayment(ctx context.Context, groupID uint, remarks string) error {
+	body := map[string]interface{}{"groupId": groupID, "remarks": remarks}
+	return c.do(ctx, http.MethodPost, "/v1/payments", nil, body, nil)
+}
+
+// PendingPayments returns a page of the shares the user has not paid yet.
+func (c *Client) PendingPayments(ctx context.Context, opts ListOptions) (PendingPaymentPage, error) {
+	var page PendingPaymentPage
+	err := c.do(ctx, http.MethodGet, "/v1/payments/pending", opts.values(), nil, &page)
+	return page, err
+}
+
+// Balance adds up what the user owes and what is owed to them over every pending group.
+func (c *Client) Balance(ctx context.Context) (Balance, error) {
+	var b Balance
+	me, err := c.Me(ctx)
+	if err != nil {
+		return b, err
+	}
+	groups := map[uint]*GroupBalance{}
+	var order []uint
+	balanceOf := func(groupID uint, name string) *GroupBalance {
+		if g, ok := groups[groupID]; ok {
+			return g
+		}
+		groups[groupID] = &GroupBalance{GroupID: groupID, GroupName: name}
+		order = append(order, groupID)
+		return groups[groupID]
+	}
+
+	opts := ListOptions{Limit: maxPageSize}
+	for {
+		page, err := c.PendingPayments(ctx, opts)
+		if err != nil {
+			return b, err
+		}
+		for _, p := range page.PendingPayments {
+			balanceOf(p.GroupID, p.GroupName).Owed += p.Amount
+			b.Owed += p.Amount
+		}
+		if !page.Pagination.HasMore || page.Pagination.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.Pagination.NextCursor
+	}
+
+	owned, err := c.AllGroups(ctx, true, ListOptions{Status: "PENDING"})
+	if err != nil {
+		return b, err
+	}
+	for _, item := range owned {
+		for _, m := range item.Members {
+			if m.UserID != me.ID && !m.HasPaid {
+				balanceOf(item.Group.ID, item.Group.Name).OwedToYou += m.SplitAmount
+				b.OwedToYou += m.SplitAmount
+			}
+		}
+	}
+
+	b.Net = b.OwedToYou - b.Owed
+	b.Groups = make([]GroupBalance, 0, len(order))
+	for _, groupID := range order {
+		b.Groups = append(b.Groups, *groups[groupID])
+	}
+	return b, nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CreateReport queues a report. Poll it with Report or WaitReport, then download it.
+func (c *Client) CreateReport(ctx context.Context, input ReportRequest) (Report, error) {
+	var report Report
+	err := c.do(ctx, http.MethodPost, "/v1/reports", nil, input, &report)
+	return report, err
+}
+
+// Report returns the status of a report.
+func (c *Client) Report(ctx context.Context, jobID uint) (Report, error) {
+	var report Report
+	err := c.do(ctx, http.MethodGet, "/v1/reports/"+id(jobID), nil, nil, &report)
+	return report, err
+}
+
+// Reports returns a page of the reports of the user, newest first.
+func (c *Client) Reports(ctx context.Context, limit, offset int) (ReportList, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+	var list ReportList
+	err := c.do(ctx, http.MethodGet, "/v1/reports", q, nil, &list)
+	return list, err
+}
+
+// CancelReport cancels a queued or running report.
+func (c *Client) CancelReport(ctx context.Context, jobID uint) (Report, error) {
+	var report Report
+	err := c.do(ctx, http.MethodPost, "/v1/reports/"+id(jobID)+"/cancel", nil, nil, &report)
+	return report, err
+}
+
+// WaitReport polls a report every interval until it is finished or ctx is done.
+func (c *Client) WaitReport(ctx context.Context, jobID uint, interval time.Duration) (Report, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		report, err := c.Report(ctx, jobID)
+		if err != nil || report.Finished() {
+			return report, err
+		}
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DownloadReport writes the file of a finished report to w and returns its file name.
+func (c *Client) DownloadReport(ctx context.Context, jobID uint, w io.Writer) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/reports/"+id(jobID)+"/download", nil, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return "", err
+	}
+	return fileName(resp), nil
+}
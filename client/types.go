@@ -0,0 +1,253 @@
+package client
+
+import "time"
+
+// User is the account of the current user.
+type User struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	Role            string    `json:"role"`
+	Locale          string    `json:"locale,omitempty"`
+	Timezone        string    `json:"timezone,omitempty"`
+	EmailVisibility string    `json:"emailVisibility"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// Group is a group as listed, with the totals of its bill.
+type Group struct {
+	ID                 uint      `json:"ID"`
+	Name               string    `json:"name"`
+	Status             string    `json:"status"`
+	CreatedBy          uint      `json:"createdBy"`
+	CreatedAt          time.Time `json:"createdAt"`
+	BillID             uint      `json:"billId"`
+	TotalAmount        float64   `json:"totalAmount"`
+	PerUserSplitAmount float64   `json:"perUserSplitAmount"`
+	PaidAmount         float64   `json:"paidAmount"`
+}
+
+// Member is the share of a user in a listed group.
+type Member struct {
+	GroupID     uint       `json:"groupId"`
+	UserID      uint       `json:"userId"`
+	HasPaid     bool       `json:"hasPaid"`
+	PaidAt      *time.Time `json:"paidAt,omitempty"`
+	SplitAmount float64    `json:"splitAmount"`
+	Remarks     string     `json:"remarks"`
+}
+
+// BudgetStatus compares a budget of a group with its bills.
+type BudgetStatus struct {
+	ID          uint    `json:"id"`
+	Category    string  `json:"category,omitempty"`
+	Amount      float64 `json:"amount"`
+	Spent       float64 `json:"spent"`
+	Remaining   float64 `json:"remaining"`
+	PercentUsed float64 `json:"percentUsed"`
+	Status      string  `json:"status"`
+}
+
+// GroupListItem is a group of a list with its members and budgets.
+type GroupListItem struct {
+	Group   Group          `json:"group"`
+	Members []Member       `json:"members"`
+	Budgets []BudgetStatus `json:"budgets"`
+}
+
+// Pagination tells whether a list has more pages. Pass NextCursor as ListOptions.Cursor to get
+// the next one.
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+	HasMore    bool   `json:"hasMore"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// GroupPage is a page of groups.
+type GroupPage struct {
+	Groups     []GroupListItem `json:"groups"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// GroupDetail is a group as seen by one of its members, with its bills and members.
+type GroupDetail struct {
+	ID                 uint           `json:"id"`
+	Name               string         `json:"name"`
+	Status             string         `json:"status"`
+	CreatedBy          uint           `json:"createdBy"`
+	CreatedAt          time.Time      `json:"createdAt"`
+	TotalAmount        float64        `json:"totalAmount"`
+	PerUserSplitAmount float64        `json:"perUserSplitAmount"`
+	PaidAmount         float64        `json:"paidAmount"`
+	Bills              []GroupBill    `json:"bills"`
+	Members            []MemberDetail `json:"members"`
+	Budgets            []BudgetStatus `json:"budgets"`
+}
+
+// GroupBill is a bill of a group with its payments.
+type GroupBill struct {
+	ID        uint          `json:"id"`
+	Name      string        `json:"name"`
+	Category  string        `json:"category"`
+	Amount    float64       `json:"amount"`
+	Completed bool          `json:"completed"`
+	CreatedAt time.Time     `json:"createdAt"`
+	History   []BillPayment `json:"history"`
+}
+
+// BillPayment is a payment made towards a bill.
+type BillPayment struct {
+	ID       uint      `json:"id"`
+	Amount   float64   `json:"amount"`
+	PaidBy   string    `json:"paidBy"`
+	PaidByID uint      `json:"paidById"`
+	PaidAt   time.Time `json:"paidAt"`
+}
+
+// MemberDetail is a member of a group with their name. Email is only set when the member shares it.
+type MemberDetail struct {
+	UserID      uint       `json:"userId"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email,omitempty"`
+	IsOwner     bool       `json:"isOwner"`
+	SplitAmount float64    `json:"splitAmount"`
+	HasPaid     bool       `json:"hasPaid"`
+	PaidAt      *time.Time `json:"paidAt,omitempty"`
+	Remarks     string     `json:"remarks,omitempty"`
+	JoinedAt    time.Time  `json:"joinedAt"`
+}
+
+// CreateGroupRequest creates a group with its bill. The bill is split between the members added
+// to the group.
+type CreateGroupRequest struct {
+	GroupName string      `json:"groupName"`
+	Bill      BillRequest `json:"bill"`
+}
+
+// BillRequest is the bill of a new group. Category is a predefined or custom category, the bill
+// is uncategorised when it is empty.
+type BillRequest struct {
+	Name     string  `json:"name"`
+	Amount   float64 `json:"amount"`
+	Category string  `json:"category,omitempty"`
+}
+
+// CreateGroupResponse holds the ids of a new group and its bill.
+type CreateGroupResponse struct {
+	GroupID uint   `json:"groupId"`
+	BillID  uint   `json:"billId"`
+	Message string `json:"message"`
+}
+
+// UpdateBillRequest changes the name or category of a bill. Nil fields are left unchanged.
+type UpdateBillRequest struct {
+	Name     *string `json:"name,omitempty"`
+	Category *string `json:"category,omitempty"`
+}
+
+// Bill is a bill after an update.
+type Bill struct {
+	Name      string  `json:"name"`
+	Category  string  `json:"category"`
+	Amount    float64 `json:"amount"`
+	GroupID   uint    `json:"groupId"`
+	Completed bool    `json:"completed"`
+}
+
+// PendingPayment is the unpaid share of the user in a group.
+type PendingPayment struct {
+	GroupID    uint      `json:"groupId"`
+	GroupName  string    `json:"groupName"`
+	BillID     uint      `json:"billId"`
+	Amount     float64   `json:"amount"`
+	BillAmount float64   `json:"billAmount"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// PendingPaymentPage is a page of pending payments. TotalAmount covers every page.
+type PendingPaymentPage struct {
+	PendingPayments []PendingPayment `json:"pendingPayments"`
+	TotalAmount     float64          `json:"totalAmount"`
+	Message         string           `json:"message,omitempty"`
+	Pagination      Pagination       `json:"pagination"`
+}
+
+// Balance is what the user owes in the groups they are a member of and what the other members
+// owe them in the groups they created.
+type Balance struct {
+	Owed      float64        `json:"owed"`
+	OwedToYou float64        `json:"owedToYou"`
+	Net       float64        `json:"net"` // OwedToYou minus Owed
+	Groups    []GroupBalance `json:"groups"`
+}
+
+// GroupBalance is the balance of the user in one group.
+type GroupBalance struct {
+	GroupID   uint    `json:"groupId"`
+	GroupName string  `json:"groupName"`
+	Owed      float64 `json:"owed"`
+	OwedToYou float64 `json:"owedToYou"`
+}
+
+// ReportRequest queues a report. Type is groups, group or statement; Format is pdf (default),
+// csv, xlsx or html, and pdf or json for statements. Dates are YYYY-MM-DD and default to the last
+// seven days.
+type ReportRequest struct {
+	Type     string  `json:"type"`
+	Format   string  `json:"format,omitempty"`
+	From     *string `json:"from,omitempty"`
+	To       *string `json:"to,omitempty"`
+	GroupID  uint    `json:"groupId,omitempty"`
+	Comments bool    `json:"comments,omitempty"`
+	Charts   *bool   `json:"charts,omitempty"`
+}
+
+// ReportParams are the parameters a report was queued with.
+type ReportParams struct {
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	GroupID  uint   `json:"groupId,omitempty"`
+	Comments bool   `json:"comments,omitempty"`
+	NoCharts bool   `json:"noCharts,omitempty"`
+}
+
+// Report statuses
+const (
+	ReportPending    = "PENDING"
+	ReportProcessing = "PROCESSING"
+	ReportDone       = "DONE"
+	ReportFailed     = "FAILED"
+	ReportCancelled  = "CANCELLED"
+	ReportExpired    = "EXPIRED"
+)
+
+// Report is a report generated in the background.
+type Report struct {
+	JobID       uint         `json:"jobId"`
+	Type        string       `json:"type"`
+	Format      string       `json:"format"`
+	Params      ReportParams `json:"params"`
+	Status      string       `json:"status"`
+	StatusURL   string       `json:"statusUrl"`
+	DownloadURL string       `json:"downloadUrl,omitempty"`
+	FileName    string       `json:"fileName,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	StartedAt   *time.Time   `json:"startedAt,omitempty"`
+	CompletedAt *time.Time   `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
+}
+
+// Finished tells whether the report will not change status anymore, other than expiring.
+func (r Report) Finished() bool {
+	return r.Status != ReportPending && r.Status != ReportProcessing
+}
+
+// ReportList is a page of the reports of the user, newest first.
+type ReportList struct {
+	Jobs   []Report `json:"jobs"`
+	Total  int64    `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+}
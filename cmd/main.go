@@ -1,9 +1,11 @@
 package main
 
 import (
+	_ "time/tzdata" // timezone preferences work on hosts without a zoneinfo database
+
 	_ "github.com/mohdjishin/SplitWise/docs"
 	"github.com/mohdjishin/SplitWise/internal/app"
-	_ "github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/db"
 	log "github.com/mohdjishin/SplitWise/logger"
 	"go.uber.org/zap/zapcore"
 )
@@ -14,6 +16,8 @@ import (
 // @host localhost:8080
 // @BasePath /
 func main() {
+	// Connect and migrate before anything else starts
+	db.GetDb()
 	app := app.New()
 	if err := app.Run(); err != nil {
 		log.Error("Error starting server", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
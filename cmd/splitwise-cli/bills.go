@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"text/tabwriter"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+)
+
+func newBillsCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bills",
+		Aliases: []string{"bill"},
+		Short:   "List and edit the bills of a group",
+		Long:    "Lists and edits the bills of a group. A bill is created together with its group, see groups create.",
+	}
+	cmd.AddCommand(newBillsCreateCmd(a), newBillsListCmd(a), newBillsUpdateCmd(a))
+	return cmd
+}
+
+// newBillsCreateCmd is groups create under the name users look for when they want to split a bill.
+func newBillsCreateCmd(a *app) *cobra.Command {
+	cmd := newGroupsCreateCmd(a)
+	cmd.Use = "create GROUP_NAME"
+	cmd.Short = "Create a bill with the group to split it in"
+	return cmd
+}
+
+func newBillsListCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "list GROUP_ID",
+		Aliases:           []string{"ls"},
+		Short:             "List the bills of a group with their payments",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeGroups(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, err := parseID("group", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			group, err := c.Group(cmd.Context(), groupID)
+			if err != nil {
+				return err
+			}
+			return a.print(group.Bills, func(t *tabwriter.Writer) {
+				row(t, "ID", "NAME", "CATEGORY", "AMOUNT", "PAID", "COMPLETED", "CREATED")
+				for _, b := range group.Bills {
+					var paid float64
+					for _, p := range b.History {
+						paid += p.Amount
+					}
+					row(t, b.ID, b.Name, b.Category, amount(b.Amount), amount(paid), yesNo(b.Completed), date(b.CreatedAt))
+				}
+			})
+		},
+	}
+}
+
+func newBillsUpdateCmd(a *app) *cobra.Command {
+	var name, category string
+	cmd := &cobra.Command{
+		Use:               "update GROUP_ID BILL_ID",
+		Short:             "Rename or recategorise a bill of a group you created",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: a.completeGroups(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, err := parseID("group", args[0])
+			if err != nil {
+				return err
+			}
+			billID, err := parseID("bill", args[1])
+			if err != nil {
+				return err
+			}
+			var input client.UpdateBillRequest
+			if cmd.Flags().Changed("name") {
+				input.Name = &name
+			}
+			if cmd.Flags().Changed("category") {
+				input.Category = &category
+			}
+			if input.Name == nil && input.Category == nil {
+				return errors.New("nothing to update, pass --name or --category")
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			bill, err := c.UpdateBill(cmd.Context(), groupID, billID, input)
+			if err != nil {
+				return err
+			}
+			return a.print(bill, func(t *tabwriter.Writer) {
+				row(t, "NAME", "CATEGORY", "AMOUNT", "COMPLETED")
+				row(t, bill.Name, bill.Category, amount(bill.Amount), yesNo(bill.Completed))
+			})
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "new name of the bill")
+	cmd.Flags().StringVar(&category, "category", "", "new category of the bill, empty for uncategorised")
+	_ = cmd.RegisterFlagCompletionFunc("category", a.completeCategories)
+	return cmd
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the requests made while completing, so a slow server does not hang the shell.
+const completionTimeout = 3 * time.Second
+
+// completeGroups completes a group id with the pending groups the user is a member of, or created
+// when owned is set. Nothing is offered when the user is not logged in or the server is down.
+func (a *app) completeGroups(owned bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		c, err := a.completionClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+		defer cancel()
+		list := c.MemberGroups
+		if owned {
+			list = c.OwnedGroups
+		}
+		page, err := list(ctx, client.ListOptions{Limit: 100, Status: "PENDING"})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		ids := make([]string, 0, len(page.Groups))
+		for _, item := range page.Groups {
+			ids = append(ids, fmt.Sprintf("%d\t%s", item.Group.ID, item.Group.Name))
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeCategories completes a bill category with the predefined and custom categories.
+func (a *app) completeCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c, err := a.completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+	names, err := c.Categories(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionClient returns a client while completing, where the config has not been loaded by the
+// hooks of the root command.
+func (a *app) completionClient() (*client.Client, error) {
+	if err := a.loadConfig(); err != nil {
+		return nil, err
+	}
+	return a.client()
+}
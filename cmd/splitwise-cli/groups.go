@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"text/tabwriter"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+)
+
+func newGroupsCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "groups",
+		Aliases: []string{"group"},
+		Short:   "List, show, create and delete groups and add members",
+	}
+	cmd.AddCommand(
+		newGroupsListCmd(a),
+		newGroupsShowCmd(a),
+		newGroupsCreateCmd(a),
+		newGroupsDeleteCmd(a),
+		newGroupsAddMembersCmd(a),
+	)
+	return cmd
+}
+
+// addListFlags adds the paging and filter flags of the list commands.
+func addListFlags(cmd *cobra.Command, opts *client.ListOptions, all *bool) {
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "page size, 20 by default and at most 100")
+	cmd.Flags().StringVar(&opts.Cursor, "cursor", "", "cursor of the page to show, printed after the previous one")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "", "createdAt, amount or name, with a leading - for descending order")
+	cmd.Flags().StringVarP(&opts.Query, "query", "q", "", "part of the group name")
+	cmd.Flags().BoolVar(all, "all", false, "fetch every page")
+	_ = cmd.RegisterFlagCompletionFunc("sort", fixedCompletion("createdAt", "-createdAt", "amount", "-amount", "name", "-name"))
+}
+
+func newGroupsListCmd(a *app) *cobra.Command {
+	var opts client.ListOptions
+	var owned, all bool
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the groups you are a member of, or created with --owned",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			var page client.GroupPage
+			if all {
+				page.Groups, err = c.AllGroups(cmd.Context(), owned, opts)
+			} else if owned {
+				page, err = c.OwnedGroups(cmd.Context(), opts)
+			} else {
+				page, err = c.MemberGroups(cmd.Context(), opts)
+			}
+			if err != nil {
+				return err
+			}
+			return a.print(page, func(t *tabwriter.Writer) {
+				row(t, "ID", "NAME", "STATUS", "TOTAL", "SHARE", "PAID", "MEMBERS", "CREATED")
+				for _, item := range page.Groups {
+					g := item.Group
+					row(t, g.ID, g.Name, g.Status, amount(g.TotalAmount), amount(g.PerUserSplitAmount), amount(g.PaidAmount), len(item.Members), date(g.CreatedAt))
+				}
+				if page.Pagination.HasMore {
+					row(t)
+					row(t, "More groups: --cursor "+page.Pagination.NextCursor)
+				}
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&owned, "owned", false, "only the groups you created")
+	cmd.Flags().StringVar(&opts.Status, "status", "", "PENDING or DONE")
+	_ = cmd.RegisterFlagCompletionFunc("status", fixedCompletion("PENDING", "DONE"))
+	addListFlags(cmd, &opts, &all)
+	return cmd
+}
+
+func newGroupsShowCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "show GROUP_ID",
+		Short:             "Show a group with its bills and members",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeGroups(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, err := parseID("group", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			group, err := c.Group(cmd.Context(), groupID)
+			if err != nil {
+				return err
+			}
+			return a.print(group, func(t *tabwriter.Writer) {
+				row(t, "GROUP", group.ID, group.Name)
+				row(t, "STATUS", group.Status)
+				row(t, "TOTAL", amount(group.TotalAmount))
+				row(t, "SHARE", amount(group.PerUserSplitAmount))
+				row(t, "PAID", amount(group.PaidAmount))
+				row(t)
+				row(t, "BILL", "NAME", "CATEGORY", "AMOUNT", "COMPLETED")
+				for _, b := range group.Bills {
+					row(t, b.ID, b.Name, b.Category, amount(b.Amount), yesNo(b.Completed))
+				}
+				row(t)
+				row(t, "USER", "NAME", "EMAIL", "SHARE", "PAID", "PAID AT", "REMARKS")
+				for _, m := range group.Members {
+					name := m.Name
+					if m.IsOwner {
+						name += " (owner)"
+					}
+					row(t, m.UserID, name, m.Email, amount(m.SplitAmount), yesNo(m.HasPaid), optionalDate(m.PaidAt), m.Remarks)
+				}
+			})
+		},
+	}
+}
+
+func newGroupsCreateCmd(a *app) *cobra.Command {
+	var input client.CreateGroupRequest
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a group with its bill",
+		Long:  "Creates a group with the bill to split. The bill is split between the members added to the group.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input.GroupName = args[0]
+			if input.Bill.Amount <= 0 {
+				return errors.New("--amount must be greater than zero")
+			}
+			if input.Bill.Name == "" {
+				input.Bill.Name = input.GroupName
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			resp, err := c.CreateGroup(cmd.Context(), input)
+			if err != nil {
+				return err
+			}
+			return a.print(resp, func(t *tabwriter.Writer) {
+				row(t, "Created group", resp.GroupID, "with bill", resp.BillID)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&input.Bill.Name, "bill", "", "name of the bill (defaults to the group name)")
+	cmd.Flags().Float64Var(&input.Bill.Amount, "amount", 0, "amount of the bill")
+	cmd.Flags().StringVar(&input.Bill.Category, "category", "", "category of the bill")
+	_ = cmd.MarkFlagRequired("amount")
+	_ = cmd.RegisterFlagCompletionFunc("category", a.completeCategories)
+	return cmd
+}
+
+func newGroupsDeleteCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete GROUP_ID",
+		Aliases:           []string{"rm"},
+		Short:             "Delete a group you created",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeGroups(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, err := parseID("group", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			if err := c.DeleteGroup(cmd.Context(), groupID); err != nil {
+				return err
+			}
+			return a.message("Deleted group %d", groupID)
+		},
+	}
+}
+
+func newGroupsAddMembersCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-members GROUP_ID EMAIL...",
+		Short: "Add users to a group you created by their email",
+		Args:  cobra.MinimumNArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return a.completeGroups(true)(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, err := parseID("group", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			if err := c.AddMembers(cmd.Context(), groupID, args[1:]); err != nil {
+				return err
+			}
+			return a.message("Added %d members to group %d", len(args)-1, groupID)
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newLoginCmd(a *app) *cobra.Command {
+	var email string
+	var passwordStdin bool
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in and store the token in the config file",
+		Long: "Logs in with an email and password and stores the token in the config file, readable only by you. " +
+			"The password is prompted for without echo, or read from stdin with --password-stdin.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := bufio.NewReader(os.Stdin)
+			if email == "" {
+				email = a.cfg.Email
+			}
+			if email == "" {
+				if passwordStdin {
+					return errors.New("--email is required with --password-stdin")
+				}
+				fmt.Fprint(os.Stderr, "Email: ")
+				line, err := in.ReadString('\n')
+				if err != nil {
+					return err
+				}
+				email = strings.TrimSpace(line)
+			}
+			password, err := readPassword(in, passwordStdin)
+			if err != nil {
+				return err
+			}
+
+			server := a.serverURL()
+			c := client.New(server, "")
+			c.Language = a.language
+			token, err := c.Login(cmd.Context(), email, password)
+			if err != nil {
+				return err
+			}
+			a.cfg = config{Server: server, Email: email, Token: token}
+			if err := saveConfig(a.configPath, a.cfg); err != nil {
+				return fmt.Errorf("save token: %w", err)
+			}
+			return a.message("Logged in to %s as %s", server, email)
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "email of the account (defaults to the last one used)")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "read the password from stdin")
+	return cmd
+}
+
+// readPassword prompts for the password without echo on a terminal, or reads the first line of
+// stdin otherwise.
+func readPassword(in *bufio.Reader, fromStdin bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !fromStdin && term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Password: ")
+		raw, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(raw), err
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password on stdin")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func newLogoutCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the token from the config file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if a.cfg.Token == "" {
+				return a.message("Not logged in")
+			}
+			a.cfg.Token = ""
+			if err := saveConfig(a.configPath, a.cfg); err != nil {
+				return err
+			}
+			return a.message("Logged out of %s", a.cfg.Server)
+		},
+	}
+}
+
+func newWhoamiCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the account of the stored token",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			user, err := c.Me(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return a.print(user, func(t *tabwriter.Writer) {
+				row(t, "ID", user.ID)
+				row(t, "NAME", user.Name)
+				row(t, "EMAIL", user.Email)
+				row(t, "SERVER", a.serverURL())
+			})
+		},
+	}
+}
@@ -0,0 +1,139 @@
+// Command splitwise-cli works with a SplitWise server from the terminal: create groups, add
+// members, mark payments, check what is pending and download reports.
+//
+//	splitwise-cli login --server http://localhost:8080 --email jis@jish.com
+//	splitwise-cli groups create "Goa trip" --bill Hotel --amount 1200 --category travel
+//	splitwise-cli groups add-members 42 a@example.com b@example.com
+//	splitwise-cli pending -o json
+//	splitwise-cli reports create --type group --group 42 --wait --download goa.pdf
+//
+// The token is kept in the config file (see --config) readable only by the user. Completion
+// scripts are printed by the completion command.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables that take precedence over the config file.
+const (
+	envServer = "SPLITWISE_SERVER"
+	envToken  = "SPLITWISE_TOKEN"
+)
+
+// app holds the global flags and the config shared by every command.
+type app struct {
+	configPath string
+	server     string
+	output     string
+	language   string
+
+	cfg config
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := newRootCmd().ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	a := &app{}
+	root := &cobra.Command{
+		Use:           "splitwise-cli",
+		Short:         "Split bills with SplitWise from the terminal",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if a.output != outputTable && a.output != outputJSON {
+				return fmt.Errorf("unknown output %q, use %s or %s", a.output, outputTable, outputJSON)
+			}
+			return a.loadConfig()
+		},
+	}
+	defaultConfig, _ := defaultConfigPath()
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", defaultConfig, "config file holding the server and token")
+	flags.StringVar(&a.server, "server", "", "server URL (defaults to $"+envServer+", then the config file, then "+client.DefaultBaseURL+")")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format, table or json")
+	flags.StringVar(&a.language, "lang", "", "language of the error messages, e.g. de")
+	_ = root.RegisterFlagCompletionFunc("output", fixedCompletion(outputTable, outputJSON))
+
+	root.AddCommand(
+		newLoginCmd(a),
+		newLogoutCmd(a),
+		newWhoamiCmd(a),
+		newGroupsCmd(a),
+		newBillsCmd(a),
+		newPayCmd(a),
+		newPendingCmd(a),
+		newBalanceCmd(a),
+		newReportsCmd(a),
+	)
+	return root
+}
+
+func (a *app) loadConfig() error {
+	if a.configPath == "" {
+		return errors.New("cannot find a config directory, pass --config")
+	}
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+	a.cfg = cfg
+	return nil
+}
+
+// serverURL is the server of the --server flag, the environment or the config file, in that order.
+func (a *app) serverURL() string {
+	for _, s := range []string{a.server, os.Getenv(envServer), a.cfg.Server} {
+		if s != "" {
+			return s
+		}
+	}
+	return client.DefaultBaseURL
+}
+
+// client returns a client for the server with the token of the environment or the config file. The
+// stored token is only sent to the server it was issued by, so pointing --server or $SPLITWISE_SERVER
+// elsewhere cannot leak it.
+func (a *app) client() (*client.Client, error) {
+	server := a.serverURL()
+	token := os.Getenv(envToken)
+	if token == "" && a.cfg.Token != "" {
+		if !sameServer(server, a.cfg.Server) {
+			return nil, fmt.Errorf("logged in to %s, not %s: run splitwise-cli login --server %s or set %s", a.cfg.Server, server, server, envToken)
+		}
+		token = a.cfg.Token
+	}
+	if token == "" {
+		return nil, errors.New("not logged in, run splitwise-cli login first")
+	}
+	c := client.New(server, token)
+	c.Language = a.language
+	return c, nil
+}
+
+// sameServer reports whether two server URLs are the same, ignoring a trailing slash.
+func sameServer(a, b string) bool {
+	return strings.TrimRight(a, "/") == strings.TrimRight(b, "/")
+}
+
+// fixedCompletion completes a flag or argument with the given values.
+func fixedCompletion(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
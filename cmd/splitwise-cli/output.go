@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// print writes v as indented JSON with -o json, or calls table otherwise.
+func (a *app) print(v interface{}, table func(t *tabwriter.Writer)) error {
+	if a.output == outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	t := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	table(t)
+	return t.Flush()
+}
+
+// row writes the cells of one table line.
+func row(t *tabwriter.Writer, cells ...interface{}) {
+	s := make([]string, len(cells))
+	for i, cell := range cells {
+		s[i] = fmt.Sprint(cell)
+	}
+	fmt.Fprintln(t, strings.Join(s, "\t"))
+}
+
+// message prints a confirmation for table output. JSON output stays machine readable, so the
+// confirmation is printed as {"message": ...}.
+func (a *app) message(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	return a.print(map[string]string{"message": msg}, func(t *tabwriter.Writer) {
+		fmt.Fprintln(t, msg)
+	})
+}
+
+func amount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func date(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func optionalDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return date(*t)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// parseID reads a group, bill or report id argument.
+func parseID(kind, arg string) (uint, error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid %s id %q", kind, arg)
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"text/tabwriter"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+)
+
+func newPayCmd(a *app) *cobra.Command {
+	var remarks string
+	cmd := &cobra.Command{
+		Use:               "pay GROUP_ID",
+		Short:             "Mark your share of a group as paid",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeGroups(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, err := parseID("group", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			if err := c.MarkPayment(cmd.Context(), groupID, remarks); err != nil {
+				return err
+			}
+			return a.message("Marked your share of group %d as paid", groupID)
+		},
+	}
+	cmd.Flags().StringVar(&remarks, "remarks", "", "note for the other members, e.g. how you paid")
+	return cmd
+}
+
+func newPendingCmd(a *app) *cobra.Command {
+	var opts client.ListOptions
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "List the shares you have not paid yet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			page, err := c.PendingPayments(cmd.Context(), opts)
+			for all && err == nil && page.Pagination.HasMore {
+				opts.Cursor = page.Pagination.NextCursor
+				var next client.PendingPaymentPage
+				next, err = c.PendingPayments(cmd.Context(), opts)
+				next.PendingPayments = append(page.PendingPayments, next.PendingPayments...)
+				page = next
+			}
+			if err != nil {
+				return err
+			}
+			return a.print(page, func(t *tabwriter.Writer) {
+				row(t, "GROUP", "NAME", "BILL", "YOUR SHARE", "BILL AMOUNT", "CREATED")
+				for _, p := range page.PendingPayments {
+					row(t, p.GroupID, p.GroupName, p.BillID, amount(p.Amount), amount(p.BillAmount), date(p.CreatedAt))
+				}
+				row(t)
+				row(t, "TOTAL", "", "", amount(page.TotalAmount))
+				if page.Pagination.HasMore {
+					row(t, "More payments: --cursor "+page.Pagination.NextCursor)
+				}
+			})
+		},
+	}
+	addListFlags(cmd, &opts, &all)
+	return cmd
+}
+
+func newBalanceCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "balance",
+		Aliases: []string{"balances"},
+		Short:   "Show what you owe and what you are owed, per group",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			b, err := c.Balance(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return a.print(b, func(t *tabwriter.Writer) {
+				row(t, "GROUP", "NAME", "YOU OWE", "OWED TO YOU")
+				for _, g := range b.Groups {
+					row(t, g.GroupID, g.GroupName, amount(g.Owed), amount(g.OwedToYou))
+				}
+				row(t)
+				row(t, "TOTAL", "", amount(b.Owed), amount(b.OwedToYou))
+				row(t, "NET", "", "", amount(b.Net))
+			})
+		},
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/client"
+	"github.com/spf13/cobra"
+)
+
+// pollInterval is how often --wait checks whether a report is ready.
+const pollInterval = 2 * time.Second
+
+func newReportsCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "reports",
+		Aliases: []string{"report"},
+		Short:   "Generate and download reports",
+		Long:    "Reports are generated in the background. Create one, wait for it to be DONE, then download it.",
+	}
+	cmd.AddCommand(
+		newReportsCreateCmd(a),
+		newReportsListCmd(a),
+		newReportsShowCmd(a),
+		newReportsCancelCmd(a),
+		newReportsDownloadCmd(a),
+	)
+	return cmd
+}
+
+func newReportsCreateCmd(a *app) *cobra.Command {
+	var input client.ReportRequest
+	var from, to, download string
+	var noCharts, wait bool
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Queue a report",
+		Long: "Queues a report: groups (the groups you created in the date range), group (one group, see --group) " +
+			"or statement (your share of every group). Dates default to the last seven days.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if input.Type == "group" && input.GroupID == 0 {
+				return errors.New("--group is required for a group report")
+			}
+			if from != "" {
+				input.From = &from
+			}
+			if to != "" {
+				input.To = &to
+			}
+			if noCharts {
+				charts := false
+				input.Charts = &charts
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			report, err := c.CreateReport(cmd.Context(), input)
+			if err != nil {
+				return err
+			}
+			if wait || download != "" {
+				if report, err = c.WaitReport(cmd.Context(), report.JobID, pollInterval); err != nil {
+					return err
+				}
+			}
+			if download != "" {
+				return a.download(cmd, c, report, download)
+			}
+			return a.printReports([]client.Report{report}, report)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&input.Type, "type", "", "groups, group or statement")
+	flags.StringVar(&input.Format, "format", "", "pdf (default), csv, xlsx or html; pdf or json for statements")
+	flags.StringVar(&from, "from", "", "first day, YYYY-MM-DD")
+	flags.StringVar(&to, "to", "", "last day, YYYY-MM-DD")
+	flags.UintVar(&input.GroupID, "group", 0, "group of a group report")
+	flags.BoolVar(&input.Comments, "comments", false, "append the comment threads to a group report")
+	flags.BoolVar(&noCharts, "no-charts", false, "leave the charts out of PDF and HTML reports")
+	flags.BoolVar(&wait, "wait", false, "wait until the report is finished")
+	flags.StringVar(&download, "download", "", "wait for the report and save it to this file, or to a directory under its own name")
+	_ = cmd.MarkFlagRequired("type")
+	_ = cmd.RegisterFlagCompletionFunc("type", fixedCompletion("groups", "group", "statement"))
+	_ = cmd.RegisterFlagCompletionFunc("format", fixedCompletion("pdf", "csv", "xlsx", "html", "json"))
+	_ = cmd.RegisterFlagCompletionFunc("group", a.completeGroups(true))
+	return cmd
+}
+
+func newReportsListCmd(a *app) *cobra.Command {
+	var limit, offset int
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your reports, newest first",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			list, err := c.Reports(cmd.Context(), limit, offset)
+			if err != nil {
+				return err
+			}
+			return a.printReports(list.Jobs, list)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "page size, 20 by default and at most 100")
+	cmd.Flags().IntVar(&offset, "offset", 0, "number of reports to skip")
+	return cmd
+}
+
+func newReportsShowCmd(a *app) *cobra.Command {
+	var wait bool
+	cmd := &cobra.Command{
+		Use:   "show REPORT_ID",
+		Short: "Show the status of a report",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobID, err := parseID("report", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			var report client.Report
+			if wait {
+				report, err = c.WaitReport(cmd.Context(), jobID, pollInterval)
+			} else {
+				report, err = c.Report(cmd.Context(), jobID)
+			}
+			if err != nil {
+				return err
+			}
+			return a.printReports([]client.Report{report}, report)
+		},
+	}
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the report is finished")
+	return cmd
+}
+
+func newReportsCancelCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel REPORT_ID",
+		Short: "Cancel a queued or running report",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobID, err := parseID("report", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			report, err := c.CancelReport(cmd.Context(), jobID)
+			if err != nil {
+				return err
+			}
+			return a.printReports([]client.Report{report}, report)
+		},
+	}
+}
+
+func newReportsDownloadCmd(a *app) *cobra.Command {
+	var output string
+	var wait bool
+	cmd := &cobra.Command{
+		Use:   "download REPORT_ID",
+		Short: "Download a finished report",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobID, err := parseID("report", args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			var report client.Report
+			if wait {
+				report, err = c.WaitReport(cmd.Context(), jobID, pollInterval)
+			} else {
+				report, err = c.Report(cmd.Context(), jobID)
+			}
+			if err != nil {
+				return err
+			}
+			return a.download(cmd, c, report, output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "file", "f", ".", "file to save the report to, or a directory to save it in under its own name, - for stdout")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the report is finished")
+	return cmd
+}
+
+// download saves the file of a finished report to path. A directory keeps the name of the report.
+func (a *app) download(cmd *cobra.Command, c *client.Client, report client.Report, path string) error {
+	if report.Status != client.ReportDone {
+		if report.Error != "" {
+			return fmt.Errorf("report %d is %s: %s", report.JobID, report.Status, report.Error)
+		}
+		return fmt.Errorf("report %d is %s, use --wait to wait for it", report.JobID, report.Status)
+	}
+	if path == "-" {
+		_, err := c.DownloadReport(cmd.Context(), report.JobID, os.Stdout)
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, filepath.Base(report.FileName))
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".report-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = c.DownloadReport(cmd.Context(), report.JobID, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return a.message("Saved report %d to %s", report.JobID, path)
+}
+
+// printReports prints reports as a table, or v as JSON.
+func (a *app) printReports(reports []client.Report, v interface{}) error {
+	return a.print(v, func(t *tabwriter.Writer) {
+		row(t, "ID", "TYPE", "FORMAT", "STATUS", "FILE", "CREATED", "EXPIRES")
+		for _, r := range reports {
+			status := r.Status
+			if r.Error != "" {
+				status += ": " + r.Error
+			}
+			row(t, r.JobID, r.Type, r.Format, status, r.FileName, date(r.CreatedAt), optionalDate(r.ExpiresAt))
+		}
+	})
+}
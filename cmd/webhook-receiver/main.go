@@ -0,0 +1,109 @@
+// Command webhook-receiver is a local HTTP endpoint for trying out SplitWise webhooks. It verifies
+// the signature of every delivery, logs the event and keeps the received deliveries in memory so
+// they can be inspected with GET /deliveries. Use -fail to reject the first deliveries and watch
+// the server retry them with backoff.
+//
+//	go run ./cmd/webhook-receiver -secret whsec_... -fail 2
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/webhook"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+type received struct {
+	DeliveryID string          `json:"deliveryId"`
+	Event      string          `json:"event"`
+	Valid      bool            `json:"valid"`
+	Error      string          `json:"error,omitempty"`
+	Status     int             `json:"status"`
+	ReceivedAt time.Time       `json:"receivedAt"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+type receiver struct {
+	secret    string
+	tolerance time.Duration
+
+	mu        sync.Mutex
+	failLeft  int
+	delivered []received
+}
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	secret := flag.String("secret", os.Getenv("WEBHOOK_SECRET"), "webhook signing secret (defaults to $WEBHOOK_SECRET)")
+	fail := flag.Int("fail", 0, "respond 500 to this many valid deliveries before accepting them")
+	tolerance := flag.Duration("tolerance", webhook.DefaultTolerance, "allowed clock skew of the timestamp header")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatal("a signing secret is required, pass -secret or set WEBHOOK_SECRET")
+	}
+
+	rcv := &receiver{secret: *secret, tolerance: *tolerance, failLeft: *fail}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /", rcv.receive)
+	mux.HandleFunc("GET /deliveries", rcv.list)
+
+	log.Info("Webhook receiver listening on " + *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Fatal("Webhook receiver stopped", zap.Error(err))
+	}
+}
+
+func (rcv *receiver) receive(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	entry := received{
+		DeliveryID: r.Header.Get(webhook.HeaderDelivery),
+		Event:      r.Header.Get(webhook.HeaderEvent),
+		ReceivedAt: time.Now(),
+		Payload:    body,
+		Status:     http.StatusOK,
+	}
+	if err := webhook.Verify(rcv.secret, r.Header, body, rcv.tolerance, time.Now()); err != nil {
+		entry.Error = err.Error()
+		entry.Status = http.StatusUnauthorized
+	} else {
+		entry.Valid = true
+	}
+
+	rcv.mu.Lock()
+	if entry.Valid && rcv.failLeft > 0 {
+		rcv.failLeft--
+		entry.Status = http.StatusInternalServerError
+		entry.Error = "simulated failure"
+	}
+	rcv.delivered = append(rcv.delivered, entry)
+	rcv.mu.Unlock()
+
+	log.Info("Webhook received",
+		zap.String("deliveryId", entry.DeliveryID),
+		zap.String("event", entry.Event),
+		zap.Bool("valid", entry.Valid),
+		zap.Int("status", entry.Status),
+		zap.String("error", entry.Error),
+		zap.ByteString("payload", body))
+	w.WriteHeader(entry.Status)
+}
+
+func (rcv *receiver) list(w http.ResponseWriter, _ *http.Request) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(rcv.delivered)
+}
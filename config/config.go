@@ -1,6 +1,7 @@
 package config
 
 import (
+	"sync"
 	"time"
 
 	log "github.com/mohdjishin/SplitWise/logger"
@@ -14,12 +15,143 @@ type Config struct {
 	DSN       string `mapstructure:"dsn"`
 	// LogLevel  string `mapstructure:"logLevel"` // not used as of now kept in .env to change it dynamically from docker env
 	ENV string `mapstructure:"env"`
+	// TrustedProxies are the addresses or CIDR ranges of reverse proxies whose X-Forwarded-For and
+	// X-Real-IP headers are believed. Requests from anywhere else are identified by their socket address.
+	TrustedProxies []string `mapstructure:"trustedProxies"`
+	// ExportAsyncThreshold is the number of records above which a personal data export is generated in the background.
+	ExportAsyncThreshold int           `mapstructure:"exportAsyncThreshold"`
+	LoginThrottle        LoginThrottle `mapstructure:"loginThrottle"`
+	Storage              Storage       `mapstructure:"storage"`
+	Webhooks             Webhooks      `mapstructure:"webhooks"`
+	Reminders            Reminders     `mapstructure:"reminders"`
+	Events               Events        `mapstructure:"events"`
+	ReportJobs           ReportJobs    `mapstructure:"reportJobs"`
+	ExportJobs           ExportJobs    `mapstructure:"exportJobs"`
+	PDF                  PDF           `mapstructure:"pdf"`
+	GraphQL              GraphQL       `mapstructure:"graphql"`
+	GRPC                 GRPC          `mapstructure:"grpc"`
 }
 
-var config Config
+// GRPC configures the gRPC server for internal services.
+type GRPC struct {
+	Port string `mapstructure:"port"` // the gRPC server is not started when empty
+	// Host is the address to listen on and must be set with Port. Only a loopback address such as
+	// 127.0.0.1 may be used without TLS.
+	Host string `mapstructure:"host"`
+	// CertFile and KeyFile are a PEM certificate and key to serve TLS with. Without them calls and
+	// their tokens travel in plaintext.
+	CertFile string `mapstructure:"certFile"`
+	KeyFile  string `mapstructure:"keyFile"`
+}
+
+// GraphQL configures the /graphql endpoint.
+type GraphQL struct {
+	MaxComplexity int `mapstructure:"maxComplexity"` // queries costing more are rejected before they run
+	MaxDepth      int `mapstructure:"maxDepth"`      // deepest selection allowed
+}
+
+// PDF configures the generated PDF reports.
+type PDF struct {
+	// FallbackFonts are TrueType files used, in order, for characters the embedded DejaVu Sans and Noto Sans
+	// Devanagari do not have, e.g. a Noto Sans Bengali file for Bengali names. They cannot add shaping, see helper/pdf.
+	FallbackFonts []string `mapstructure:"fallbackFonts"`
+}
+
+// ReportJobs configures the background generation of reports.
+type ReportJobs struct {
+	Workers           int           `mapstructure:"workers"`           // reports generated at the same time per instance
+	PollInterval      time.Duration `mapstructure:"pollInterval"`      // how often idle workers check the queue
+	JobTimeout        time.Duration `mapstructure:"jobTimeout"`        // a job running longer is cancelled and failed
+	ResultTTL         time.Duration `mapstructure:"resultTTL"`         // finished reports are kept this long
+	MaxPendingPerUser int           `mapstructure:"maxPendingPerUser"` // queued or running jobs a user may have
+}
+
+// ExportJobs configures the background generation of personal data exports.
+type ExportJobs struct {
+	PollInterval time.Duration `mapstructure:"pollInterval"` // how often the worker checks the queue
+	JobTimeout   time.Duration `mapstructure:"jobTimeout"`   // a job running longer is cancelled and failed
+	ResultTTL    time.Duration `mapstructure:"resultTTL"`    // finished archives are kept this long
+}
+
+// Events configures the real-time group update stream.
+type Events struct {
+	Broker       string        `mapstructure:"broker"`       // "memory"
+	ReplayBuffer int           `mapstructure:"replayBuffer"` // messages kept per group for Last-Event-ID resume
+	Heartbeat    time.Duration `mapstructure:"heartbeat"`
+}
+
+// Reminders configures payment reminders for unpaid members.
+type Reminders struct {
+	Interval        time.Duration `mapstructure:"interval"`        // how often schedules are checked
+	NudgeCooldown   time.Duration `mapstructure:"nudgeCooldown"`   // minimum time between two nudges of the same member
+	DefaultChannels []string      `mapstructure:"defaultChannels"` // used by groups without a schedule
+	SMTP            SMTP          `mapstructure:"smtp"`
+}
+
+// SMTP configures the mail server used by the email reminder channel. Email is disabled when Host is empty.
+type SMTP struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+// Webhooks configures delivery of events to registered webhook endpoints.
+type Webhooks struct {
+	PollInterval time.Duration `mapstructure:"pollInterval"` // how often the delivery queue is checked
+	BatchSize    int           `mapstructure:"batchSize"`    // deliveries sent per poll
+	Timeout      time.Duration `mapstructure:"timeout"`      // per request
+	MaxAttempts  int           `mapstructure:"maxAttempts"`
+	BaseBackoff  time.Duration `mapstructure:"baseBackoff"` // doubled after every failed attempt
+	MaxBackoff   time.Duration `mapstructure:"maxBackoff"`
+	// AllowPrivateAddresses lets webhooks point at loopback, private and link-local addresses.
+	// Only for trying webhooks locally: it lets anyone who can register a webhook reach internal services.
+	AllowPrivateAddresses bool `mapstructure:"allowPrivateAddresses"`
+}
+
+// Storage configures where attachments are kept and how they are served.
+type Storage struct {
+	Driver         string        `mapstructure:"driver"`    // "local" or "s3"
+	LocalPath      string        `mapstructure:"localPath"` // root directory of the local driver
+	S3             S3Storage     `mapstructure:"s3"`
+	MaxUploadBytes int64         `mapstructure:"maxUploadBytes"`
+	SignedURLTTL   time.Duration `mapstructure:"signedUrlTTL"`
+	URLSigningKey  string        `mapstructure:"urlSigningKey"` // signs attachment download links, never the JWT secret
+}
+
+type S3Storage struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	AccessKey string `mapstructure:"accessKey"`
+	SecretKey string `mapstructure:"secretKey"`
+	Bucket    string `mapstructure:"bucket"`
+	Region    string `mapstructure:"region"`
+	UseSSL    bool   `mapstructure:"useSSL"`
+}
 
-func init() {
+// LoginThrottle configures brute-force protection of the login endpoint.
+type LoginThrottle struct {
+	Store   string         `mapstructure:"store"`  // "memory" or "postgres"
+	Window  time.Duration  `mapstructure:"window"` // failures older than this are forgotten
+	Account ThrottlePolicy `mapstructure:"account"`
+	IP      ThrottlePolicy `mapstructure:"ip"`
+}
+
+type ThrottlePolicy struct {
+	FreeAttempts     int           `mapstructure:"freeAttempts"`
+	BaseDelay        time.Duration `mapstructure:"baseDelay"`
+	MaxDelay         time.Duration `mapstructure:"maxDelay"`
+	LockoutThreshold int           `mapstructure:"lockoutThreshold"`
+	LockoutDuration  time.Duration `mapstructure:"lockoutDuration"`
+}
 
+var (
+	config   Config
+	loadOnce sync.Once
+)
+
+// load reads config.json from the working directory.
+func load() {
 	viper.SetConfigFile("config.json")
 	viper.SetConfigType("json")
 
@@ -39,6 +171,14 @@ func init() {
 	log.Panic("Failed to load config file after multiple attempts.")
 }
 
+// GetConfig returns the configuration, reading it on first use.
 func GetConfig() Config {
+	loadOnce.Do(load)
 	return config
 }
+
+// SetConfig replaces the configuration without reading config.json, for tests.
+func SetConfig(c Config) {
+	loadOnce.Do(func() {})
+	config = c
+}
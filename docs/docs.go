@@ -15,9 +15,70 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/attachments/{attachmentId}/download": {
+            "get": {
+                "description": "Downloads an attachment. Authorisation comes from the signature of the url returned by the signed url endpoint, so no Authorization header is needed.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry as unix timestamp",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Url signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
-                "description": "Logs in a user with email and password.",
+                "description": "Logs in a user with email and password. Repeated failures per account and per IP are throttled with exponential backoff and a temporary lockout.",
                 "consumes": [
                     "application/json"
                 ],
@@ -67,6 +128,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "429": {
+                        "description": "Too many failed attempts, see the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -107,10 +174,7 @@ const docTemplate = `{
                     "200": {
                         "description": "User registered",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.RegisterResponse"
                         }
                     },
                     "400": {
@@ -195,19 +259,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/groups/": {
-            "post": {
-                "description": "Creates a group with the specified name and an associated bill, then adds the user as a member of the group.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/admin/audit": {
+            "get": {
+                "description": "Returns audit log entries across the whole system, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "audit"
                 ],
-                "summary": "Create a new group with an associated bill",
+                "summary": "List global audit log (admin only)",
                 "parameters": [
                     {
                         "type": "string",
@@ -217,24 +278,81 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "CreateGroupWithBillRequest details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateGroupWithBillRequest"
-                        }
+                        "type": "integer",
+                        "description": "Filter by the user who performed the action",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by group",
+                        "name": "groupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action, e.g. account.created",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by entity type: account, group, member, bill, payment",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this date (YYYY-MM-DD, in the timezone of the admin's preferences)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before the end of this date (YYYY-MM-DD, in the timezone of the admin's preferences)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateGroupWithBillResponse"
+                            "$ref": "#/definitions/dto.AuditLogListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -248,45 +366,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/groups/member-groups": {
+        "/v1/analytics/spending": {
             "get": {
-                "description": "Retrieves all groups associated with the authenticated user. Optionally filters the results by group status. If no status is provided, all groups will be returned.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Aggregates spending per category and per month for bills created in the date range (last twelve months by default). Without groupId it covers the user's share in every group they belong to; with groupId it covers the group's bill totals and requires membership. Dates and months are those of the user's timezone preference.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "groups"
+                    "analytics"
                 ],
-                "summary": "List groups the user belongs to",
+                "summary": "Spending by category and month",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The status of the groups to filter by. Valid values are 'PENDING' or 'DONE'",
-                        "name": "status",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group to analyse instead of the user",
+                        "name": "groupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "from date in the format YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to date in the format YYYY-MM-DD",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with the list of groups.",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ListMemberGroupsResponse"
-                            }
+                            "$ref": "#/definitions/dto.SpendingAnalyticsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid status parameter provided.",
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -294,13 +431,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/groups/owned": {
+        "/v1/categories": {
             "get": {
-                "description": "Fetches and returns a list of groups that are owned by the current user, including group members.",
+                "description": "Returns the predefined categories and the custom categories created by the user.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "groups"
+                    "categories"
                 ],
-                "summary": "List groups owned by the user",
+                "summary": "List bill categories",
                 "parameters": [
                     {
                         "type": "string",
@@ -312,12 +452,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of groups owned by the user",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ListOwnedGroupsResponse"
-                            }
+                            "$ref": "#/definitions/dto.CategoryListResponse"
                         }
                     },
                     "500": {
@@ -327,15 +464,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/groups/{id}": {
-            "delete": {
-                "description": "Deletes a group identified by the specified group ID if the user is the creator of the group. (owner only can do this operation)",
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "groups"
+                    "categories"
                 ],
-                "summary": "Delete a group by ID (NOT NEEDED AS OF NOW)",
+                "summary": "Create a custom category",
                 "parameters": [
                     {
                         "type": "string",
@@ -345,22 +485,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "ID of the group to be deleted",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.DeleteGroupResponse"
+                            "$ref": "#/definitions/models.CustomCategory"
                         }
                     },
-                    "404": {
-                        "description": "Group Not Found",
+                    "400": {
+                        "description": "Invalid name",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Category already exists",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -374,13 +522,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/groups/{id}/users": {
-            "post": {
-                "description": "Adds members identified by their email addresses to a group if the user is the creator of the group.",
+        "/v1/categories/{id}": {
+            "delete": {
+                "description": "Deletes a custom category. Bills of the user's groups in this category become uncategorised.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "groups"
+                    "categories"
                 ],
-                "summary": "Add members to a group",
+                "summary": "Delete a custom category",
                 "parameters": [
                     {
                         "type": "string",
@@ -390,37 +541,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "ID of the group to which members will be added",
+                        "type": "integer",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "List of user email IDs to add to the group",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.AddUsersToGroupRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success message",
-                        "schema": {
-                            "$ref": "#/definitions/dto.AddUsersToGroupResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Category deleted",
                         "schema": {
-                            "$ref": "#/definitions/errors.Error"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Group Not Found or Users Not Found",
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -434,9 +573,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/payments/pending-payments": {
-            "get": {
-                "description": "Fetches all pending payments for the current user that have not been paid yet, including group ID, group name, bill ID, and amount owed.",
+        "/v1/groups/": {
+            "post": {
+                "description": "Creates a group with the specified name and an associated bill, then adds the user as a member of the group.",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,24 +583,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "groups"
                 ],
-                "summary": "Retrieve Pending Payments",
-                "responses": {
-                    "200": {
-                        "description": "Successful response containing the list of pending payments and total amount.",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PendingPaymentsWithTotalResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "No pending payments found for the user.",
-                        "schema": {
-                            "$ref": "#/definitions/errors.Error"
-                        }
-                    },
+                "summary": "Create a new group with an associated bill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "CreateGroupWithBillRequest details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGroupWithBillRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGroupWithBillResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error occurred while fetching pending payments.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -469,19 +626,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/report": {
-            "post": {
-                "description": "Generates and downloads a PDF report for the groups created by the user within a specified date range.",
+        "/v1/groups/member-groups": {
+            "get": {
+                "description": "Retrieves a page of the groups the authenticated user is a member of. Optionally filters the results by group status, creation date, total amount and name. Pages are walked with the nextCursor of the response, which is also linked in the Link header.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/pdf"
+                    "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "groups"
+                ],
+                "summary": "List groups the user belongs to",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Groups per page, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, amount or name, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Smallest total amount",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Largest total amount",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The status of the groups to filter by. Valid values are 'PENDING' or 'DONE'",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the group name, ignoring case",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with the page of groups.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListMemberGroupsPage"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter provided.",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/owned": {
+            "get": {
+                "description": "Fetches a page of the groups created by the current user, including group members. Pages are walked with the nextCursor of the response, which is also linked in the Link header.",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Download PDF report of user's groups",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "List groups owned by the user",
                 "parameters": [
                     {
                         "type": "string",
@@ -490,43 +741,167 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Groups per page, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "from date in the format YYYY-MM-DD",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, amount or name, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or after this day (YYYY-MM-DD) in the user's timezone",
                         "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "to date in the format YYYY-MM-DD",
+                        "description": "Created on or before this day (YYYY-MM-DD) in the user's timezone",
                         "name": "to",
                         "in": "query"
                     },
                     {
-                        "description": "GetGroupReportRequest details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "number",
+                        "description": "Smallest total amount",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Largest total amount",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING or DONE",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the group name, ignoring case",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of groups owned by the user",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetGroupReportRequest"
+                            "$ref": "#/definitions/dto.ListOwnedGroupsPage"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
                         }
                     }
+                }
+            }
+        },
+        "/v1/groups/{id}": {
+            "get": {
+                "description": "Returns the group with its bills, budgets and members, including their names, split amounts and whether and when they paid. Any member of the group can fetch it. Emails are only shown for the viewer and for members who share their email with co-members, see PUT /v1/me/privacy.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Get a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PDF report generated and downloaded",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/dto.GroupDetailResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Group not found or the user is not a member",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a group identified by the specified group ID if the user is the creator of the group. (owner only can do this operation)",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Delete a group by ID (NOT NEEDED AS OF NOW)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the group to be deleted",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteGroupResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Group Not Found",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -540,19 +915,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/report/{id}": {
+        "/v1/groups/{id}/activity": {
             "get": {
-                "description": "Generates a detailed PDF report for the group specified by its ID. The report includes group details, associated bills, and member history.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a chronological feed of everything that happened in the group: creation, members joining, bills created or edited, payments with remarks and status changes. Entries carry the names of the people involved. Only members can see it.",
                 "produces": [
-                    "application/pdf"
+                    "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "groups"
                 ],
-                "summary": "Generate a PDF report for a specific group",
+                "summary": "Get the activity feed of a group",
                 "parameters": [
                     {
                         "type": "string",
@@ -567,17 +939,41 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt (oldest first, the default) or -createdAt (newest first)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PDF report generated successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/dto.ActivityFeedResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid query parameter",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -589,7 +985,3857 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/attachments": {
+            "get": {
+                "description": "Lists receipts and payment proofs attached in the group. Only members of the group can see them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "List attachments of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only attachments of this bill",
+                        "name": "billId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only attachments of this payment (group member id)",
+                        "name": "paymentId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttachmentResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/attachments/{attachmentId}": {
+            "delete": {
+                "description": "Deletes an attachment and its stored file. The uploader and the group owner can delete it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/attachments/{attachmentId}/url": {
+            "get": {
+                "description": "Returns a signed, time-limited url to download the attachment. Only members of the group can request it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get a signed download url",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentURLResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/audit": {
+            "get": {
+                "description": "Returns the audit log entries of a group, newest first. Only members of the group can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit log of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action, e.g. payment.marked",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by entity type: group, member, bill, payment",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuditLogListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/bills/{billId}": {
+            "patch": {
+                "description": "Changes the name or category of a bill of the group. Only the group owner can edit bills.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Edit a bill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "billId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateBillRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BillResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid name or unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or bill not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/bills/{billId}/attachments": {
+            "post": {
+                "description": "Uploads a photo (JPEG, PNG, WEBP, GIF) or PDF of a receipt for a bill of the group. Any member can upload.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Attach a receipt to a bill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "billId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Receipt file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or bill not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/bills/{billId}/comments": {
+            "get": {
+                "description": "Returns top-level comments posted on a bill of the group, oldest first, each with its replies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List comments on a bill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "billId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of top-level comments (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posted on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posted on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or bill not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Posts a comment on a bill of the group, or a reply when parentId is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a bill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bill ID",
+                        "name": "billId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group, bill or parent comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/budgets": {
+            "get": {
+                "description": "Returns the group budget and the category budgets of the group, each compared with the bill totals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "List the budgets of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BudgetStatus"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets the budget of the whole group, or of one category when a category is given, replacing any previous budget for it. Members are alerted when spending crosses one of the thresholds (80% and 100% by default). Only the group owner can set budgets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Set a group or category budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Budget",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetBudgetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BudgetStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid amount, thresholds or category",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/budgets/{budgetId}": {
+            "delete": {
+                "description": "Removes a group or category budget. Only the group owner can delete budgets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Delete a budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Budget ID",
+                        "name": "budgetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Budget deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or budget not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/comments": {
+            "get": {
+                "description": "Returns top-level comments posted on the group, oldest first, each with its replies. Only members of the group can read them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "List comments on a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of top-level comments (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posted on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Posted on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Posts a comment on the group, or a reply when parentId is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or parent comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/comments/{commentId}": {
+            "delete": {
+                "description": "Deletes a comment. The author and the group owner can delete it. Comments with replies are kept as a removed placeholder so the thread stays readable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comment deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the text of a comment. Only its author can edit it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Edit a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the author",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/events": {
+            "get": {
+                "description": "Streams member, bill, payment and status changes of the group as Server-Sent Events. Each event carries the domain event and the group's current totals. A comment line is sent as heartbeat every few seconds. Reconnecting clients send the Last-Event-ID header to receive the updates they missed; if those are no longer available a \"reset\" event asks the client to reload the group.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Stream group updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Streaming not supported",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/members/{userId}/nudge": {
+            "post": {
+                "description": "Sends a payment reminder to the member right away through the group's reminder channels. Only the group owner can nudge, and each member at most once per cooldown period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Nudge an unpaid member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the member",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReminderResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Member has already paid",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Member was nudged recently",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/payments/attachments": {
+            "post": {
+                "description": "Uploads a photo (JPEG, PNG, WEBP, GIF) or PDF proving the current user's payment in the group.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Attach a proof of payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Proof of payment",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing file",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/reminders": {
+            "get": {
+                "description": "Lists scheduled reminders and nudges sent in the group, newest first. The owner sees every reminder, other members only their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "List reminders of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sent on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sent on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReminderListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/reminders/schedule": {
+            "get": {
+                "description": "Returns the automatic payment reminder schedule of the group. Groups without a schedule get a disabled default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Get the reminder schedule of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReminderScheduleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets when unpaid members are reminded automatically and through which channels (inapp, email, webhook). Only the group owner can change it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Configure the reminder schedule of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReminderScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReminderScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/report-template": {
+            "get": {
+                "description": "Returns the template the detailed report of the group is laid out with. Only the group owner can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Get the report template of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportTemplateResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found or no template set",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Lays out the detailed report of the group with the template, in place of the owner's group template. The template is JSON, or YAML when sent as application/yaml, and has the same form as the templates of PUT /v1/report-templates/group. Only the group owner can set it.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Set the report template of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid template",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the template of the group, its report goes back to the owner's group template or the bundled layout. Only the group owner can delete it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Delete the report template of a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found or no template set",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/groups/{id}/users": {
+            "post": {
+                "description": "Adds members identified by their email addresses to a group if the user is the creator of the group.",
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Add members to a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the group to which members will be added",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "List of user email IDs to add to the group",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddUsersToGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success message",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddUsersToGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group Not Found or Users Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Get the current user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/export": {
+            "get": {
+                "description": "Builds a ZIP archive with JSON and CSV files covering the user's profile, groups, bills, splits, payments and bill history. Large exports (or async=true) are generated in the background and return 202 with a job to poll.",
+                "produces": [
+                    "application/zip",
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Export personal data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Force asynchronous generation",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive with the user's data",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Export queued",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/export/{jobId}": {
+            "get": {
+                "description": "Returns the status of a personal data export job owned by the current user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get export status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Export not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/export/{jobId}/download": {
+            "get": {
+                "description": "Downloads the ZIP archive of a finished personal data export job.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Download export archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Export job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive with the user's data",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Export not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Export not ready",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "410": {
+                        "description": "Export expired",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/logins": {
+            "get": {
+                "description": "Returns the most recent successful and failed login attempts for the current user, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List recent login attempts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attempts on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attempts on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginEventListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/preferences": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Get locale and timezone preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PreferencesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets the locale amounts and dates in reports are written in, such as 1.234,56 € for de-DE or ₹1,23,456.00 for en-IN, and the timezone report date ranges are read in and times are shown in. A report template can still set its own locale. The closest supported locale is picked, see supportedLocales in the response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Update locale and timezone preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PreferencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported locale or unknown timezone",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/privacy": {
+            "put": {
+                "description": "With emailVisibility members, members of the user's groups see the user's email in group details; with private, the default, only the user does.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Update privacy settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Privacy settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePrivacyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications": {
+            "get": {
+                "description": "Returns the current user's notifications, newest first, together with the unread count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/preferences": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationPreferences"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Enables or disables notifications per event type. Event types left out keep their current setting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationPreferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown event type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/read-all": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications marked as read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/{id}/read": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payments/pending": {
+            "get": {
+                "description": "Fetches a page of the payments the current user has not made yet, including group ID, group name, bill ID and the amount owed. totalAmount is the sum owed over every page matching the filters. Pages are walked with the nextCursor of the response, which is also linked in the Link header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Retrieve Pending Payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payments per page, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, amount or name (of the group), with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group created on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group created on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Smallest amount owed",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Largest amount owed",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the group name, ignoring case",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response containing the page of pending payments and total amount.",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PendingPaymentsWithTotalResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter provided.",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error occurred while fetching pending payments.",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report": {
+            "post": {
+                "description": "Generates and downloads a report for the groups created by the user within a specified date range. The format is taken from the format query parameter, then from the Accept header, and defaults to PDF. PDF, CSV and HTML reports are laid out with the user's groups template when one is set (see /v1/report-templates). Owners with many groups should queue the report with POST /v1/reports instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/html"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Download report of user's groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "from date in the format YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "to date in the format YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf (default), csv, xlsx or html",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Draw the paid versus outstanding, spending over time and category charts (PDF and HTML only, default true)",
+                        "name": "charts",
+                        "in": "query"
+                    },
+                    {
+                        "description": "GetGroupReportRequest details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetGroupReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report generated and downloaded",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-templates": {
+            "get": {
+                "description": "Returns the templates the user has set for their groups and group reports. Templates of single groups are listed with GET /v1/groups/{id}/report-template.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "List my report templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReportTemplateResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-templates/defaults/{kind}": {
+            "get": {
+                "description": "Returns the template reports of the kind are laid out with when no custom template is set. It is a good starting point for a custom template.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Get a bundled report template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groups or group",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf (default), csv or html",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown kind or format",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report-templates/{kind}": {
+            "get": {
+                "description": "Returns the template the user has set for reports of the kind.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Get my report template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groups or group",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown kind",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "No template set",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Lays out every report of the kind the user generates with the template, unless the group has a template of its own. The template is JSON, or YAML when sent as application/yaml. It lists the sections of the report in order: text, fields and table sections show datasets of the report, chart sections draw charts, comments adds the comment threads when asked for and pageBreak starts a new page. Get a bundled template from GET /v1/report-templates/defaults/{kind} to see the datasets, fields and charts available.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Set my report template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groups or group",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid template",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the template of the kind, reports go back to the bundled layout.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report templates"
+                ],
+                "summary": "Delete my report template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "groups or group",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown kind",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "No template set",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/me": {
+            "post": {
+                "description": "Generates a statement for the current user covering every group they are a member of that was created in the date range: their share of each bill, payments made with dates, outstanding amounts and totals. Returned as PDF (default) or JSON, chosen by the format query parameter or the Accept header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf",
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Download personal statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Date range, defaults to the last seven days",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetGroupReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statement as JSON, or the PDF file",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MemberReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/report/{id}": {
+            "get": {
+                "description": "Generates a detailed report for the group specified by its ID. The PDF and HTML reports include group details, budget versus actual, associated bills, member history and the list of attachments; CSV and XLSX contain the summary, members and payment history tables (XLSX as separate sheets). PDF, CSV and HTML reports are laid out with the group's report template, or else the owner's group template, when one is set. The format is taken from the format query parameter, then from the Accept header, and defaults to PDF.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/html"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Generate a report for a specific group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf (default), csv, xlsx or html",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Append the group's comment threads as an appendix (PDF and HTML only)",
+                        "name": "comments",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Draw the paid versus outstanding, member contribution and payments over time charts (PDF and HTML only, default true)",
+                        "name": "charts",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report generated successfully",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List report jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, default 20, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requested on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requested on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, PROCESSING, DONE, FAILED, CANCELLED or EXPIRED",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportJobListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Queues the owner groups report, a group report or the personal statement to be generated in the background and returns the job to poll. The file can be downloaded once the job is DONE, until it expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Queue a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Report to generate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReportJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Report queued",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid type, format or date range",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many reports in progress",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/{jobId}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get report job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Report job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Report job not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/{jobId}/cancel": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Cancel a report job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Report job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Report job not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Report job already finished",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/{jobId}/download": {
+            "get": {
+                "produces": [
+                    "application/pdf",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/html",
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Download a generated report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Report job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Generated report",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Report job not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Report not ready or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "410": {
+                        "description": "Report expired",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Report generation failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks": {
+            "get": {
+                "description": "Lists webhooks of the groups the user owns. Admins see every webhook, including system-wide ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only webhooks of this group",
+                        "name": "groupId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers an endpoint that receives the selected events as signed JSON POST requests. Group owners can register webhooks for their group; admins can also register system-wide webhooks by leaving groupId out. The signing secret is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or event type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a webhook. Deliveries still queued for it are not sent.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the URL, subscribed events or active flag of a webhook. Set rotateSecret to replace the signing secret; the new secret is returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or event type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Returns the delivery log of a webhook, newest first, with the attempts made and the last response.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, SUCCEEDED or FAILED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "createdAt, with a leading - for descending order; -createdAt by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queued on or after this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queued on or before this day (YYYY-MM-DD) in the user's timezone",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryListResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links to the first and next pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "description": "Queues a new delivery with the same event payload. The original delivery stays in the log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/ping": {
+            "post": {
+                "description": "Queues a webhook.ping event for the webhook so the receiver and its signature check can be tested.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send a test delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the group owner or an admin",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/errors.Error"
                         }
@@ -599,219 +4845,1502 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "dto.ActivityEntry": {
+            "description": "Activity feed entry. Types are group.created, member.joined, bill.created, bill.updated, bill.completed, payment.marked and group.completed.",
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "actorName": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "billId": {
+                    "type": "integer"
+                },
+                "billName": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "memberId": {
+                    "description": "user the entry is about, e.g. who joined",
+                    "type": "integer"
+                },
+                "memberName": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ActivityFeedResponse": {
+            "description": "Response model for the activity feed of a group, oldest first unless sorted by -createdAt.",
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ActivityEntry"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "dto.AddUsersToGroupRequest": {
             "type": "object",
             "required": [
-                "userEmailIds"
+                "userEmailIds"
+            ],
+            "properties": {
+                "userEmailIds": {
+                    "description": "UserIds      []uint   ` + "`" + `json:\"userIds\"` + "`" + `",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AddUsersToGroupResponse": {
+            "description": "Response model for adding users to a group.",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AttachmentResponse": {
+            "description": "Response model for an attachment. billId is set for bill receipts, paymentId for payment proofs.",
+            "type": "object",
+            "properties": {
+                "billId": {
+                    "type": "integer"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paymentId": {
+                    "description": "group member id",
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploadedBy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AttachmentURLResponse": {
+            "description": "Signed download url for an attachment. The url can be used without an Authorization header until it expires.",
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditLogListResponse": {
+            "description": "Response model for listing audit log entries, newest first.",
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditLog"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BillResponse": {
+            "description": "Response model for a bill, without its payments.",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "completed": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BudgetStatus": {
+            "description": "Budget versus actual spending. Reached is the highest threshold crossed, 0 when none.",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "percentUsed": {
+                    "type": "number"
+                },
+                "reached": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "type": "number"
+                },
+                "spent": {
+                    "type": "number"
+                },
+                "status": {
+                    "description": "OK, WARNING or EXCEEDED",
+                    "type": "string"
+                },
+                "thresholds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.CategoryAmount": {
+            "description": "Amount spent in a category.",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CategoryListResponse": {
+            "description": "Predefined categories and the user's custom categories.",
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CustomCategory"
+                    }
+                },
+                "predefined": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CommentListResponse": {
+            "description": "Response model for listing comments. Pagination applies to top-level comments; each carries all its replies.",
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CommentResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CommentResponse": {
+            "description": "A comment with author attribution and nested replies.",
+            "type": "object",
+            "properties": {
+                "authorId": {
+                    "type": "integer"
+                },
+                "authorName": {
+                    "type": "string"
+                },
+                "billId": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "editedAt": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parentId": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "boolean"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CommentResponse"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCategoryRequest": {
+            "description": "Request model for a custom bill category. Names are stored in lower case.",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "dto.CreateCommentRequest": {
+            "description": "Request model for posting a comment on a group or a bill, optionally as a reply.",
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Why is my share so high?"
+                },
+                "parentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateGroupWithBillRequest": {
+            "type": "object",
+            "required": [
+                "bill",
+                "groupName"
+            ],
+            "properties": {
+                "bill": {
+                    "type": "object",
+                    "required": [
+                        "amount",
+                        "name"
+                    ],
+                    "properties": {
+                        "amount": {
+                            "type": "number"
+                        },
+                        "category": {
+                            "description": "predefined or custom category, uncategorised when empty",
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "groupName": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateGroupWithBillResponse": {
+            "description": "Response model for the creation of a group with an associated bill.",
+            "type": "object",
+            "properties": {
+                "billId": {
+                    "type": "integer"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateReportJobRequest": {
+            "description": "Request model for a background report. type is groups (groups owned by the user in the date range), group (detailed report of groupId) or statement (personal statement). Format is pdf (default), csv, xlsx or html, and pdf or json for statements. Dates default to the last seven days.",
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "charts": {
+                    "description": "PDF and HTML groups and group reports, false leaves out the charts",
+                    "type": "boolean"
+                },
+                "comments": {
+                    "description": "group reports only, append the comment threads",
+                    "type": "boolean"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "description": "Request model for registering a webhook. Leave groupId out for a system-wide webhook (admins only).",
+            "type": "object",
+            "required": [
+                "eventTypes",
+                "url"
+            ],
+            "properties": {
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeleteGroupResponse": {
+            "description": "Response model for deleting a group.",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExportJobResponse": {
+            "description": "Status of an asynchronous personal data export.",
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "downloadUrl": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "jobId": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "statusUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetGroupReportRequest": {
+            "description": "Request model for generating a group report based on date range.",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GroupBill": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "completed": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BillHistory"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GroupDetailResponse": {
+            "description": "A group as seen by one of its members, with its bills and the members' names and payment status.",
+            "type": "object",
+            "properties": {
+                "bills": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GroupBill"
+                    }
+                },
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BudgetStatus"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GroupMemberDetail"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paidAmount": {
+                    "type": "number"
+                },
+                "perUserSplitAmount": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.GroupMemberDetail": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "hasPaid": {
+                    "type": "boolean"
+                },
+                "isOwner": {
+                    "type": "boolean"
+                },
+                "joinedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "splitAmount": {
+                    "type": "number"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ListMemberGroupsPage": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ListMemberGroupsResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                }
+            }
+        },
+        "dto.ListMemberGroupsResponse": {
+            "description": "Response model for listing groups the user belongs to, including group details and member information.",
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BudgetStatus"
+                    }
+                },
+                "group": {
+                    "$ref": "#/definitions/models.Group"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupMember"
+                    }
+                }
+            }
+        },
+        "dto.ListOwnedGroupsPage": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ListOwnedGroupsResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                }
+            }
+        },
+        "dto.ListOwnedGroupsResponse": {
+            "description": "ListOwnedGroupsResponse is the response model for listing owned groups.",
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BudgetStatus"
+                    }
+                },
+                "group": {
+                    "$ref": "#/definitions/models.Group"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupMember"
+                    }
+                }
+            }
+        },
+        "dto.LoginEventListResponse": {
+            "description": "Response model for the recent login attempts of the current user, newest first.",
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LoginEventResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                }
+            }
+        },
+        "dto.LoginEventResponse": {
+            "description": "Response model for a login attempt. reason is set for failed attempts.",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "userAgent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "jis@jish.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Passw0rd@123"
+                }
+            }
+        },
+        "dto.MarkPaymentRequest": {
+            "description": "Mark a payment for a specific group",
+            "type": "object",
+            "required": [
+                "groupId"
+            ],
+            "properties": {
+                "groupId": {
+                    "type": "integer"
+                },
+                "remarks": {
+                    "description": "Optional* remarks for the payment",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MarkPaymentResponse": {
+            "description": "Response for marking a payment",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MemberReport": {
+            "description": "Statement of what the user owes and paid in every group they belong to, for groups created in the date range.",
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MemberReportEntry"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/dto.MemberReportTotals"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MemberReportEntry": {
+            "description": "The member's share of a bill and their payment of it.",
+            "type": "object",
+            "properties": {
+                "billAmount": {
+                    "type": "number"
+                },
+                "billDate": {
+                    "type": "string"
+                },
+                "billId": {
+                    "type": "integer"
+                },
+                "billName": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "hasPaid": {
+                    "type": "boolean"
+                },
+                "outstanding": {
+                    "type": "number"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "paidAmount": {
+                    "type": "number"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "share": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MemberReportTotals": {
+            "description": "Totals of a member report.",
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "type": "number"
+                },
+                "paid": {
+                    "type": "number"
+                },
+                "share": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.MonthlySpending": {
+            "description": "Amount spent in a month (YYYY-MM), split by category.",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryAmount"
+                    }
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NotificationListResponse": {
+            "description": "Response model for the notification inbox, newest first, with the number of unread notifications.",
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Notification"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unreadCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.NotificationPreferences": {
+            "description": "Map of event type (member.added, bill.created, bill.updated, payment.marked, group.completed, reminder.due, budget.threshold) to enabled flag.",
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "preferences": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "dto.Pagination": {
+            "description": "Pagination of a list, with the cursor of the next page when there is one.",
+            "type": "object",
+            "properties": {
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "-createdAt"
+                }
+            }
+        },
+        "dto.PendingPayments": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "the user's share",
+                    "type": "number"
+                },
+                "billAmount": {
+                    "description": "the whole bill",
+                    "type": "number"
+                },
+                "billId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "groupName": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PendingPaymentsWithTotalResponse": {
+            "description": "Response model for listing pending payments with total amount.",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "pendingPayments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PendingPayments"
+                    }
+                },
+                "totalAmount": {
+                    "description": "over every page matching the filters",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.PreferencesResponse": {
+            "description": "Locale and timezone reports are formatted with and report date ranges are read in. Locale is the supported locale closest to the one asked for, empty for the default formatting; timezone is UTC unless set. Example shows how amounts and dates look.",
+            "type": "object",
+            "properties": {
+                "example": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "supportedLocales": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
             ],
             "properties": {
-                "userEmailIds": {
-                    "description": "UserIds      []uint   ` + "`" + `json:\"userIds\"` + "`" + `",
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "dto.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "User registered"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserResponse"
+                }
+            }
+        },
+        "dto.ReminderListResponse": {
+            "description": "Response model for listing reminders, newest first.",
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "reminders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReminderResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReminderResponse": {
+            "description": "Response model for a sent reminder. kind is SCHEDULED or NUDGE, status SENT or FAILED.",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "memberId": {
+                    "description": "group member id",
+                    "type": "integer"
+                },
+                "sentBy": {
+                    "description": "owner who nudged, 0 for scheduled reminders",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReminderScheduleRequest": {
+            "description": "Request model for a reminder schedule. The first reminder is sent firstAfterDays after a member joined, then every repeatEveryDays until they pay (0 sends a single reminder).",
+            "type": "object",
+            "required": [
+                "channels"
+            ],
+            "properties": {
+                "channels": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "firstAfterDays": {
+                    "type": "integer"
+                },
+                "repeatEveryDays": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.AddUsersToGroupResponse": {
-            "description": "Response model for adding users to a group.",
+        "dto.ReminderScheduleResponse": {
+            "description": "Response model for a reminder schedule.",
             "type": "object",
             "properties": {
-                "message": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "firstAfterDays": {
+                    "type": "integer"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "repeatEveryDays": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "updatedBy": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.CreateGroupWithBillRequest": {
+        "dto.ReportJobListResponse": {
+            "description": "Response model for listing report jobs, newest first.",
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReportJobResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReportJobResponse": {
+            "description": "Status of a background report. The file can be downloaded from downloadUrl while the job is DONE, until expiresAt.",
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "downloadUrl": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "jobId": {
+                    "type": "integer"
+                },
+                "params": {
+                    "$ref": "#/definitions/models.ReportJobParams"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "statusUrl": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReportTemplateResponse": {
+            "description": "A report template of the user, or of a group when groupId is set. The template holds the title, branding, locale and sections of the report.",
+            "type": "object",
+            "properties": {
+                "groupId": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "template": {
+                    "type": "object"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SetBudgetRequest": {
+            "description": "Request model for a budget. Without a category the budget covers the whole group. Thresholds are percentages of the amount that raise an alert when crossed, 80 and 100 by default.",
             "type": "object",
             "required": [
-                "bill",
-                "groupName"
+                "amount"
             ],
             "properties": {
-                "bill": {
-                    "type": "object",
-                    "required": [
-                        "amount",
-                        "name"
-                    ],
-                    "properties": {
-                        "amount": {
-                            "type": "number"
-                        },
-                        "name": {
-                            "type": "string"
-                        }
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "thresholds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.SpendingAnalyticsResponse": {
+            "description": "Spending per category and per month. For a user it is their share of each bill, for a group the bill totals.",
+            "type": "object",
+            "properties": {
+                "byCategory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryAmount"
                     }
                 },
-                "groupName": {
+                "byMonth": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MonthlySpending"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "description": "user or group",
+                    "type": "string"
+                },
+                "to": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "number"
                 }
             }
         },
-        "dto.CreateGroupWithBillResponse": {
-            "description": "Response model for the creation of a group with an associated bill.",
+        "dto.UpdateBillRequest": {
+            "description": "Request model for editing the name or category of a bill.",
             "type": "object",
             "properties": {
-                "billId": {
-                    "type": "integer"
-                },
-                "groupId": {
-                    "type": "integer"
-                },
-                "message": {
+                "category": {
                     "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
                 }
             }
         },
-        "dto.DeleteGroupResponse": {
-            "description": "Response model for deleting a group.",
+        "dto.UpdateCommentRequest": {
+            "description": "Request model for editing the text of a comment.",
             "type": "object",
+            "required": [
+                "body"
+            ],
             "properties": {
-                "message": {
-                    "type": "string"
+                "body": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "Never mind, it includes the taxi."
                 }
             }
         },
-        "dto.GetGroupReportRequest": {
-            "description": "Request model for generating a group report based on date range.",
+        "dto.UpdatePreferencesRequest": {
+            "description": "Request model for the locale and timezone of the user. Fields left out keep their current value, an empty string resets one to the default.",
             "type": "object",
             "properties": {
-                "from": {
+                "locale": {
+                    "description": "BCP 47 tag such as en-US, de-DE or en-IN",
                     "type": "string"
                 },
-                "to": {
+                "timezone": {
+                    "description": "IANA name such as Europe/Berlin",
                     "type": "string"
                 }
             }
         },
-        "dto.ListMemberGroupsResponse": {
-            "description": "Response model for listing groups the user belongs to, including group details and member information.",
+        "dto.UpdatePrivacyRequest": {
+            "description": "private keeps the email to the user; members shows it to members of the user's groups.",
             "type": "object",
+            "required": [
+                "emailVisibility"
+            ],
             "properties": {
-                "group": {
-                    "$ref": "#/definitions/models.Group"
-                },
-                "members": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.GroupMember"
-                    }
+                "emailVisibility": {
+                    "type": "string",
+                    "enum": [
+                        "private",
+                        "members"
+                    ]
                 }
             }
         },
-        "dto.ListOwnedGroupsResponse": {
-            "description": "ListOwnedGroupsResponse is the response model for listing owned groups.",
+        "dto.UpdateWebhookRequest": {
+            "description": "Request model for updating a webhook. Set rotateSecret to get a new signing secret.",
             "type": "object",
             "properties": {
-                "group": {
-                    "$ref": "#/definitions/models.Group"
+                "active": {
+                    "type": "boolean"
                 },
-                "members": {
+                "eventTypes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.GroupMember"
+                        "type": "string"
                     }
+                },
+                "rotateSecret": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.UserResponse": {
+            "description": "The current user's account. Credentials are never included.",
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
                 "email": {
-                    "type": "string",
-                    "example": "jis@jish.com"
+                    "type": "string"
                 },
-                "password": {
+                "emailVisibility": {
                     "type": "string",
-                    "example": "Passw0rd@123"
-                }
-            }
-        },
-        "dto.MarkPaymentRequest": {
-            "description": "Mark a payment for a specific group",
-            "type": "object",
-            "required": [
-                "groupId"
-            ],
-            "properties": {
-                "groupId": {
+                    "enum": [
+                        "private",
+                        "members"
+                    ]
+                },
+                "id": {
                     "type": "integer"
                 },
-                "remarks": {
-                    "description": "Optional* remarks for the payment",
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "timezone": {
                     "type": "string"
                 }
             }
         },
-        "dto.MarkPaymentResponse": {
-            "description": "Response for marking a payment",
+        "dto.WebhookDeliveryListResponse": {
+            "description": "Response model for the delivery log of a webhook, newest first.",
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.PendingPayments": {
+        "dto.WebhookDeliveryResponse": {
+            "description": "Response model for a webhook delivery, with the attempts made and the last response.",
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "attempts": {
+                    "type": "integer"
                 },
-                "billId": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "groupId": {
+                "lastAttemptAt": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "redeliveryOf": {
+                    "description": "delivery this one was manually re-sent from",
                     "type": "integer"
                 },
-                "groupName": {
+                "responseStatus": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
+                },
+                "webhookId": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.PendingPaymentsWithTotalResponse": {
-            "description": "Response model for listing pending payments with total amount.",
+        "dto.WebhookListResponse": {
+            "description": "Response model for listing webhooks.",
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/dto.Pagination"
                 },
-                "pendingPayments": {
+                "total": {
+                    "type": "integer"
+                },
+                "webhooks": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.PendingPayments"
+                        "$ref": "#/definitions/dto.WebhookResponse"
                     }
-                },
-                "totalAmount": {
-                    "type": "number"
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "dto.WebhookResponse": {
+            "description": "Response model for a webhook.",
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "active": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "createdAt": {
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
+                "createdBy": {
+                    "type": "integer"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "groupId": {
+                    "description": "nil for system-wide webhooks",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
@@ -827,6 +6356,47 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "e.g. group.created, payment.marked",
+                    "type": "string"
+                },
+                "actorId": {
+                    "description": "User who performed the action, 0 for anonymous",
+                    "type": "integer"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entityId": {
+                    "type": "integer"
+                },
+                "entityType": {
+                    "description": "group, member, bill, payment or account",
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Bill": {
             "type": "object",
             "properties": {
@@ -834,6 +6404,9 @@ const docTemplate = `{
                     "description": "Total amount",
                     "type": "number"
                 },
+                "category": {
+                    "type": "string"
+                },
                 "completed": {
                     "description": "Overall bill payment status",
                     "type": "boolean"
@@ -879,6 +6452,24 @@ const docTemplate = `{
                 "paidBy": {
                     "description": "User who made the payment",
                     "type": "string"
+                },
+                "paidById": {
+                    "description": "Id of the user who made the payment",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CustomCategory": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
@@ -936,6 +6527,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "paidAt": {
+                    "type": "string"
+                },
                 "remarks": {
                     "type": "string"
                 },
@@ -949,6 +6543,55 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReportJobParams": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "boolean"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "groupId": {
+                    "type": "integer"
+                },
+                "noCharts": {
+                    "type": "boolean"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	mChi "github.com/go-chi/chi/middleware"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// Entity types
+const (
+	EntityAccount    = "account"
+	EntityGroup      = "group"
+	EntityMember     = "member"
+	EntityBill       = "bill"
+	EntityPayment    = "payment"
+	EntityComment    = "comment"
+	EntityAttachment = "attachment"
+	EntityWebhook    = "webhook"
+	EntitySchedule   = "reminder_schedule"
+	EntityCategory   = "category"
+	EntityBudget     = "budget"
+	EntityTemplate   = "report_template"
+)
+
+// Actions
+const (
+	ActionAccountCreated = "account.created"
+	ActionGroupCreated   = "group.created"
+	ActionGroupUpdated   = "group.updated"
+	ActionGroupDeleted   = "group.deleted"
+	ActionMemberAdded    = "member.added"
+	ActionMemberUpdated  = "member.updated"
+	ActionBillCreated    = "bill.created"
+	ActionBillUpdated    = "bill.updated"
+	ActionPaymentMarked  = "payment.marked"
+	ActionCommentCreated = "comment.created"
+	ActionCommentUpdated = "comment.updated"
+	ActionCommentDeleted = "comment.deleted"
+
+	ActionAttachmentCreated = "attachment.created"
+	ActionAttachmentDeleted = "attachment.deleted"
+
+	ActionWebhookCreated = "webhook.created"
+	ActionWebhookUpdated = "webhook.updated"
+	ActionWebhookDeleted = "webhook.deleted"
+
+	ActionScheduleUpdated = "reminder_schedule.updated"
+	ActionMemberNudged    = "member.nudged"
+
+	ActionCategoryCreated = "category.created"
+	ActionCategoryDeleted = "category.deleted"
+
+	ActionBudgetSet     = "budget.set"
+	ActionBudgetDeleted = "budget.deleted"
+
+	ActionPreferencesUpdated = "account.preferences_updated"
+	ActionPrivacyUpdated     = "account.privacy_updated"
+
+	ActionTemplateSet     = "report_template.set"
+	ActionTemplateDeleted = "report_template.deleted"
+)
+
+// Entry describes a single change. Before and After are stored as JSON snapshots;
+// leave Before nil for creates and After nil for deletes.
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityID   uint
+	GroupID    uint
+	Before     any
+	After      any
+}
+
+// Record appends an entry to the audit log with the request id and client address of ctx. Failures
+// are logged and never fail the request.
+func Record(ctx context.Context, actorID uint, entry Entry) {
+	row := models.AuditLog{
+		ActorID:    actorID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Before:     snapshot(entry.Before),
+		After:      snapshot(entry.After),
+		RequestID:  mChi.GetReqID(ctx),
+		IP:         middleware.GetClientIP(ctx),
+	}
+	if entry.GroupID != 0 {
+		groupID := entry.GroupID
+		row.GroupID = &groupID
+	}
+
+	if err := db.GetDb().Create(&row).Error; err != nil {
+		log.Error("Failed to record audit log", zap.String("action", entry.Action), zap.Error(err))
+	}
+}
+
+func snapshot(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Error("Failed to marshal audit snapshot", zap.Error(err))
+		return nil
+	}
+	return data
+}
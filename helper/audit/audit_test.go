@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	mChi "github.com/go-chi/chi/middleware"
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+func TestRecord(t *testing.T) {
+	conn := dbtest.Open(t, &models.AuditLog{})
+	ctx := context.WithValue(context.Background(), mChi.RequestIDKey, "req-1")
+	ctx = middleware.WithClientIP(ctx, "203.0.113.7")
+
+	Record(ctx, 4, Entry{Action: ActionGroupDeleted, EntityType: EntityGroup, EntityID: 9, GroupID: 9, Before: map[string]string{"name": "Trip"}})
+	Record(context.Background(), 4, Entry{Action: ActionPrivacyUpdated, EntityType: EntityAccount, EntityID: 4})
+
+	var rows []models.AuditLog
+	if err := conn.Order("id").Find(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("recorded %d entries, want 2", len(rows))
+	}
+	if rows[0].RequestID != "req-1" || rows[0].IP != "203.0.113.7" || rows[0].ActorID != 4 || rows[0].GroupID == nil || *rows[0].GroupID != 9 {
+		t.Errorf("entry = %+v, want the request id, address and group of the context", rows[0])
+	}
+	if string(rows[0].Before) != `{"name":"Trip"}` || rows[0].After != nil {
+		t.Errorf("snapshots = %s, %s", rows[0].Before, rows[0].After)
+	}
+	if rows[1].RequestID != "" || rows[1].IP != "" || rows[1].GroupID != nil {
+		t.Errorf("entry outside a request = %+v, want no request id, address or group", rows[1])
+	}
+}
@@ -1,8 +1,13 @@
 package helper
 
 import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/mohdjishin/SplitWise/config"
 	"github.com/mohdjishin/SplitWise/internal/db"
 	"github.com/mohdjishin/SplitWise/internal/models"
 	log "github.com/mohdjishin/SplitWise/logger"
@@ -10,11 +15,12 @@ import (
 	"go.uber.org/zap"
 )
 
-func LogBillHistory(billID uint, amount float64, paidBy string) error {
+func LogBillHistory(billID uint, amount float64, paidBy string, paidByID uint) error {
 	history := models.BillHistory{
 		BillID:    billID,
 		Amount:    amount,
 		PaidBy:    paidBy,
+		PaidByID:  paidByID,
 		PaidAt:    time.Now(),
 		CreatedAt: time.Now(),
 	}
@@ -25,3 +31,74 @@ func LogBillHistory(billID uint, amount float64, paidBy string) error {
 	}
 	return nil
 }
+
+var (
+	trustedProxies     []*net.IPNet
+	trustedProxiesOnce sync.Once
+)
+
+// loadTrustedProxies parses config.TrustedProxies, where a plain address stands for itself.
+func loadTrustedProxies() {
+	for _, entry := range config.GetConfig().TrustedProxies {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := 8 * len(ip.To16())
+				if ip.To4() != nil {
+					ip, bits = ip.To4(), 32
+				}
+				trustedProxies = append(trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			log.Warn("Ignoring invalid trusted proxy", zap.String("proxy", entry))
+			continue
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+}
+
+func isTrustedProxy(ip net.IP) bool {
+	trustedProxiesOnce.Do(loadTrustedProxies)
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the caller address. It is the socket address of the request unless that is a
+// trusted proxy, in which case the forwarding headers are followed back to the first address that
+// is not a trusted proxy.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isTrustedProxy(ip) {
+		return host
+	}
+
+	// Each proxy appends the address it got the request from, so the right-most entries are the
+	// ones our proxies wrote and everything left of the first untrusted one may be forged.
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		if !isTrustedProxy(hop) {
+			return hop.String()
+		}
+	}
+	if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); realIP != nil {
+		return realIP.String()
+	}
+	return host
+}
@@ -0,0 +1,183 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+const dateTimeFormat = "2006-01-02 15:04:05"
+
+type table struct {
+	name   string
+	data   any
+	header []string
+	rows   [][]string
+}
+
+// WriteArchive writes the user's data as a ZIP archive containing a JSON and a CSV file per section.
+func WriteArchive(w io.Writer, data dto.UserExport) error {
+	zw := zip.NewWriter(w)
+
+	for _, t := range tables(data) {
+		if err := writeJSON(zw, t.name+".json", data.GeneratedAt, t.data); err != nil {
+			return err
+		}
+		if err := writeCSV(zw, t.name+".csv", data.GeneratedAt, t.header, t.rows); err != nil {
+			return err
+		}
+	}
+
+	readme := fmt.Sprintf("SplitWise personal data export for %s (%s)\nGenerated at: %s UTC\n",
+		data.Profile.Name, data.Profile.Email, data.GeneratedAt.UTC().Format(dateTimeFormat))
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "README.txt", Method: zip.Deflate, Modified: data.GeneratedAt})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(f, readme); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func tables(data dto.UserExport) []table {
+	profile := table{
+		name:   "profile",
+		data:   data.Profile,
+		header: []string{"id", "email", "name", "email_visibility", "created_at", "updated_at"},
+		rows: [][]string{{
+			uintStr(data.Profile.ID),
+			data.Profile.Email,
+			data.Profile.Name,
+			data.Profile.EmailVisibility,
+			data.Profile.CreatedAt.Format(dateTimeFormat),
+			data.Profile.UpdatedAt.Format(dateTimeFormat),
+		}},
+	}
+
+	groups := table{
+		name:   "groups",
+		data:   data.Groups,
+		header: []string{"id", "name", "created_by", "bill_id", "total_amount", "per_user_split_amount", "paid_amount", "status", "created_at", "updated_at"},
+	}
+	for _, g := range data.Groups {
+		groups.rows = append(groups.rows, []string{
+			uintStr(g.ID),
+			g.Name,
+			uintStr(g.CreatedBy),
+			uintStr(g.BillID),
+			amount(g.TotalAmount),
+			amount(g.PerUserSplitAmount),
+			amount(g.PaidAmount),
+			g.Status,
+			g.CreatedAt.Format(dateTimeFormat),
+			g.UpdatedAt.Format(dateTimeFormat),
+		})
+	}
+
+	bills := table{
+		name:   "bills",
+		data:   data.Bills,
+		header: []string{"id", "group_id", "name", "amount", "completed", "created_at"},
+	}
+	for _, b := range data.Bills {
+		bills.rows = append(bills.rows, []string{
+			uintStr(b.ID),
+			uintStr(b.GroupID),
+			b.Name,
+			amount(b.Amount),
+			strconv.FormatBool(b.Completed),
+			b.CreatedAt.Format(dateTimeFormat),
+		})
+	}
+
+	splits := table{
+		name:   "splits",
+		data:   data.Splits,
+		header: []string{"id", "group_id", "split_amount", "has_paid", "remarks", "created_at", "updated_at"},
+	}
+	for _, s := range data.Splits {
+		splits.rows = append(splits.rows, []string{
+			uintStr(s.ID),
+			uintStr(s.GroupID),
+			amount(s.SplitAmount),
+			strconv.FormatBool(s.HasPaid),
+			s.Remarks,
+			s.CreatedAt.Format(dateTimeFormat),
+			s.UpdatedAt.Format(dateTimeFormat),
+		})
+	}
+
+	payments := table{
+		name:   "payments",
+		data:   data.Payments,
+		header: []string{"group_id", "group_name", "amount", "remarks", "paid_at"},
+	}
+	for _, p := range data.Payments {
+		payments.rows = append(payments.rows, []string{
+			uintStr(p.GroupID),
+			p.GroupName,
+			amount(p.Amount),
+			p.Remarks,
+			p.PaidAt.Format(dateTimeFormat),
+		})
+	}
+
+	history := table{
+		name:   "bill_history",
+		data:   data.BillHistory,
+		header: []string{"id", "bill_id", "amount", "paid_by", "paid_at", "created_at"},
+	}
+	for _, h := range data.BillHistory {
+		history.rows = append(history.rows, []string{
+			uintStr(h.ID),
+			uintStr(h.BillID),
+			amount(h.Amount),
+			h.PaidBy,
+			h.PaidAt.Format(dateTimeFormat),
+			h.CreatedAt.Format(dateTimeFormat),
+		})
+	}
+
+	return []table{profile, groups, bills, splits, payments, history}
+}
+
+func writeJSON(zw *zip.Writer, name string, modified time.Time, v any) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeCSV(zw *zip.Writer, name string, modified time.Time, header []string, rows [][]string) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func uintStr(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func amount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
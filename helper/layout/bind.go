@@ -0,0 +1,150 @@
+package layout
+
+import "github.com/mohdjishin/SplitWise/internal/models/dto"
+
+// Document is a template filled with the data of a report, ready to be drawn.
+type Document struct {
+	Title    string
+	Branding Branding
+	Locale   Locale
+	Blocks   []Block
+}
+
+// Block is a section of a document. Type is the section type; the other fields are set as for the section.
+type Block struct {
+	Type       string
+	Title      string
+	Text       string
+	Fields     []Field               // fields
+	Columns    []Column              // table, with titles, alignment and widths filled in
+	Rows       [][]Value             // table
+	Highlights []string              // table, per row
+	Empty      string                // table without rows
+	Chart      *Chart                // chart
+	Comments   []dto.CommentResponse // comments
+	BillName   string                // comments, the bill the comments on a bill refer to
+}
+
+// Hidden tells whether a table has no rows and nothing to say about it, in which case PDF and HTML
+// reports leave it out. Spreadsheets still get its header.
+func (b Block) Hidden() bool {
+	return b.Type == SectionTable && len(b.Rows) == 0 && b.Empty == ""
+}
+
+// Field is a labelled value of a fields block.
+type Field struct {
+	Label string
+	Value Value
+}
+
+// Bind fills the template with the report data. Charts without data and comments when there are
+// none are left out.
+func (t Template) Bind(data Data) Document {
+	doc := Document{Title: expand(t.Title, data, t.Locale), Branding: t.Branding, Locale: t.Locale}
+	for _, s := range t.Sections {
+		b := Block{Type: s.Type, Title: expand(s.Title, data, t.Locale)}
+		switch s.Type {
+		case SectionText:
+			b.Text = expand(s.Text, data, t.Locale)
+		case SectionFields:
+			set := datasets[s.Data]
+			record := set.rows(data)[0]
+			for _, c := range s.Fields {
+				f := set.fields[c.Field]
+				label := c.Title
+				if label == "" {
+					label = f.title
+				}
+				b.Fields = append(b.Fields, Field{Label: label, Value: f.value(record)})
+			}
+		case SectionTable:
+			set := datasets[s.Data]
+			b.Columns = columns(set, s.Columns)
+			for _, row := range set.rows(data) {
+				values := make([]Value, len(s.Columns))
+				for i, c := range s.Columns {
+					values[i] = set.fields[c.Field].value(row)
+				}
+				b.Rows = append(b.Rows, values)
+				highlight := ""
+				if set.highlight != nil {
+					highlight = set.highlight(row)
+				}
+				b.Highlights = append(b.Highlights, highlight)
+			}
+			b.Empty = expand(s.Empty, data, t.Locale)
+		case SectionChart:
+			if b.Chart = bindChart(s.Chart, data, t.Locale); b.Chart.Empty() {
+				continue
+			}
+		case SectionComments:
+			if len(data.Group.Comments) == 0 {
+				continue
+			}
+			b.Comments, b.BillName = data.Group.Comments, data.Group.Bill.Name
+		}
+		doc.Blocks = append(doc.Blocks, b)
+	}
+	return doc
+}
+
+// columns fills in the titles, alignment and widths the template left out. Columns wider than the page
+// together are narrowed in proportion, but not below MinColumnWidth.
+func columns(set dataset, cs []Column) []Column {
+	resolved := resolveColumns(set, cs)
+	total := 0.0
+	for _, c := range resolved {
+		total += c.Width
+	}
+	if total > PageWidth {
+		for i := range resolved {
+			resolved[i].Width *= PageWidth / total
+		}
+	}
+	// Templates saved before the minimum existed can still narrow a column too far. Such columns get
+	// the minimum and the others share what is left, which always suffices as maxColumns columns of
+	// the minimum width fit on the page.
+	for {
+		fixed, narrowed, rest := 0, 0, 0.0
+		for _, c := range resolved {
+			if c.Width <= MinColumnWidth {
+				fixed++
+				if c.Width < MinColumnWidth {
+					narrowed++
+				}
+			} else {
+				rest += c.Width
+			}
+		}
+		if narrowed == 0 {
+			return resolved
+		}
+		scale := (PageWidth - float64(fixed)*MinColumnWidth) / rest
+		for i := range resolved {
+			if resolved[i].Width <= MinColumnWidth {
+				resolved[i].Width = MinColumnWidth
+			} else if scale < 1 {
+				resolved[i].Width *= scale
+			}
+		}
+	}
+}
+
+// resolveColumns fills in the titles, alignment and widths the template left out.
+func resolveColumns(set dataset, cs []Column) []Column {
+	resolved := make([]Column, len(cs))
+	for i, c := range cs {
+		f := set.fields[c.Field]
+		if c.Title == "" {
+			c.Title = f.title
+		}
+		if c.Align == "" {
+			c.Align = f.align
+		}
+		if c.Width == 0 {
+			c.Width = f.width
+		}
+		resolved[i] = c
+	}
+	return resolved
+}
@@ -0,0 +1,208 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+// Charts
+const (
+	ChartPaid         = "paid"         // paid versus outstanding per group
+	ChartSpending     = "spending"     // bill amounts over the report period
+	ChartCategories   = "categories"   // bill amounts per category
+	ChartProgress     = "progress"     // how much of the group total has been paid
+	ChartContribution = "contribution" // paid versus outstanding per member
+	ChartPayments     = "payments"     // running total of the payments over time
+)
+
+// Chart is the data of a chart section.
+type Chart struct {
+	Type    string
+	Bars    []Bar    // paid, contribution and categories
+	Periods []Period // spending
+	Paid    float64  // progress
+	Total   float64  // progress and payments, the amount to collect
+	Points  []Point  // payments, in time order
+	Start   time.Time
+	End     time.Time
+}
+
+// Bar is one entry of a bar chart. Paid and outstanding bars use Paid and Outstanding, category bars Amount.
+type Bar struct {
+	Label       string
+	Paid        float64
+	Outstanding float64
+	Amount      float64
+}
+
+// Period is one column of the spending chart.
+type Period struct {
+	Label  string
+	Amount float64
+}
+
+// Point is an amount at a moment in time.
+type Point struct {
+	At     time.Time
+	Amount float64
+}
+
+func bindChart(kind string, data Data, locale Locale) *Chart {
+	c := &Chart{Type: kind}
+	switch kind {
+	case ChartPaid:
+		c.Bars = groupPaidBars(data.Groups)
+	case ChartSpending:
+		spend := make([]Point, 0, len(data.Groups))
+		for _, group := range data.Groups {
+			spend = append(spend, Point{At: group.Bills.Date, Amount: group.Bills.Amount})
+		}
+		c.Periods = Periods(data.From, data.To, spend, locale)
+	case ChartCategories:
+		byCategory := map[string]float64{}
+		for _, group := range data.Groups {
+			byCategory[group.Bills.Category] += group.Bills.Amount
+		}
+		c.Bars = CategoryBars(byCategory)
+	case ChartProgress:
+		c.Paid, c.Total = data.Group.Group.PaidAmount, data.Group.Group.TotalAmount
+	case ChartContribution:
+		for _, member := range data.Group.Members {
+			bar := Bar{Label: name(data.Group.UserInfo, member.UserID), Outstanding: member.SplitAmount}
+			if member.HasPaid {
+				bar.Paid, bar.Outstanding = member.SplitAmount, 0
+			}
+			c.Bars = append(c.Bars, bar)
+		}
+	case ChartPayments:
+		for _, history := range data.Group.History {
+			c.Points = append(c.Points, Point{At: history.PaidAt, Amount: history.Amount})
+		}
+		sort.Slice(c.Points, func(i, j int) bool { return c.Points[i].At.Before(c.Points[j].At) })
+		c.Total = data.Group.Group.TotalAmount
+		c.Start, c.End = data.Group.Group.CreatedAt, data.Group.Group.UpdatedAt
+		if len(c.Points) > 0 {
+			if first := c.Points[0].At; first.Before(c.Start) {
+				c.Start = first
+			}
+			if last := c.Points[len(c.Points)-1].At; last.After(c.End) {
+				c.End = last
+			}
+		}
+		if !c.End.After(c.Start) {
+			c.End = c.Start.Add(24 * time.Hour)
+		}
+	}
+	return c
+}
+
+// Empty tells whether there is nothing to draw.
+func (c *Chart) Empty() bool {
+	switch c.Type {
+	case ChartProgress:
+		return c.Total <= 0
+	case ChartSpending:
+		return len(c.Periods) == 0
+	case ChartPayments:
+		return len(c.Points) == 0
+	}
+	return len(c.Bars) == 0
+}
+
+// groupPaidBars returns the paid and outstanding amounts of the largest groups, the rest summed up in
+// one bar so the chart stays readable.
+func groupPaidBars(groups []dto.Group) []Bar {
+	const maxBars = 15
+	seen := map[string]bool{}
+	var bars []Bar
+	for _, group := range groups {
+		if seen[group.ID] {
+			continue
+		}
+		seen[group.ID] = true
+		bars = append(bars, Bar{Label: group.Name, Paid: group.PaidAmount, Outstanding: max(group.TotalAmount-group.PaidAmount, 0)})
+	}
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].Paid+bars[i].Outstanding > bars[j].Paid+bars[j].Outstanding
+	})
+	if len(bars) <= maxBars {
+		return bars
+	}
+	others := Bar{Label: fmt.Sprintf("%d other groups", len(bars)-maxBars+1)}
+	for _, bar := range bars[maxBars-1:] {
+		others.Paid += bar.Paid
+		others.Outstanding += bar.Outstanding
+	}
+	return append(bars[:maxBars-1], others)
+}
+
+// CategoryBars turns per category sums into bars ordered by amount, largest first.
+func CategoryBars(sums map[string]float64) []Bar {
+	bars := make([]Bar, 0, len(sums))
+	for category, amount := range sums {
+		if category == "" {
+			category = "uncategorised"
+		}
+		bars = append(bars, Bar{Label: category, Amount: amount})
+	}
+	sort.Slice(bars, func(i, j int) bool {
+		if bars[i].Amount != bars[j].Amount {
+			return bars[i].Amount > bars[j].Amount
+		}
+		return bars[i].Label < bars[j].Label
+	})
+	return bars
+}
+
+// Periods splits the range into days, weeks, months or years, whichever gives a readable number of
+// columns, and sums the amounts of each. Periods are labelled in the locale's language.
+func Periods(from, to time.Time, amounts []Point, locale Locale) []Period {
+	days := to.Sub(from).Hours() / 24
+	day := "MMM D"
+	if strings.HasPrefix(locale.DateFormat, "D") {
+		day = "D MMM"
+	}
+	var (
+		begin  func(time.Time) time.Time
+		next   func(time.Time) time.Time
+		layout string
+	)
+	switch {
+	case days <= 31:
+		begin = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()) }
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+		layout = day
+	case days <= 182:
+		begin = func(t time.Time) time.Time {
+			day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+			return day.AddDate(0, 0, -(int(day.Weekday())+6)%7) // weeks start on Monday
+		}
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+		layout = day
+	case days <= 3*366:
+		begin = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()) }
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+		layout = "MMM YYYY"
+	default:
+		begin = func(t time.Time) time.Time { return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location()) }
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+		layout = "YYYY"
+	}
+
+	var result []Period
+	index := map[time.Time]int{}
+	for t := begin(from); !t.After(to); t = next(t) {
+		index[t] = len(result)
+		result = append(result, Period{Label: locale.formatTime(t, layout)})
+	}
+	for _, a := range amounts {
+		if i, ok := index[begin(a.At.In(from.Location()))]; ok {
+			result[i].Amount += a.Amount
+		}
+	}
+	return result
+}
@@ -0,0 +1,311 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+// Data is what a report is made of.
+type Data struct {
+	Kind     string
+	Owner    string
+	From, To time.Time
+	Groups   []dto.Group            // groups reports
+	Group    dto.GroupReportRequest // group reports
+}
+
+// OwnerData is the data of the report of the groups an owner created in a date range.
+func OwnerData(groups []dto.Group, from, to time.Time, owner string) Data {
+	return Data{Kind: KindGroups, Owner: owner, From: from, To: to, Groups: groups}
+}
+
+// GroupData is the data of the detailed report of a group.
+func GroupData(report dto.GroupReportRequest) Data {
+	return Data{Kind: KindGroup, Group: report}
+}
+
+// ValueKind tells renderers how to write a value.
+type ValueKind int
+
+const (
+	KindText ValueKind = iota
+	KindMoney
+	KindNumber
+	KindPercent
+	KindDate
+	KindDateTime
+)
+
+// Value is a field of a report, formatted by the renderer with the template's locale.
+type Value struct {
+	Kind   ValueKind
+	Text   string
+	Number float64
+	Time   time.Time
+}
+
+func text(s string) Value     { return Value{Kind: KindText, Text: s} }
+func money(v float64) Value   { return Value{Kind: KindMoney, Number: v} }
+func number(v int) Value      { return Value{Kind: KindNumber, Number: float64(v)} }
+func percent(v float64) Value { return Value{Kind: KindPercent, Number: v} }
+func date(t time.Time, withTime bool) Value {
+	if withTime {
+		return Value{Kind: KindDateTime, Time: t}
+	}
+	return Value{Kind: KindDate, Time: t}
+}
+
+// optionalDate is a date, or the placeholder when it is not set.
+func optionalDate(t *time.Time, withTime bool, placeholder string) Value {
+	if t == nil || t.IsZero() {
+		return text(placeholder)
+	}
+	return date(*t, withTime)
+}
+
+func yesNo(b bool) Value {
+	if b {
+		return text("Yes")
+	}
+	return text("No")
+}
+
+// field is something a template can show from a dataset, with the title, alignment and PDF width it gets by default.
+type field struct {
+	title string
+	align string
+	width float64
+	value func(row any) Value
+}
+
+func fieldOf[T any](title, align string, width float64, value func(T) Value) field {
+	return field{title: title, align: align, width: width, value: func(row any) Value { return value(row.(T)) }}
+}
+
+// dataset is a source of fields: a single record for fields sections, rows for table sections.
+type dataset struct {
+	kind      string
+	table     bool
+	fields    map[string]field
+	order     []string
+	rows      func(Data) []any
+	highlight func(row any) string // optional, "warning" or "exceeded"
+}
+
+func newDataset(kind string, table bool, rows func(Data) []any, fields ...struct {
+	name string
+	field
+}) dataset {
+	d := dataset{kind: kind, table: table, rows: rows, fields: map[string]field{}}
+	for _, f := range fields {
+		d.fields[f.name] = f.field
+		d.order = append(d.order, f.name)
+	}
+	return d
+}
+
+type named = struct {
+	name string
+	field
+}
+
+// Rows of the datasets
+type (
+	ownerSummary struct{ data Data }
+	memberRow    struct {
+		report dto.GroupReportRequest
+		member models.GroupMember
+	}
+	attachmentRow struct {
+		report      dto.GroupReportRequest
+		attachment  models.Attachment
+		memberUsers map[uint]uint
+	}
+)
+
+var datasets = map[string]dataset{
+	"summary": newDataset(KindGroups, false,
+		func(d Data) []any { return []any{ownerSummary{d}} },
+		named{"owner", fieldOf("Owner", "L", 40, func(s ownerSummary) Value { return text(s.data.Owner) })},
+		named{"from", fieldOf("From", "C", 22, func(s ownerSummary) Value { return date(s.data.From, false) })},
+		named{"to", fieldOf("To", "C", 22, func(s ownerSummary) Value { return date(s.data.To, false) })},
+		named{"groups", fieldOf("Groups", "C", 14, func(s ownerSummary) Value { return number(len(groupIDs(s.data.Groups))) })},
+		named{"totalAmount", fieldOf("Total Amount", "R", 20, func(s ownerSummary) Value { total, _ := ownerTotals(s.data.Groups); return money(total) })},
+		named{"paidAmount", fieldOf("Paid Amount", "R", 20, func(s ownerSummary) Value { _, paid := ownerTotals(s.data.Groups); return money(paid) })},
+		named{"outstanding", fieldOf("Outstanding Amount", "R", 20, func(s ownerSummary) Value {
+			total, paid := ownerTotals(s.data.Groups)
+			return money(max(total-paid, 0))
+		})},
+	),
+	"groups": newDataset(KindGroups, true,
+		func(d Data) []any { return rowsOf(d.Groups) },
+		named{"id", fieldOf("Group ID", "C", 14, func(g dto.Group) Value { return text(g.ID) })},
+		named{"name", fieldOf("Group Name", "L", 38, func(g dto.Group) Value { return text(g.Name) })},
+		named{"owner", fieldOf("Owner", "L", 25, func(g dto.Group) Value { return text(g.Owner) })},
+		named{"category", fieldOf("Category", "L", 22, func(g dto.Group) Value { return text(g.Bills.Category) })},
+		named{"status", fieldOf("Status", "C", 18, func(g dto.Group) Value { return text(g.Status) })},
+		named{"billAmount", fieldOf("Bill Amount", "R", 20, func(g dto.Group) Value { return money(g.Bills.Amount) })},
+		named{"totalAmount", fieldOf("Total Amount", "R", 20, func(g dto.Group) Value { return money(g.TotalAmount) })},
+		named{"share", fieldOf("Share Per Member", "R", 20, func(g dto.Group) Value { return money(g.PerUserSplitAmount) })},
+		named{"paidAmount", fieldOf("Paid Amount", "R", 18, func(g dto.Group) Value { return money(g.PaidAmount) })},
+		named{"outstanding", fieldOf("Outstanding", "R", 20, func(g dto.Group) Value { return money(max(g.TotalAmount-g.PaidAmount, 0)) })},
+		named{"members", fieldOf("Members", "C", 14, func(g dto.Group) Value { return number(g.Members) })},
+		named{"lastBillDate", fieldOf("Last Bill Date", "C", 22, func(g dto.Group) Value { return optionalDate(&g.Bills.Date, false, "N/A") })},
+	),
+	"group": newDataset(KindGroup, false,
+		func(d Data) []any { return []any{d.Group} },
+		named{"groupId", fieldOf("Group ID", "C", 14, func(r dto.GroupReportRequest) Value { return text(strconv.FormatUint(uint64(r.Group.ID), 10)) })},
+		named{"groupName", fieldOf("Group Name", "L", 40, func(r dto.GroupReportRequest) Value { return text(r.Group.Name) })},
+		named{"owner", fieldOf("Owner", "L", 40, func(r dto.GroupReportRequest) Value { return text(name(r.UserInfo, r.Group.CreatedBy)) })},
+		named{"status", fieldOf("Status", "C", 18, func(r dto.GroupReportRequest) Value { return text(r.Group.Status) })},
+		named{"billName", fieldOf("Bill Name", "L", 40, func(r dto.GroupReportRequest) Value { return text(r.Bill.Name) })},
+		named{"category", fieldOf("Category", "L", 22, func(r dto.GroupReportRequest) Value { return text(r.Bill.Category) })},
+		named{"billAmount", fieldOf("Bill Amount", "R", 20, func(r dto.GroupReportRequest) Value { return money(r.Bill.Amount) })},
+		named{"totalAmount", fieldOf("Total Amount", "R", 20, func(r dto.GroupReportRequest) Value { return money(r.Group.TotalAmount) })},
+		named{"share", fieldOf("Share Per Member", "R", 20, func(r dto.GroupReportRequest) Value { return money(r.Group.PerUserSplitAmount) })},
+		named{"paidAmount", fieldOf("Paid Amount", "R", 20, func(r dto.GroupReportRequest) Value { return money(r.Group.PaidAmount) })},
+		named{"outstanding", fieldOf("Outstanding Amount", "R", 20, func(r dto.GroupReportRequest) Value {
+			return money(max(r.Group.TotalAmount-r.Group.PaidAmount, 0))
+		})},
+		named{"members", fieldOf("Members", "C", 14, func(r dto.GroupReportRequest) Value { return number(len(r.Members)) })},
+		named{"createdAt", fieldOf("Created At", "C", 32, func(r dto.GroupReportRequest) Value { return date(r.Group.CreatedAt, true) })},
+		named{"updatedAt", fieldOf("Updated At", "C", 32, func(r dto.GroupReportRequest) Value { return date(r.Group.UpdatedAt, true) })},
+	),
+	"members": newDataset(KindGroup, true,
+		func(d Data) []any {
+			rows := make([]any, len(d.Group.Members))
+			for i, m := range d.Group.Members {
+				rows[i] = memberRow{d.Group, m}
+			}
+			return rows
+		},
+		named{"name", fieldOf("Member Name", "L", 55, func(m memberRow) Value { return text(name(m.report.UserInfo, m.member.UserID)) })},
+		named{"splitAmount", fieldOf("Split Amount", "R", 30, func(m memberRow) Value { return money(m.member.SplitAmount) })},
+		named{"hasPaid", fieldOf("Has Paid", "C", 20, func(m memberRow) Value { return yesNo(m.member.HasPaid) })},
+		named{"paidAt", fieldOf("Paid At", "C", 35, func(m memberRow) Value { return optionalDate(m.member.PaidAt, true, "-") })},
+		named{"remarks", fieldOf("Remarks", "L", 50, func(m memberRow) Value { return text(m.member.Remarks) })},
+	),
+	"payments": newDataset(KindGroup, true,
+		func(d Data) []any { return rowsOf(d.Group.History) },
+		named{"paidBy", fieldOf("Paid By", "L", 80, func(h models.BillHistory) Value { return text(h.PaidBy) })},
+		named{"amount", fieldOf("Amount", "R", 45, func(h models.BillHistory) Value { return money(h.Amount) })},
+		named{"paidAt", fieldOf("Paid At", "C", 65, func(h models.BillHistory) Value { return date(h.PaidAt, true) })},
+	),
+	"attachments": newDataset(KindGroup, true,
+		func(d Data) []any {
+			memberUsers := make(map[uint]uint, len(d.Group.Members))
+			for _, member := range d.Group.Members {
+				memberUsers[member.ID] = member.UserID
+			}
+			rows := make([]any, len(d.Group.Attachments))
+			for i, a := range d.Group.Attachments {
+				rows[i] = attachmentRow{d.Group, a, memberUsers}
+			}
+			return rows
+		},
+		named{"attachment", fieldOf("Attachment", "L", 38, func(a attachmentRow) Value {
+			if a.attachment.PaymentID != nil {
+				return text(fmt.Sprintf("Payment proof of %s", name(a.report.UserInfo, a.memberUsers[*a.attachment.PaymentID])))
+			}
+			return text("Bill receipt")
+		})},
+		named{"fileName", fieldOf("File", "L", 50, func(a attachmentRow) Value { return text(a.attachment.FileName) })},
+		named{"contentType", fieldOf("Type", "L", 28, func(a attachmentRow) Value { return text(a.attachment.ContentType) })},
+		named{"size", fieldOf("Size", "R", 16, func(a attachmentRow) Value {
+			return text(fmt.Sprintf("%.1f KB", float64(a.attachment.Size)/1024))
+		})},
+		named{"uploadedBy", fieldOf("Uploaded By", "L", 30, func(a attachmentRow) Value { return text(name(a.report.UserInfo, a.attachment.UploadedBy)) })},
+		named{"uploadedAt", fieldOf("Uploaded At", "C", 28, func(a attachmentRow) Value { return date(a.attachment.CreatedAt, true) })},
+	),
+	"budgets": func() dataset {
+		d := newDataset(KindGroup, true,
+			func(d Data) []any { return rowsOf(d.Group.Budgets) },
+			named{"budget", fieldOf("Budget", "L", 45, func(b dto.BudgetStatus) Value {
+				if b.Category == "" {
+					return text("Whole group")
+				}
+				return text(b.Category)
+			})},
+			named{"amount", fieldOf("Amount", "R", 30, func(b dto.BudgetStatus) Value { return money(b.Amount) })},
+			named{"spent", fieldOf("Spent", "R", 30, func(b dto.BudgetStatus) Value { return money(b.Spent) })},
+			named{"remaining", fieldOf("Remaining", "R", 30, func(b dto.BudgetStatus) Value { return money(b.Remaining) })},
+			named{"used", fieldOf("Used", "R", 20, func(b dto.BudgetStatus) Value { return percent(b.PercentUsed) })},
+			named{"status", fieldOf("Status", "C", 35, func(b dto.BudgetStatus) Value { return text(b.Status) })},
+		)
+		d.highlight = func(row any) string {
+			switch row.(dto.BudgetStatus).Status {
+			case dto.BudgetExceeded:
+				return HighlightExceeded
+			case dto.BudgetWarning:
+				return HighlightWarning
+			}
+			return ""
+		}
+		return d
+	}(),
+}
+
+// Row highlights
+const (
+	HighlightWarning  = "warning"
+	HighlightExceeded = "exceeded"
+)
+
+// charts lists the charts available per kind of report.
+var charts = map[string][]string{
+	KindGroups: {ChartPaid, ChartSpending, ChartCategories},
+	KindGroup:  {ChartProgress, ChartContribution, ChartPayments},
+}
+
+func datasetNames(kind string, table bool) []string {
+	var names []string
+	for n, d := range datasets {
+		if d.kind == kind && d.table == table {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func rowsOf[T any](items []T) []any {
+	rows := make([]any, len(items))
+	for i, item := range items {
+		rows[i] = item
+	}
+	return rows
+}
+
+// name returns the name of a user in the report, falling back to their id.
+func name(users map[uint]string, id uint) string {
+	if n, ok := users[id]; ok && n != "" {
+		return n
+	}
+	return fmt.Sprintf("User %d", id)
+}
+
+func groupIDs(groups []dto.Group) map[string]bool {
+	ids := map[string]bool{}
+	for _, g := range groups {
+		ids[g.ID] = true
+	}
+	return ids
+}
+
+// ownerTotals sums the groups of an owner report, which may list a group once per bill.
+func ownerTotals(groups []dto.Group) (total, paid float64) {
+	seen := map[string]bool{}
+	for _, g := range groups {
+		if !seen[g.ID] {
+			seen[g.ID] = true
+			total += g.TotalAmount
+			paid += g.PaidAmount
+		}
+	}
+	return total, paid
+}
@@ -0,0 +1,140 @@
+package layout
+
+// Default returns the bundled template of a kind of report for a format, which reproduces the layout
+// reports had before templates. Spreadsheet formats get their own column order.
+func Default(kind, format string) Template {
+	if format == "csv" {
+		if kind == KindGroup {
+			return defaultGroupCSV
+		}
+		return defaultGroupsCSV
+	}
+	if kind == KindGroup {
+		return defaultGroup
+	}
+	return defaultGroups
+}
+
+var defaultGroups = Template{
+	Title: "Monthly/Weekly Report for Groups Owned by {owner}",
+	Sections: []Section{
+		{Type: SectionText, Text: "Report Period: {from} - {to}"},
+		{Type: SectionTable, Data: "groups", Columns: []Column{
+			{Field: "id"},
+			{Field: "name"},
+			{Field: "category"},
+			{Field: "totalAmount"},
+			{Field: "share"},
+			{Field: "paidAmount"},
+			{Field: "members"},
+			{Field: "status"},
+			{Field: "lastBillDate"},
+		}},
+		{Type: SectionChart, Title: "Paid vs Outstanding by Group", Chart: ChartPaid},
+		{Type: SectionChart, Title: "Spending Over Time", Chart: ChartSpending},
+		{Type: SectionChart, Title: "Spending by Category", Chart: ChartCategories},
+	},
+}
+
+var defaultGroupsCSV = Template{
+	Title: defaultGroups.Title,
+	Sections: []Section{
+		{Type: SectionTable, Data: "groups", Columns: []Column{
+			{Field: "id"},
+			{Field: "name"},
+			{Field: "totalAmount"},
+			{Field: "share"},
+			{Field: "paidAmount"},
+			{Field: "members"},
+			{Field: "status"},
+			{Field: "category"},
+			{Field: "lastBillDate"},
+		}},
+	},
+}
+
+var defaultGroup = Template{
+	Title: "Report for Group: {group}",
+	Sections: []Section{
+		{Type: SectionFields, Title: "Owner and Bill Details", Data: "group", Fields: []Column{
+			{Field: "owner"},
+			{Field: "billName"},
+			{Field: "category"},
+			{Field: "billAmount", Title: "Total Amount"},
+			{Field: "groupId"},
+		}},
+		{Type: SectionFields, Title: "Group Details", Data: "group", Fields: []Column{
+			{Field: "groupId"},
+			{Field: "createdAt"},
+			{Field: "updatedAt"},
+			{Field: "totalAmount"},
+			{Field: "paidAmount"},
+			{Field: "status"},
+		}},
+		{Type: SectionTable, Title: "Budget vs Actual", Data: "budgets", Columns: []Column{
+			{Field: "budget"},
+			{Field: "amount"},
+			{Field: "spent"},
+			{Field: "remaining"},
+			{Field: "used"},
+			{Field: "status"},
+		}},
+		{Type: SectionChart, Title: "Paid vs Outstanding", Chart: ChartProgress},
+		{Type: SectionChart, Title: "Contribution per Member", Chart: ChartContribution},
+		{Type: SectionChart, Title: "Payments Over Time", Chart: ChartPayments},
+		{Type: SectionTable, Title: "Payment History", Data: "payments", Empty: "No payments yet.", Columns: []Column{
+			{Field: "paidBy"},
+			{Field: "amount"},
+			{Field: "paidAt"},
+		}},
+		{Type: SectionTable, Title: "Members", Data: "members", Columns: []Column{
+			{Field: "name"},
+			{Field: "splitAmount"},
+			{Field: "hasPaid"},
+			{Field: "paidAt"},
+			{Field: "remarks"},
+		}},
+		{Type: SectionTable, Title: "Attachments", Data: "attachments", Columns: []Column{
+			{Field: "attachment"},
+			{Field: "fileName"},
+			{Field: "contentType"},
+			{Field: "size"},
+			{Field: "uploadedBy"},
+			{Field: "uploadedAt"},
+		}},
+		{Type: SectionComments, Title: "Appendix: Comments"},
+	},
+}
+
+var defaultGroupCSV = Template{
+	Title: defaultGroup.Title,
+	Sections: []Section{
+		{Type: SectionFields, Data: "group", Fields: []Column{
+			{Field: "groupId"},
+			{Field: "groupName"},
+			{Field: "owner"},
+			{Field: "status"},
+			{Field: "billName"},
+			{Field: "category"},
+			{Field: "billAmount"},
+			{Field: "totalAmount"},
+			{Field: "share"},
+			{Field: "paidAmount"},
+			{Field: "outstanding"},
+			{Field: "members"},
+			{Field: "createdAt"},
+			{Field: "updatedAt"},
+		}},
+		{Type: SectionTable, Data: "members", Columns: []Column{
+			{Field: "name", Title: "Member"},
+			{Field: "splitAmount"},
+			{Field: "hasPaid"},
+			{Field: "remarks"},
+		}},
+		{Type: SectionTable, Data: "payments", Columns: []Column{
+			{Field: "paidAt"},
+			{Field: "paidBy"},
+			{Field: "amount"},
+		}},
+	},
+}
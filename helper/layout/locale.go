@@ -0,0 +1,276 @@
+package layout
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Locale controls how amounts and dates are written in a report.
+type Locale struct {
+	Language           string `json:"language,omitempty"`           // language of month names: en (default), de, es, fr, it, nl or pt
+	Currency           string `json:"currency,omitempty"`           // currency symbol, $ by default
+	CurrencyAfter      bool   `json:"currencyAfter,omitempty"`      // write the symbol after the amount, as in 1.234,56 €
+	DecimalSeparator   string `json:"decimalSeparator,omitempty"`   // . (default) or ,
+	ThousandsSeparator string `json:"thousandsSeparator,omitempty"` // none by default
+	Grouping           string `json:"grouping,omitempty"`           // thousands (default) or indian, as in 1,23,456
+	DateFormat         string `json:"dateFormat,omitempty"`         // YYYY-MM-DD by default
+	DateTimeFormat     string `json:"dateTimeFormat,omitempty"`     // YYYY-MM-DD HH:mm:ss by default
+	Timezone           string `json:"timezone,omitempty"`           // IANA name, times are written as stored by default
+}
+
+// Digit groupings
+const (
+	GroupingThousands = "thousands" // 1,234,567
+	GroupingIndian    = "indian"    // 12,34,567
+)
+
+const (
+	defaultCurrency       = "$"
+	defaultDateFormat     = "YYYY-MM-DD"
+	defaultDateTimeFormat = "YYYY-MM-DD HH:mm:ss"
+)
+
+// Validate checks the separators, date formats and timezone.
+func (l Locale) Validate() error {
+	if len([]rune(l.Currency)) > 5 {
+		return fmt.Errorf("currency must be at most 5 characters")
+	}
+	if l.DecimalSeparator != "" && l.DecimalSeparator != "." && l.DecimalSeparator != "," {
+		return fmt.Errorf("decimalSeparator must be . or ,")
+	}
+	if l.Language != "" && monthNames[l.Language] == nil {
+		return fmt.Errorf("language must be one of %s", strings.Join(languages(), ", "))
+	}
+	if l.Grouping != "" && l.Grouping != GroupingThousands && l.Grouping != GroupingIndian {
+		return fmt.Errorf("grouping must be %s or %s", GroupingThousands, GroupingIndian)
+	}
+	if len([]rune(l.ThousandsSeparator)) > 1 || l.ThousandsSeparator != "" && l.ThousandsSeparator == l.decimal() {
+		return fmt.Errorf("thousandsSeparator must be a single character other than the decimal separator")
+	}
+	for _, format := range []string{l.DateFormat, l.DateTimeFormat} {
+		if len(format) > 40 || strings.IndexFunc(format, unicode.IsDigit) >= 0 {
+			return fmt.Errorf("date format %q must be at most 40 characters and use YYYY, MM, DD, HH, mm and ss instead of digits", format)
+		}
+	}
+	if l.Timezone != "" {
+		if _, err := time.LoadLocation(l.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", l.Timezone)
+		}
+	}
+	return nil
+}
+
+func (l Locale) decimal() string {
+	if l.DecimalSeparator == "" {
+		return "."
+	}
+	return l.DecimalSeparator
+}
+
+// Symbol returns the currency symbol.
+func (l Locale) Symbol() string {
+	if l.Currency == "" {
+		return defaultCurrency
+	}
+	return l.Currency
+}
+
+// Money writes an amount with the currency symbol.
+func (l Locale) Money(v float64) string {
+	return l.Amount(v, 2)
+}
+
+// Amount writes an amount with the given decimals and the currency symbol.
+func (l Locale) Amount(v float64, decimals int) string {
+	number := l.Number(math.Abs(v), decimals)
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+	if l.CurrencyAfter {
+		return sign + number + "\u00a0" + l.Symbol()
+	}
+	return sign + l.Symbol() + number
+}
+
+// Compact writes an amount in few characters, such as $12.5k, for chart axes.
+func (l Locale) Compact(v float64) string {
+	suffix := ""
+	switch {
+	case v >= 1e6:
+		v, suffix = v/1e6, "M"
+	case v >= 1e4:
+		v, suffix = v/1e3, "k"
+	}
+	number := strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+	number = strings.Replace(number, ".", l.decimal(), 1) + suffix
+	if l.CurrencyAfter {
+		return number + "\u00a0" + l.Symbol()
+	}
+	return l.Symbol() + number
+}
+
+// Number writes a number with the given decimals and the locale's separators.
+func (l Locale) Number(v float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	whole, fraction, _ := strings.Cut(s, ".")
+	if sep := l.ThousandsSeparator; sep != "" && len(whole) > 3 {
+		var b strings.Builder
+		for i, digit := range whole {
+			if i > 0 && l.groupsAt(len(whole)-i) {
+				b.WriteString(sep)
+			}
+			b.WriteRune(digit)
+		}
+		whole = b.String()
+	}
+	if v < 0 {
+		whole = "-" + whole
+	}
+	if fraction == "" {
+		return whole
+	}
+	return whole + l.decimal() + fraction
+}
+
+// groupsAt reports whether a separator goes before the digit with n digits from it to the decimal point.
+// Indian grouping separates the thousands, then every two digits.
+func (l Locale) groupsAt(n int) bool {
+	if l.Grouping == GroupingIndian {
+		return n == 3 || n > 3 && (n-3)%2 == 0
+	}
+	return n%3 == 0
+}
+
+// FormatTime writes a date, or a date and time, in the locale's format and timezone.
+func (l Locale) FormatTime(t time.Time, withTime bool) string {
+	format := l.DateFormat
+	if format == "" {
+		format = defaultDateFormat
+	}
+	if withTime {
+		format = l.DateTimeFormat
+		if format == "" {
+			format = defaultDateTimeFormat
+		}
+	}
+	return l.formatTime(l.In(t), format)
+}
+
+// In returns the time in the locale's timezone.
+func (l Locale) In(t time.Time) time.Time {
+	if l.Timezone != "" {
+		if loc, err := time.LoadLocation(l.Timezone); err == nil {
+			return t.In(loc)
+		}
+	}
+	return t
+}
+
+// Stamp writes a date and time with its timezone, UTC unless the locale has a timezone.
+func (l Locale) Stamp(t time.Time) string {
+	if l.Timezone == "" {
+		t = t.UTC()
+	}
+	return l.FormatTime(t, true) + " " + l.In(t).Format("MST")
+}
+
+// Format writes a value for people to read.
+func (l Locale) Format(v Value) string {
+	switch v.Kind {
+	case KindMoney:
+		return l.Money(v.Number)
+	case KindNumber:
+		return l.Number(v.Number, 0)
+	case KindPercent:
+		return l.Number(v.Number, 1) + "%"
+	case KindDate, KindDateTime:
+		return l.FormatTime(v.Time, v.Kind == KindDateTime)
+	}
+	return v.Text
+}
+
+// Plain writes a value for spreadsheets: amounts without the currency symbol or thousands separators.
+func (l Locale) Plain(v Value) string {
+	plain := Locale{DecimalSeparator: l.DecimalSeparator}
+	switch v.Kind {
+	case KindMoney:
+		return plain.Number(v.Number, 2)
+	case KindNumber:
+		return plain.Number(v.Number, 0)
+	case KindPercent:
+		return plain.Number(v.Number, 1)
+	}
+	return l.Format(v)
+}
+
+// monthNames has the full then the abbreviated month names of the languages other than English.
+var monthNames = map[string][]string{
+	"en": nil,
+	"de": {"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember",
+		"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+	"es": {"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	"fr": {"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	"it": {"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+	"nl": {"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december",
+		"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+	"pt": {"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+		"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+}
+
+func languages() []string {
+	names := make([]string, 0, len(monthNames))
+	for name := range monthNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// layoutTokens maps the date format tokens to Go layout elements, longest first.
+var layoutTokens = []struct{ token, layout string }{
+	{"YYYY", "2006"}, {"YY", "06"},
+	{"MMMM", "January"}, {"MMM", "Jan"}, {"MM", "01"},
+	{"DD", "02"}, {"D", "2"},
+	{"HH", "15"}, {"hh", "03"},
+	{"mm", "04"}, {"ss", "05"},
+	{"A", "PM"}, {"ZZZ", "MST"},
+}
+
+// formatTime writes t in a format such as DD/MM/YYYY HH:mm, naming months in the locale's language.
+// Anything that is not a token is copied as is.
+func (l Locale) formatTime(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); {
+		matched := false
+		for _, token := range layoutTokens {
+			if !strings.HasPrefix(format[i:], token.token) {
+				continue
+			}
+			switch names := monthNames[l.Language]; {
+			case names != nil && token.token == "MMMM":
+				b.WriteString(names[t.Month()-1])
+			case names != nil && token.token == "MMM":
+				b.WriteString(names[12+t.Month()-1])
+			default:
+				b.WriteString(t.Format(token.layout))
+			}
+			i += len(token.token)
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,235 @@
+package layout
+
+import (
+	"testing"
+	"time"
+)
+
+// preset returns the locale users get for a tag.
+func preset(t *testing.T, tag string) Locale {
+	t.Helper()
+	l, _, err := LocaleFor(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   Locale
+		v        float64
+		decimals int
+		want     string
+	}{
+		{"default", Locale{}, 1234567.891, 2, "1234567.89"},
+		{"thousands", Locale{ThousandsSeparator: ","}, 1234567.891, 2, "1,234,567.89"},
+		{"below a thousand", Locale{ThousandsSeparator: ","}, 999, 2, "999.00"},
+		{"a thousand", Locale{ThousandsSeparator: ","}, 1000, 2, "1,000.00"},
+		{"negative", Locale{ThousandsSeparator: ","}, -1234.5, 2, "-1,234.50"},
+		{"rounded", Locale{ThousandsSeparator: ","}, 1234.6, 0, "1,235"},
+		{"decimal comma", Locale{DecimalSeparator: ",", ThousandsSeparator: "."}, 1234567.891, 2, "1.234.567,89"},
+		{"non-breaking space separator", Locale{DecimalSeparator: ",", ThousandsSeparator: "\u00a0"}, 1234.5, 2, "1\u00a0234,50"},
+		{"indian lakh", Locale{ThousandsSeparator: ",", Grouping: GroupingIndian}, 123456, 2, "1,23,456.00"},
+		{"indian crore", Locale{ThousandsSeparator: ",", Grouping: GroupingIndian}, 12345678, 2, "1,23,45,678.00"},
+		{"indian thousands", Locale{ThousandsSeparator: ",", Grouping: GroupingIndian}, 1234, 0, "1,234"},
+		{"grouping without separator", Locale{Grouping: GroupingIndian}, 123456, 0, "123456"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.Number(tt.v, tt.decimals); got != tt.want {
+				t.Errorf("Number(%v, %d) = %q, want %q", tt.v, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		tag  string
+		v    float64
+		want string
+	}{
+		{"en-US", 1234.5, "$1,234.50"},
+		{"en-US", -0.5, "-$0.50"},
+		{"en-GB", 99, "£99.00"},
+		{"en-IN", 123456, "₹1,23,456.00"},
+		{"de-DE", 1234.56, "1.234,56\u00a0€"},
+		{"de-DE", -1234.56, "-1.234,56\u00a0€"},
+		{"fr-FR", 1234.56, "1\u00a0234,56\u00a0€"},
+		{"nl-NL", 1234.56, "€1.234,56"},
+		{"pt-BR", 1234.56, "R$1.234,56"},
+	}
+	for _, tt := range tests {
+		if got := preset(t, tt.tag).Money(tt.v); got != tt.want {
+			t.Errorf("%s: Money(%v) = %q, want %q", tt.tag, tt.v, got, tt.want)
+		}
+	}
+	if got := (Locale{}).Money(12.5); got != "$12.50" {
+		t.Errorf("default Money(12.5) = %q, want $12.50", got)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	de := preset(t, "de-DE")
+	tests := []struct {
+		locale Locale
+		v      float64
+		want   string
+	}{
+		{Locale{}, 0, "$0"},
+		{Locale{}, 1500, "$1500"},
+		{Locale{}, 12500, "$12.5k"},
+		{Locale{}, 2500000, "$2.5M"},
+		{de, 12500, "12,5k\u00a0€"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.Compact(tt.v); got != tt.want {
+			t.Errorf("Compact(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	afternoon := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	earlyUTC := time.Date(2024, 3, 5, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		locale   Locale
+		t        time.Time
+		withTime bool
+		want     string
+	}{
+		{"default date", Locale{}, afternoon, false, "2024-03-05"},
+		{"default date and time", Locale{}, afternoon, true, "2024-03-05 14:07:09"},
+		{"german date", Locale{DateFormat: "DD.MM.YYYY"}, afternoon, false, "05.03.2024"},
+		{"12 hour clock", Locale{DateTimeFormat: "MM/DD/YYYY hh:mm A"}, afternoon, true, "03/05/2024 02:07 PM"},
+		{"short year and day", Locale{DateFormat: "D/M/YY"}, afternoon, false, "5/M/24"},
+		{"english month", Locale{DateFormat: "D MMMM YYYY"}, afternoon, false, "5 March 2024"},
+		{"german month", Locale{Language: "de", DateFormat: "D. MMMM YYYY"}, afternoon, false, "5. März 2024"},
+		{"spanish month", Locale{Language: "es", DateFormat: "D [de] MMMM"}, afternoon, false, "5 [de] marzo"},
+		{"french short month", Locale{Language: "fr", DateFormat: "D MMM YYYY"}, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), false, "1 févr. 2024"},
+		{"english short month", Locale{DateFormat: "MMM D"}, afternoon, false, "Mar 5"},
+		{"timezone ahead", Locale{Timezone: "Asia/Kolkata"}, afternoon, true, "2024-03-05 19:37:09"},
+		{"timezone changes the day", Locale{Timezone: "America/New_York"}, earlyUTC, false, "2024-03-04"},
+		{"timezone name", Locale{Timezone: "Europe/Paris", DateTimeFormat: "HH:mm ZZZ"}, afternoon, true, "15:07 CET"},
+		{"summer time", Locale{Timezone: "Europe/Paris", DateTimeFormat: "HH:mm ZZZ"}, time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), true, "14:00 CEST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locale.FormatTime(tt.t, tt.withTime); got != tt.want {
+				t.Errorf("FormatTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStamp(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 7, 9, 0, time.FixedZone("IST", 5*3600+1800))
+	if got, want := (Locale{}).Stamp(at), "2024-03-05 08:37:09 UTC"; got != want {
+		t.Errorf("Stamp() = %q, want %q", got, want)
+	}
+	if got, want := (Locale{Timezone: "Asia/Tokyo"}).Stamp(at), "2024-03-05 17:37:09 JST"; got != want {
+		t.Errorf("Stamp() in Asia/Tokyo = %q, want %q", got, want)
+	}
+}
+
+func TestFormatAndPlain(t *testing.T) {
+	de := preset(t, "de-DE")
+	day := time.Date(2024, 3, 5, 14, 7, 0, 0, time.UTC)
+	tests := []struct {
+		value  Value
+		format string
+		plain  string
+	}{
+		{Value{Kind: KindMoney, Number: 1234.5}, "1.234,50\u00a0€", "1234,50"},
+		{Value{Kind: KindMoney, Number: -3}, "-3,00\u00a0€", "-3,00"},
+		{Value{Kind: KindNumber, Number: 12345}, "12.345", "12345"},
+		{Value{Kind: KindPercent, Number: 12.34}, "12,3%", "12,3"},
+		{Value{Kind: KindDate, Time: day}, "05.03.2024", "05.03.2024"},
+		{Value{Kind: KindDateTime, Time: day}, "05.03.2024 14:07", "05.03.2024 14:07"},
+		{Value{Kind: KindText, Text: "Trip"}, "Trip", "Trip"},
+	}
+	for _, tt := range tests {
+		if got := de.Format(tt.value); got != tt.format {
+			t.Errorf("Format(%+v) = %q, want %q", tt.value, got, tt.format)
+		}
+		if got := de.Plain(tt.value); got != tt.plain {
+			t.Errorf("Plain(%+v) = %q, want %q", tt.value, got, tt.plain)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		ok     bool
+	}{
+		{"empty", Locale{}, true},
+		{"german", Locale{Language: "de", Currency: "€", CurrencyAfter: true, DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD.MM.YYYY", Timezone: "Europe/Berlin"}, true},
+		{"indian", Locale{ThousandsSeparator: ",", Grouping: GroupingIndian}, true},
+		{"long currency", Locale{Currency: "dollars"}, false},
+		{"decimal separator", Locale{DecimalSeparator: "'"}, false},
+		{"same separators", Locale{DecimalSeparator: ",", ThousandsSeparator: ","}, false},
+		{"default decimal as thousands", Locale{ThousandsSeparator: "."}, false},
+		{"long thousands separator", Locale{ThousandsSeparator: ", "}, false},
+		{"language", Locale{Language: "xx"}, false},
+		{"grouping", Locale{Grouping: "chinese"}, false},
+		{"digits in date format", Locale{DateFormat: "2006-01-02"}, false},
+		{"timezone", Locale{Timezone: "Mars/Olympus"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.locale.Validate(); (err == nil) != tt.ok {
+				t.Errorf("Validate() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLocaleFor(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{"en-US", "en-US", false},
+		{"en-GB", "en-GB", false},
+		{"en-IN", "en-IN", false},
+		{"de-DE", "de-DE", false},
+		{"de-AT", "de-DE", false},
+		{"fr-CA", "fr-FR", false},
+		{"pt-PT", "pt-BR", false},
+		{"not a tag!", "", true},
+	}
+	for _, tt := range tests {
+		_, got, err := LocaleFor(tt.tag)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("LocaleFor(%q) = %q, %v, want %q", tt.tag, got, err, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := preset(t, "de-DE")
+	base.Timezone = "Europe/Berlin"
+
+	if got := (Locale{}).Merge(base); got != base {
+		t.Errorf("empty locale merged = %+v, want the base %+v", got, base)
+	}
+
+	got := Locale{Currency: "$", DecimalSeparator: ".", DateFormat: "YYYY-MM-DD"}.Merge(base)
+	want := Locale{
+		Language:         "de",
+		Currency:         "$",
+		DecimalSeparator: ".",
+		DateFormat:       "YYYY-MM-DD",
+		DateTimeFormat:   base.DateTimeFormat,
+		Timezone:         "Europe/Berlin",
+	}
+	if got != want {
+		t.Errorf("Merge() = %+v, want %+v: the currency and number format are taken as a whole", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// presets are the locales users can pick in their preferences, by BCP 47 tag.
+var presets = []struct {
+	tag    language.Tag
+	locale Locale
+}{
+	{language.AmericanEnglish, Locale{Language: "en", Currency: "$", DecimalSeparator: ".", ThousandsSeparator: ",", DateFormat: "MM/DD/YYYY", DateTimeFormat: "MM/DD/YYYY hh:mm A"}},
+	{language.BritishEnglish, Locale{Language: "en", Currency: "£", DecimalSeparator: ".", ThousandsSeparator: ",", DateFormat: "DD/MM/YYYY", DateTimeFormat: "DD/MM/YYYY HH:mm"}},
+	{language.MustParse("en-IN"), Locale{Language: "en", Currency: "₹", DecimalSeparator: ".", ThousandsSeparator: ",", Grouping: GroupingIndian, DateFormat: "DD/MM/YYYY", DateTimeFormat: "DD/MM/YYYY hh:mm A"}},
+	{language.MustParse("de-DE"), Locale{Language: "de", Currency: "€", CurrencyAfter: true, DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD.MM.YYYY", DateTimeFormat: "DD.MM.YYYY HH:mm"}},
+	{language.MustParse("es-ES"), Locale{Language: "es", Currency: "€", CurrencyAfter: true, DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY", DateTimeFormat: "DD/MM/YYYY HH:mm"}},
+	{language.MustParse("fr-FR"), Locale{Language: "fr", Currency: "€", CurrencyAfter: true, DecimalSeparator: ",", ThousandsSeparator: "\u00a0", DateFormat: "DD/MM/YYYY", DateTimeFormat: "DD/MM/YYYY HH:mm"}},
+	{language.MustParse("it-IT"), Locale{Language: "it", Currency: "€", CurrencyAfter: true, DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY", DateTimeFormat: "DD/MM/YYYY HH:mm"}},
+	{language.MustParse("nl-NL"), Locale{Language: "nl", Currency: "€", DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD-MM-YYYY", DateTimeFormat: "DD-MM-YYYY HH:mm"}},
+	{language.BrazilianPortuguese, Locale{Language: "pt", Currency: "R$", DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: "DD/MM/YYYY", DateTimeFormat: "DD/MM/YYYY HH:mm"}},
+	{language.MustParse("ja-JP"), Locale{Language: "en", Currency: "¥", DecimalSeparator: ".", ThousandsSeparator: ",", DateFormat: "YYYY/MM/DD", DateTimeFormat: "YYYY/MM/DD HH:mm"}},
+}
+
+var presetMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(presets))
+	for i, p := range presets {
+		tags[i] = p.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// Locales lists the tags of the locales users can pick.
+func Locales() []string {
+	tags := make([]string, len(presets))
+	for i, p := range presets {
+		tags[i] = p.tag.String()
+	}
+	return tags
+}
+
+// LocaleFor returns the closest locale to a BCP 47 tag, such as de-DE or de-AT for German formatting,
+// with the tag of the locale picked.
+func LocaleFor(tag string) (Locale, string, error) {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return Locale{}, "", fmt.Errorf("invalid locale %q", tag)
+	}
+	_, i, confidence := presetMatcher.Match(t)
+	if confidence == language.No {
+		return Locale{}, "", fmt.Errorf("unsupported locale %q, use one of %v", tag, Locales())
+	}
+	return presets[i].locale, presets[i].tag.String(), nil
+}
+
+// Merge fills in what the locale leaves unset from base, such as the preferences of the user a
+// report is generated for. The currency, the number format and each date format are taken as a whole.
+func (l Locale) Merge(base Locale) Locale {
+	if l.Language == "" {
+		l.Language = base.Language
+	}
+	if l.Currency == "" {
+		l.Currency, l.CurrencyAfter = base.Currency, base.CurrencyAfter
+	}
+	if l.DecimalSeparator == "" && l.ThousandsSeparator == "" && l.Grouping == "" {
+		l.DecimalSeparator, l.ThousandsSeparator, l.Grouping = base.DecimalSeparator, base.ThousandsSeparator, base.Grouping
+	}
+	if l.DateFormat == "" {
+		l.DateFormat = base.DateFormat
+	}
+	if l.DateTimeFormat == "" {
+		l.DateTimeFormat = base.DateTimeFormat
+	}
+	if l.Timezone == "" {
+		l.Timezone = base.Timezone
+	}
+	return l
+}
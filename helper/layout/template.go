@@ -0,0 +1,277 @@
+// Package layout describes what goes into a report and in which order. A Template is a declarative
+// definition of sections, columns, branding and locale that users and groups can store; Bind fills it
+// with the report data into a Document, which the PDF, CSV and HTML renderers draw.
+package layout
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg" // logos may be JPEG
+	_ "image/png"  // or PNG
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Report kinds a template can be written for.
+const (
+	KindGroups = "groups" // groups owned by the user in a date range
+	KindGroup  = "group"  // detailed report of one group
+)
+
+// Section types
+const (
+	SectionText      = "text"
+	SectionFields    = "fields"
+	SectionTable     = "table"
+	SectionChart     = "chart"
+	SectionComments  = "comments"
+	SectionPageBreak = "pageBreak"
+)
+
+const (
+	// PageWidth is the width available to table columns in PDF reports, in millimetres.
+	PageWidth = 190.0
+	// MinColumnWidth is the narrowest a table column may be, in millimetres, so every column holds a
+	// few characters.
+	MinColumnWidth = 8.0
+
+	maxSections = 40
+	maxColumns  = 20
+	maxLogoSize = 200 << 10
+	maxText     = 500
+)
+
+// Template is a report layout.
+type Template struct {
+	Title    string    `json:"title"`              // may use {owner}, {group}, {from} and {to}
+	Branding Branding  `json:"branding,omitempty"` //
+	Locale   Locale    `json:"locale,omitempty"`   //
+	Sections []Section `json:"sections"`
+}
+
+// Branding customises the look of PDF and HTML reports.
+type Branding struct {
+	Logo        string `json:"logo,omitempty"`        // PNG or JPEG, base64 or a data URI, at most 200 KB
+	Color       string `json:"color,omitempty"`       // title colour, #RRGGBB
+	AccentColor string `json:"accentColor,omitempty"` // section and table header background, #RRGGBB
+	Footer      string `json:"footer,omitempty"`      // text added to the footer of every page
+}
+
+// Section is one block of a report. Which fields are used depends on the type:
+// text uses Text, fields uses Data and Fields, table uses Data, Columns and Empty, chart uses Chart.
+type Section struct {
+	Type    string   `json:"type"`
+	Title   string   `json:"title,omitempty"`
+	Text    string   `json:"text,omitempty"`    // may use {owner}, {group}, {from} and {to}
+	Data    string   `json:"data,omitempty"`    // dataset the fields or rows come from
+	Fields  []Column `json:"fields,omitempty"`  //
+	Columns []Column `json:"columns,omitempty"` //
+	Empty   string   `json:"empty,omitempty"`   // shown when the table has no rows, which otherwise leaves the section out
+	Chart   string   `json:"chart,omitempty"`   //
+}
+
+// Column is a field of a dataset shown in a table column or a fields list.
+type Column struct {
+	Field string  `json:"field"`
+	Title string  `json:"title,omitempty"` // defaults to the field's own title
+	Width float64 `json:"width,omitempty"` // in millimetres in PDF reports, relative in HTML
+	Align string  `json:"align,omitempty"` // L, C or R
+}
+
+// Parse reads a template written in JSON, or in YAML when asYAML is set. Unknown keys are rejected so
+// that misspelt options do not go unnoticed.
+func Parse(definition []byte, asYAML bool) (Template, error) {
+	var t Template
+	if asYAML {
+		var doc any
+		if err := yaml.Unmarshal(definition, &doc); err != nil {
+			return t, fmt.Errorf("invalid YAML: %w", err)
+		}
+		var err error
+		if definition, err = json.Marshal(doc); err != nil {
+			return t, fmt.Errorf("invalid YAML: %w", err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(definition))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&t); err != nil {
+		return t, fmt.Errorf("invalid template: %w", err)
+	}
+	return t, nil
+}
+
+// WithoutCharts returns the template with its chart sections left out.
+func (t Template) WithoutCharts() Template {
+	sections := make([]Section, 0, len(t.Sections))
+	for _, s := range t.Sections {
+		if s.Type != SectionChart {
+			sections = append(sections, s)
+		}
+	}
+	t.Sections = sections
+	return t
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Validate checks that the template only uses sections, datasets, fields and charts that exist for the kind of report.
+func (t Template) Validate(kind string) error {
+	if kind != KindGroups && kind != KindGroup {
+		return fmt.Errorf("kind must be %s or %s", KindGroups, KindGroup)
+	}
+	if len(t.Title) > maxText || len(t.Branding.Footer) > maxText {
+		return fmt.Errorf("title and footer must be at most %d characters", maxText)
+	}
+	if len(t.Sections) == 0 || len(t.Sections) > maxSections {
+		return fmt.Errorf("a template needs between 1 and %d sections", maxSections)
+	}
+	for _, color := range []string{t.Branding.Color, t.Branding.AccentColor} {
+		if color != "" && !colorPattern.MatchString(color) {
+			return fmt.Errorf("colour %q must be in the form #RRGGBB", color)
+		}
+	}
+	if t.Branding.Logo != "" {
+		if _, _, err := t.Branding.LogoImage(); err != nil {
+			return err
+		}
+	}
+	if err := t.Locale.Validate(); err != nil {
+		return err
+	}
+
+	for i, s := range t.Sections {
+		if err := s.validate(kind); err != nil {
+			return fmt.Errorf("section %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (s Section) validate(kind string) error {
+	if len(s.Title) > maxText || len(s.Text) > maxText || len(s.Empty) > maxText {
+		return fmt.Errorf("texts must be at most %d characters", maxText)
+	}
+	switch s.Type {
+	case SectionText:
+		if s.Text == "" {
+			return fmt.Errorf("text sections need a text")
+		}
+	case SectionFields, SectionTable:
+		set, ok := datasets[s.Data]
+		if !ok || set.kind != kind || set.table != (s.Type == SectionTable) {
+			return fmt.Errorf("data must be one of %s", strings.Join(datasetNames(kind, s.Type == SectionTable), ", "))
+		}
+		columns := s.Columns
+		if s.Type == SectionFields {
+			columns = s.Fields
+		}
+		if len(columns) == 0 || len(columns) > maxColumns {
+			return fmt.Errorf("%s sections need between 1 and %d fields", s.Type, maxColumns)
+		}
+		for _, c := range columns {
+			if _, ok := set.fields[c.Field]; !ok {
+				return fmt.Errorf("unknown field %q, %s has %s", c.Field, s.Data, strings.Join(set.order, ", "))
+			}
+			if c.Width != 0 && (c.Width < MinColumnWidth || c.Width > PageWidth) {
+				return fmt.Errorf("width of %s must be between %g and %g, or 0 for the default", c.Field, MinColumnWidth, PageWidth)
+			}
+			if c.Align != "" && c.Align != "L" && c.Align != "C" && c.Align != "R" {
+				return fmt.Errorf("align of %s must be L, C or R", c.Field)
+			}
+			if len(c.Title) > maxText {
+				return fmt.Errorf("texts must be at most %d characters", maxText)
+			}
+		}
+		if s.Type == SectionTable {
+			return checkScaledWidths(resolveColumns(set, columns))
+		}
+	case SectionChart:
+		if !contains(charts[kind], s.Chart) {
+			return fmt.Errorf("chart must be one of %s", strings.Join(charts[kind], ", "))
+		}
+	case SectionComments:
+		if kind != KindGroup {
+			return fmt.Errorf("comments are only available in group reports")
+		}
+	case SectionPageBreak:
+	default:
+		return fmt.Errorf("type must be one of %s, %s, %s, %s, %s or %s", SectionText, SectionFields, SectionTable, SectionChart, SectionComments, SectionPageBreak)
+	}
+	return nil
+}
+
+// checkScaledWidths rejects columns that would be narrowed below MinColumnWidth because the columns
+// together are wider than the page.
+func checkScaledWidths(columns []Column) error {
+	total := 0.0
+	for _, c := range columns {
+		total += c.Width
+	}
+	if total <= PageWidth {
+		return nil
+	}
+	for _, c := range columns {
+		if width := c.Width * PageWidth / total; width < MinColumnWidth {
+			return fmt.Errorf("the columns are %gmm wide together, which narrows %s to %.1fmm, below the minimum of %gmm; the page has room for %gmm", total, c.Field, width, MinColumnWidth, PageWidth)
+		}
+	}
+	return nil
+}
+
+// LogoImage decodes the logo and returns it with its format, png or jpeg.
+func (b Branding) LogoImage() ([]byte, string, error) {
+	data := b.Logo
+	if i := strings.Index(data, ","); strings.HasPrefix(data, "data:") && i > 0 {
+		data = data[i+1:]
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("logo must be base64 encoded")
+	}
+	if len(raw) > maxLogoSize {
+		return nil, "", fmt.Errorf("logo must be at most %d KB", maxLogoSize>>10)
+	}
+	_, format, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return nil, "", fmt.Errorf("logo must be a PNG or JPEG image")
+	}
+	return raw, format, nil
+}
+
+// Color returns the red, green and blue parts of a #RRGGBB colour, or the fallback when it is not set.
+func Color(hex string, fallback [3]int) [3]int {
+	var c [3]int
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &c[0], &c[1], &c[2]); err != nil {
+		return fallback
+	}
+	return c
+}
+
+// expand fills in the placeholders of a title or text.
+func expand(s string, data Data, locale Locale) string {
+	owner, group := data.Owner, ""
+	if data.Kind == KindGroup {
+		owner = name(data.Group.UserInfo, data.Group.Group.CreatedBy)
+		group = data.Group.Group.Name
+	}
+	return strings.NewReplacer(
+		"{owner}", owner,
+		"{group}", group,
+		"{from}", locale.FormatTime(data.From, false),
+		"{to}", locale.FormatTime(data.To, false),
+	).Replace(s)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,311 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper/layout"
+)
+
+// drawChart draws a chart section of a report.
+func drawChart(d *document, title string, c *layout.Chart) {
+	switch c.Type {
+	case layout.ChartPaid, layout.ChartContribution:
+		drawPaidChart(d, title, c.Bars)
+	case layout.ChartCategories:
+		drawCategoryChart(d, title, c.Bars)
+	case layout.ChartSpending:
+		drawColumnChart(d, title, c.Periods)
+	case layout.ChartProgress:
+		drawProgress(d, title, c.Paid, c.Total)
+	case layout.ChartPayments:
+		drawCumulativeChart(d, title, c)
+	}
+}
+
+// drawCategoryChart draws a section with a horizontal bar per category, scaled to the largest amount.
+func drawCategoryChart(d *document, title string, bars []layout.Bar) {
+	const (
+		labelWidth  = 35.0
+		amountWidth = 25.0
+		barHeight   = 5.0
+		rowHeight   = 7.0
+	)
+	if len(bars) == 0 {
+		return
+	}
+
+	d.ensureSpace(24 + rowHeight*float64(min(len(bars), 10)))
+	d.section(title)
+
+	maxBar := d.contentWidth() - labelWidth - amountWidth
+	largest := bars[0].Amount
+
+	d.font("", 9)
+	d.SetTextColor(0, 0, 0)
+	for _, bar := range bars {
+		d.ensureSpace(rowHeight)
+		x, y := d.GetX(), d.GetY()
+		d.cell(labelWidth, rowHeight, d.fit(bar.Label, labelWidth-2), "", 0, "L", false)
+		width := 0.0
+		if largest > 0 {
+			width = maxBar * bar.Amount / largest
+		}
+		if width > 0 {
+			d.fillColor(spendColor)
+			d.Rect(x+labelWidth, y+(rowHeight-barHeight)/2, width, barHeight, "F")
+		}
+		d.SetXY(x+labelWidth+width+1, y)
+		d.cell(amountWidth, rowHeight, d.money(bar.Amount), "", 0, "L", false)
+		d.SetXY(x, y+rowHeight)
+	}
+	d.SetFillColor(255, 255, 255)
+}
+
+type rgb struct{ r, g, b int }
+
+var (
+	paidColor        = rgb{76, 175, 80}
+	outstandingColor = rgb{255, 152, 0}
+	spendColor       = rgb{0, 102, 204}
+	gridColor        = rgb{220, 220, 220}
+)
+
+func (d *document) fillColor(c rgb) { d.SetFillColor(c.r, c.g, c.b) }
+func (d *document) drawColor(c rgb) { d.SetDrawColor(c.r, c.g, c.b) }
+
+type legendItem struct {
+	label string
+	color rgb
+}
+
+// drawLegend draws a row of colour swatches with their labels.
+func drawLegend(d *document, items ...legendItem) {
+	d.font("", 8)
+	d.SetTextColor(33, 33, 33)
+	x, y := d.GetXY()
+	for _, item := range items {
+		d.fillColor(item.color)
+		d.Rect(x, y+1.5, 3, 3, "F")
+		d.SetXY(x+4, y)
+		w := d.width(item.label) + 2
+		d.cell(w, 6, item.label, "", 0, "L", false)
+		x += 4 + w + 4
+	}
+	d.SetXY(marginLeft, y+8)
+	d.SetFillColor(255, 255, 255)
+}
+
+// drawPaidChart draws a section with a bar per entry, split into the paid and the outstanding part and
+// scaled to the largest entry.
+func drawPaidChart(d *document, title string, bars []layout.Bar) {
+	const (
+		labelWidth  = 40.0
+		amountWidth = 36.0
+		barHeight   = 5.0
+		rowHeight   = 7.0
+	)
+	if len(bars) == 0 {
+		return
+	}
+
+	d.ensureSpace(32 + rowHeight*float64(min(len(bars), 10)))
+	d.section(title)
+	drawLegend(d, legendItem{"Paid", paidColor}, legendItem{"Outstanding", outstandingColor})
+
+	largest := 0.0
+	for _, bar := range bars {
+		largest = max(largest, max(bar.Paid, 0)+max(bar.Outstanding, 0))
+	}
+	maxBar := d.contentWidth() - labelWidth - amountWidth
+
+	d.font("", 8)
+	d.SetTextColor(0, 0, 0)
+	for _, bar := range bars {
+		paid, outstanding := max(bar.Paid, 0), max(bar.Outstanding, 0)
+		d.ensureSpace(rowHeight)
+		x, y := d.GetX(), d.GetY()
+		d.cell(labelWidth, rowHeight, d.fit(bar.Label, labelWidth-2), "", 0, "L", false)
+		paidWidth, outstandingWidth := 0.0, 0.0
+		if largest > 0 {
+			paidWidth = maxBar * paid / largest
+			outstandingWidth = maxBar * outstanding / largest
+		}
+		top := y + (rowHeight-barHeight)/2
+		if paidWidth > 0 {
+			d.fillColor(paidColor)
+			d.Rect(x+labelWidth, top, paidWidth, barHeight, "F")
+		}
+		if outstandingWidth > 0 {
+			d.fillColor(outstandingColor)
+			d.Rect(x+labelWidth+paidWidth, top, outstandingWidth, barHeight, "F")
+		}
+		d.SetXY(x+labelWidth+paidWidth+outstandingWidth+1, y)
+		d.cell(amountWidth, rowHeight, fmt.Sprintf("%s of %s", d.money(paid), d.money(paid+outstanding)), "", 0, "L", false)
+		d.SetXY(x, y+rowHeight)
+	}
+	d.SetFillColor(255, 255, 255)
+}
+
+// drawProgress draws a full width bar of how much of the total has been paid.
+func drawProgress(d *document, title string, paid, total float64) {
+	const barHeight = 8.0
+	if total <= 0 {
+		return
+	}
+	paid = min(max(paid, 0), total)
+
+	d.ensureSpace(40)
+	d.section(title)
+	x, y := d.GetXY()
+	w := d.contentWidth()
+	d.fillColor(outstandingColor)
+	d.Rect(x, y, w, barHeight, "F")
+	d.fillColor(paidColor)
+	d.Rect(x, y, w*paid/total, barHeight, "F")
+
+	d.SetXY(x, y+barHeight+1)
+	d.font("", 9)
+	d.SetTextColor(33, 33, 33)
+	d.cell(w/2, 6, fmt.Sprintf("Paid %s (%.0f%%)", d.money(paid), 100*paid/total), "", 0, "L", false)
+	d.cell(w/2, 6, fmt.Sprintf("Outstanding %s", d.money(total-paid)), "", 1, "R", false)
+	d.SetFillColor(255, 255, 255)
+}
+
+// niceScale returns the top of the value axis and the step between grid lines for values up to largest.
+func niceScale(largest float64) (top, step float64) {
+	if largest <= 0 {
+		return 1, 0.25
+	}
+	raw := largest / 4
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if step = m * magnitude; step >= raw {
+			break
+		}
+	}
+	return step * math.Ceil(largest/step), step
+}
+
+// plot is the area of a chart with a value axis on the left.
+type plot struct {
+	left, top, width, height float64
+	max                      float64
+}
+
+func (p plot) y(v float64) float64 { return p.top + p.height - p.height*v/p.max }
+
+// drawAxis draws the value grid of a chart at the current position and returns its plot area.
+func drawAxis(d *document, largest, height float64) plot {
+	const axisWidth = 18.0
+	top, step := niceScale(largest)
+	p := plot{left: marginLeft + axisWidth, top: d.GetY() + 2, width: d.contentWidth() - axisWidth, height: height, max: top}
+
+	d.font("", 6)
+	d.SetTextColor(90, 90, 90)
+	d.drawColor(gridColor)
+	d.SetLineWidth(0.1)
+	for v := 0.0; v <= top+step/2; v += step {
+		y := p.y(v)
+		d.Line(p.left, y, p.left+p.width, y)
+		d.SetXY(marginLeft, y-2)
+		d.cell(axisWidth-2, 4, d.locale.Compact(v), "", 0, "R", false)
+	}
+	d.SetDrawColor(0, 0, 0)
+	d.SetLineWidth(0.2)
+	d.Line(p.left, p.top, p.left, p.top+p.height)
+	d.Line(p.left, p.top+p.height, p.left+p.width, p.top+p.height)
+	return p
+}
+
+// drawColumnChart draws a section with a column per period.
+func drawColumnChart(d *document, title string, periods []layout.Period) {
+	const plotHeight = 55.0
+	if len(periods) == 0 {
+		return
+	}
+
+	d.ensureSpace(plotHeight + 30)
+	d.section(title)
+	largest := 0.0
+	for _, p := range periods {
+		largest = max(largest, p.Amount)
+	}
+	p := drawAxis(d, largest, plotHeight)
+
+	slot := p.width / float64(len(periods))
+	columnWidth := slot * 0.7
+	// Label every nth column so that the labels do not overlap
+	every := int(math.Ceil(float64(len(periods)) / 12))
+	for i, period := range periods {
+		x := p.left + float64(i)*slot
+		if period.Amount > 0 {
+			d.fillColor(spendColor)
+			d.Rect(x+(slot-columnWidth)/2, p.y(period.Amount), columnWidth, p.top+p.height-p.y(period.Amount), "F")
+		}
+		if i%every == 0 {
+			labelWidth := slot * float64(every)
+			d.SetXY(x+slot/2-labelWidth/2, p.top+p.height+1)
+			d.cell(labelWidth, 4, d.fit(period.Label, labelWidth), "", 0, "C", false)
+		}
+	}
+	d.SetXY(marginLeft, p.top+p.height+8)
+	d.SetFillColor(255, 255, 255)
+}
+
+// drawCumulativeChart draws a section with the running total of the payments from the start to the
+// end of the chart as a step line, with a dashed line at the total to collect.
+func drawCumulativeChart(d *document, title string, c *layout.Chart) {
+	const plotHeight = 55.0
+	if len(c.Points) == 0 {
+		return
+	}
+	start, end, target := c.Start, c.End, c.Total
+
+	total := 0.0
+	for _, point := range c.Points {
+		total += point.Amount
+	}
+
+	d.ensureSpace(plotHeight + 36)
+	d.section(title)
+	drawLegend(d, legendItem{"Paid so far", paidColor}, legendItem{"Total to collect", outstandingColor})
+	p := drawAxis(d, max(total, target), plotHeight)
+	x := func(t time.Time) float64 {
+		return p.left + p.width*float64(t.Sub(start))/float64(end.Sub(start))
+	}
+
+	if target > 0 {
+		d.drawColor(outstandingColor)
+		d.SetLineWidth(0.4)
+		d.SetDashPattern([]float64{1.5, 1}, 0)
+		d.Line(p.left, p.y(target), p.left+p.width, p.y(target))
+		d.SetDashPattern(nil, 0)
+	}
+
+	d.drawColor(paidColor)
+	d.fillColor(paidColor)
+	d.SetLineWidth(0.6)
+	running := 0.0
+	lastX, lastY := p.left, p.y(0)
+	for _, point := range c.Points {
+		ax := x(point.At)
+		d.Line(lastX, lastY, ax, lastY)
+		running += point.Amount
+		d.Line(ax, lastY, ax, p.y(running))
+		d.Circle(ax, p.y(running), 0.8, "F")
+		lastX, lastY = ax, p.y(running)
+	}
+	d.Line(lastX, lastY, p.left+p.width, lastY)
+	d.SetLineWidth(0.2)
+	d.SetDrawColor(0, 0, 0)
+
+	d.font("", 6)
+	d.SetTextColor(90, 90, 90)
+	d.SetXY(p.left, p.top+p.height+1)
+	d.cell(p.width/2, 4, d.locale.FormatTime(start, false), "", 0, "L", false)
+	d.cell(p.width/2, 4, d.locale.FormatTime(end, false), "", 0, "R", false)
+	d.SetXY(marginLeft, p.top+p.height+8)
+	d.SetFillColor(255, 255, 255)
+}
@@ -0,0 +1,56 @@
+package pdf
+
+const (
+	devanagariNukta  = 0x093C
+	devanagariIMatra = 0x093F // vowel sign I, written after its consonant but drawn before it
+	devanagariVirama = 0x094D
+)
+
+func isDevanagariConsonant(r rune) bool {
+	return r >= 0x0915 && r <= 0x0939 || r >= 0x0958 && r <= 0x095F || r >= 0x0978 && r <= 0x097F
+}
+
+// reorderDevanagari moves each vowel sign I in front of the consonant cluster it follows, as a shaping
+// engine would. Conjuncts are not formed, so a cluster keeps its visible viramas, e.g. स्कि is drawn as
+// ि स ् क. Text without the vowel sign is returned unchanged.
+func reorderDevanagari(s string) string {
+	runes := []rune(s)
+	changed := false
+	for i, r := range runes {
+		if r != devanagariIMatra {
+			continue
+		}
+		start := clusterStart(runes, i)
+		if start == i {
+			continue
+		}
+		copy(runes[start+1:i+1], runes[start:i])
+		runes[start] = devanagariIMatra
+		changed = true
+	}
+	if !changed {
+		return s
+	}
+	return string(runes)
+}
+
+// clusterStart returns where the consonant cluster ending before i starts, or i when there is none.
+// Consonants, each maybe with a nukta, belong to one cluster when a virama joins them.
+func clusterStart(runes []rune, i int) int {
+	start := i
+	for j := i - 1; j >= 0; j -= 2 {
+		if runes[j] == devanagariNukta {
+			if j--; j < 0 {
+				break
+			}
+		}
+		if !isDevanagariConsonant(runes[j]) {
+			break
+		}
+		start = j
+		if j == 0 || runes[j-1] != devanagariVirama {
+			break
+		}
+	}
+	return start
+}
@@ -0,0 +1,370 @@
+package pdf
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	marginLeft   = 10.0
+	marginTop    = 14.0
+	marginRight  = 10.0
+	marginBottom = 16.0
+	lineHeight   = 4.5
+	cellPadding  = 1.0
+)
+
+// document is a PDF report with the embedded fonts, a running header from the second page on and a
+// footer with the page number, the generation time and the report id on every page.
+type document struct {
+	*gofpdf.Fpdf
+	id          string
+	generatedAt time.Time
+	style       string
+	size        float64
+	locale      layout.Locale
+	primary     rgb // title colour
+	accent      rgb // section and table header background
+	footer      string
+}
+
+func newDocument(title string, branding layout.Branding, locale layout.Locale) *document {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	d := &document{
+		Fpdf:        gofpdf.New("P", "mm", "A4", ""),
+		id:          hex.EncodeToString(id),
+		generatedAt: time.Now().UTC(),
+		locale:      locale,
+		primary:     themeColor(branding.Color, rgb{0, 51, 102}),
+		accent:      themeColor(branding.AccentColor, rgb{200, 200, 255}),
+		footer:      branding.Footer,
+	}
+	registerFonts(d.Fpdf)
+	d.SetTitle(title, true)
+	d.SetSubject("Report "+d.id, true)
+	d.SetCreator("SplitWise", true)
+	d.SetMargins(marginLeft, marginTop, marginRight)
+	d.SetAutoPageBreak(true, marginBottom)
+	d.AliasNbPages("{nb}")
+
+	d.SetHeaderFunc(func() {
+		if d.PageNo() == 1 {
+			return
+		}
+		style, size := d.style, d.size
+		d.SetXY(marginLeft, 6)
+		d.font("I", 7)
+		d.SetTextColor(120, 120, 120)
+		d.cell(0, 4, d.fit(title, d.contentWidth()), "", 0, "L", false)
+		d.SetXY(marginLeft, marginTop)
+		d.font(style, size)
+	})
+	d.SetFooterFunc(func() {
+		style, size := d.style, d.size
+		_, pageHeight := d.GetPageSize()
+		d.SetDrawColor(200, 200, 200)
+		d.Line(marginLeft, pageHeight-11, marginLeft+d.contentWidth(), pageHeight-11)
+		d.SetDrawColor(0, 0, 0)
+		d.SetXY(marginLeft, pageHeight-10)
+		d.font("I", 7)
+		d.SetTextColor(120, 120, 120)
+		note := fmt.Sprintf("Generated %s · Report %s", d.locale.Stamp(d.generatedAt), d.id)
+		if d.footer != "" {
+			note = d.footer + " · " + note
+		}
+		d.cell(d.contentWidth()*3/4, 4, d.fit(note, d.contentWidth()*3/4), "", 0, "L", false)
+		d.cell(d.contentWidth()/4, 4, fmt.Sprintf("Page %d of {nb}", d.PageNo()), "", 0, "R", false)
+		d.font(style, size)
+	})
+
+	d.font("", 10)
+	d.AddPage()
+	log.Info("Generating PDF report", zap.String("reportId", d.id), zap.String("title", title))
+	return d
+}
+
+func themeColor(hex string, fallback rgb) rgb {
+	c := layout.Color(hex, [3]int{fallback.r, fallback.g, fallback.b})
+	return rgb{c[0], c[1], c[2]}
+}
+
+// money writes an amount in the document's locale.
+func (d *document) money(v float64) string {
+	return d.locale.Money(v)
+}
+
+func (d *document) contentWidth() float64 {
+	pageWidth, _ := d.GetPageSize()
+	return pageWidth - marginLeft - marginRight
+}
+
+// font sets the style and size text is drawn with.
+func (d *document) font(style string, size float64) {
+	d.style, d.size = style, size
+	d.useFace(0)
+}
+
+func (d *document) useFace(i int) {
+	if i == 0 {
+		d.SetFont(fontFamily, d.style, d.size)
+		return
+	}
+	d.SetFont(loadedFaces()[i].family, "", d.size)
+}
+
+// width measures a line of text in the current font.
+func (d *document) width(s string) float64 {
+	total := 0.0
+	for _, seg := range segments(visual(s)) {
+		d.useFace(seg.face)
+		total += d.GetStringWidth(seg.text)
+	}
+	d.useFace(0)
+	return total
+}
+
+// cell draws a single line of text like CellFormat, switching to the fallback fonts for the
+// characters the embedded font lacks.
+func (d *document) cell(w, h float64, text, border string, ln int, align string, fill bool) {
+	x, y := d.GetXY()
+	if w == 0 {
+		w = marginLeft + d.contentWidth() - x
+	}
+	margin := d.GetCellMargin()
+	d.CellFormat(w, h, "", border, 0, "", fill, 0, "")
+
+	segs := segments(visual(text))
+	textWidth := 0.0
+	for _, seg := range segs {
+		d.useFace(seg.face)
+		textWidth += d.GetStringWidth(seg.text)
+	}
+	offset := margin
+	switch align {
+	case "C":
+		offset = (w - textWidth) / 2
+	case "R":
+		offset = w - margin - textWidth
+	}
+
+	d.SetCellMargin(0)
+	d.SetXY(x+offset, y)
+	for _, seg := range segs {
+		d.useFace(seg.face)
+		d.CellFormat(d.GetStringWidth(seg.text), h, seg.text, "", 0, "L", false, 0, "")
+	}
+	d.SetCellMargin(margin)
+	d.useFace(0)
+
+	switch ln {
+	case 1:
+		d.SetXY(marginLeft, y+h)
+	case 2:
+		d.SetXY(x, y+h)
+	default:
+		d.SetXY(x+w, y)
+	}
+}
+
+// wrap breaks text into lines no wider than w, breaking inside words only when a word alone is too wide.
+func (d *document) wrap(text string, w float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.FieldsFunc(paragraph, unicode.IsSpace) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if d.width(candidate) <= w {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = word
+			for d.width(line) > w {
+				runes := []rune(line)
+				n := len(runes) - 1
+				for n > 1 && d.width(string(runes[:n])) > w {
+					n--
+				}
+				// A column narrower than one character still takes a character per line.
+				n = max(n, 1)
+				lines = append(lines, string(runes[:n]))
+				line = string(runes[n:])
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fit shortens text with an ellipsis so it fits in the width.
+func (d *document) fit(text string, w float64) string {
+	if d.width(text) <= w {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && d.width(string(runes)+"…") > w {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// ensureSpace starts a new page when less than h is left on the current one.
+func (d *document) ensureSpace(h float64) {
+	_, pageHeight := d.GetPageSize()
+	if d.GetY()+h > pageHeight-marginBottom {
+		d.AddPage()
+	}
+}
+
+func (d *document) title(text string, width float64) {
+	d.font("B", 14)
+	d.SetTextColor(d.primary.r, d.primary.g, d.primary.b)
+	for _, line := range d.wrap(text, width) {
+		d.cell(0, 8, line, "", 1, "L", false)
+	}
+	d.Ln(2)
+}
+
+func (d *document) subtitle(text string) {
+	d.font("I", 9)
+	d.SetTextColor(33, 33, 33)
+	d.paragraph(0, 5, text)
+	d.Ln(4)
+}
+
+// section starts a titled block, moving it to the next page when there is no room for some of its content.
+func (d *document) section(text string) {
+	d.ensureSpace(30)
+	d.Ln(4)
+	d.fillColor(d.accent)
+	d.SetTextColor(0, 0, 0)
+	d.font("B", 12)
+	d.cell(0, 10, d.fit(text, d.contentWidth()), "", 1, "L", true)
+	d.Ln(2)
+	d.font("", 10)
+	d.SetTextColor(33, 33, 33)
+	d.SetFillColor(255, 255, 255)
+}
+
+func (d *document) field(label, value string) {
+	d.paragraph(0, 6, fmt.Sprintf("%s: %s", label, value))
+}
+
+// paragraph draws wrapped text, indented from the left margin.
+func (d *document) paragraph(indent, h float64, text string) {
+	for _, line := range d.wrap(text, d.contentWidth()-indent) {
+		d.ensureSpace(h)
+		d.SetX(marginLeft + indent)
+		d.cell(d.contentWidth()-indent, h, line, "", 1, "L", false)
+	}
+}
+
+type column struct {
+	title string
+	width float64
+	align string
+}
+
+// table draws rows of wrapped cells, repeating the column titles at the top of every page it spans.
+type table struct {
+	d       *document
+	columns []column
+	size    float64
+}
+
+func (d *document) table(size float64, columns ...column) *table {
+	t := &table{d: d, columns: columns, size: size}
+	t.header()
+	return t
+}
+
+func (t *table) header() {
+	titles := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		titles[i] = c.title
+	}
+	t.d.font("B", t.size)
+	t.d.SetTextColor(0, 0, 0)
+	t.d.fillColor(t.d.accent)
+	// Keep the titles together with at least one row
+	t.d.ensureSpace(t.height(titles) + lineHeight + 2*cellPadding)
+	t.draw(titles, "C")
+	t.d.font("", t.size)
+	t.d.SetFillColor(255, 255, 255)
+}
+
+// row draws one row filled with the current fill colour, moving it to a new page with the titles
+// repeated when it does not fit.
+func (t *table) row(values ...string) {
+	t.d.font("", t.size)
+	_, pageHeight := t.d.GetPageSize()
+	if t.d.GetY()+t.height(values) > pageHeight-marginBottom {
+		r, g, b := t.d.GetFillColor()
+		t.d.AddPage()
+		t.header()
+		t.d.SetFillColor(r, g, b)
+		t.d.font("", t.size)
+	}
+	t.draw(values, "")
+}
+
+func (t *table) lines(values []string) [][]string {
+	_, pageHeight := t.d.GetPageSize()
+	maxLines := int((pageHeight - marginTop - marginBottom - 20) / lineHeight)
+	lines := make([][]string, len(values))
+	for i, value := range values {
+		lines[i] = t.d.wrap(value, t.columns[i].width-2*t.d.GetCellMargin())
+		if len(lines[i]) > maxLines {
+			lines[i] = lines[i][:maxLines]
+			lines[i][maxLines-1] = t.d.fit(lines[i][maxLines-1]+"…", t.columns[i].width-2*t.d.GetCellMargin())
+		}
+	}
+	return lines
+}
+
+func (t *table) height(values []string) float64 {
+	rows := 1
+	for _, l := range t.lines(values) {
+		rows = max(rows, len(l))
+	}
+	return float64(rows)*lineHeight + 2*cellPadding
+}
+
+func (t *table) draw(values []string, align string) {
+	lines := t.lines(values)
+	h := t.height(values)
+	x, y := marginLeft, t.d.GetY()
+	for i, c := range t.columns {
+		a := c.align
+		if align != "" {
+			a = align
+		}
+		if a == "" {
+			a = "L"
+		}
+		t.d.SetXY(x, y)
+		t.d.CellFormat(c.width, h, "", "1", 0, "", true, 0, "")
+		for j, line := range lines[i] {
+			t.d.SetXY(x, y+cellPadding+float64(j)*lineHeight)
+			t.d.cell(c.width, lineHeight, line, "", 0, a, false)
+		}
+		x += c.width
+	}
+	t.d.SetXY(marginLeft, y+h)
+}
@@ -0,0 +1,45 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/runenames"
+)
+
+const (
+	regionalIndicatorA = 0x1F1E6
+	regionalIndicatorZ = 0x1F1FF
+	skinToneFirst      = 0x1F3FB
+	skinToneLast       = 0x1F3FF
+)
+
+// spellEmoji replaces the emoji the PDF library cannot embed by their names, so "🍕 night" prints as
+// "[slice of pizza] night" instead of a replacement character. Flags become their region code, e.g.
+// [IN], and skin tone modifiers are dropped. Emoji in the Basic Multilingual Plane, such as ☕, are
+// left to the fonts.
+func spellEmoji(s string) string {
+	if !strings.ContainsFunc(s, func(r rune) bool { return r > 0xFFFF }) {
+		return s
+	}
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r >= regionalIndicatorA && r <= regionalIndicatorZ:
+			b.WriteByte('[')
+			for ; i < len(runes) && runes[i] >= regionalIndicatorA && runes[i] <= regionalIndicatorZ; i++ {
+				b.WriteRune('A' + runes[i] - regionalIndicatorA)
+			}
+			b.WriteByte(']')
+			i--
+		case r >= skinToneFirst && r <= skinToneLast:
+		case r > 0xFFFF && unicode.Is(unicode.So, r) && runenames.Name(r) != "":
+			b.WriteString("[" + strings.ToLower(runenames.Name(r)) + "]")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package pdf
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/go-fonts/dejavu/dejavusans"
+	"github.com/go-fonts/dejavu/dejavusansbold"
+	"github.com/go-fonts/dejavu/dejavusansoblique"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/mohdjishin/SplitWise/config"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"golang.org/x/image/font/sfnt"
+)
+
+const fontFamily = "DejaVu"
+
+// devanagariTTF is Noto Sans Devanagari, for Hindi, Marathi and Nepali text. See fonts/OFL.txt.
+//
+//go:embed fonts/NotoSansDevanagari-Regular.ttf
+var devanagariTTF []byte
+
+// face is a font that characters can be drawn with. The first face is the embedded DejaVu Sans,
+// which covers Latin, Greek, Cyrillic, Hebrew and Arabic, the second the embedded Noto Sans
+// Devanagari. The others are the configured fallbacks. All but the first only come in the regular
+// style. gofpdf draws glyphs one by one and only from the Basic Multilingual Plane, so Devanagari is
+// reordered but not shaped (see devanagari.go) and emoji are spelled out (see emoji.go).
+type face struct {
+	family string
+	ttf    []byte
+	font   *sfnt.Font
+}
+
+var (
+	loadFaces sync.Once
+	faces     []face
+	faceCache sync.Map // rune -> index in faces, -1 when no face has it
+)
+
+func loadedFaces() []face {
+	loadFaces.Do(func() {
+		main, err := sfnt.Parse(dejavusans.TTF)
+		if err != nil {
+			log.Panic("Failed to parse the embedded PDF font", zap.Error(err))
+		}
+		faces = append(faces, face{family: fontFamily, ttf: dejavusans.TTF, font: main})
+		devanagari, err := sfnt.Parse(devanagariTTF)
+		if err != nil {
+			log.Panic("Failed to parse the embedded Devanagari font", zap.Error(err))
+		}
+		faces = append(faces, face{family: "NotoDevanagari", ttf: devanagariTTF, font: devanagari})
+
+		for i, path := range config.GetConfig().PDF.FallbackFonts {
+			ttf, err := os.ReadFile(path)
+			if err == nil {
+				var f *sfnt.Font
+				if f, err = sfnt.Parse(ttf); err == nil {
+					faces = append(faces, face{family: fmt.Sprintf("Fallback%d", i), ttf: ttf, font: f})
+					continue
+				}
+			}
+			log.Error("Skipping PDF fallback font", zap.String("path", path), zap.Error(err))
+		}
+	})
+	return faces
+}
+
+// registerFonts makes the embedded fonts, DejaVu Sans in regular, bold and italic, and the fallback
+// fonts available to the document.
+func registerFonts(pdf *gofpdf.Fpdf) {
+	pdf.AddUTF8FontFromBytes(fontFamily, "", dejavusans.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", dejavusansbold.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "I", dejavusansoblique.TTF)
+	for _, f := range loadedFaces()[1:] {
+		pdf.AddUTF8FontFromBytes(f.family, "", f.ttf)
+	}
+}
+
+// faceFor returns the index of the first face that has a glyph for r, or -1.
+func faceFor(r rune) int {
+	if i, ok := faceCache.Load(r); ok {
+		return i.(int)
+	}
+	index := -1
+	var buf sfnt.Buffer
+	for i, f := range loadedFaces() {
+		if g, err := f.font.GlyphIndex(&buf, r); err == nil && g != 0 {
+			index = i
+			break
+		}
+	}
+	faceCache.Store(r, index)
+	return index
+}
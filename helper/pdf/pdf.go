@@ -1,154 +1,213 @@
 package pdf
 
 import (
+	"bytes"
 	"fmt"
-
-	"time"
+	"strconv"
 
 	"github.com/jung-kurt/gofpdf"
+	"github.com/mohdjishin/SplitWise/helper/layout"
 	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
 )
 
-func GenerateOwnerGroupsReportPDF(groupsInfo []dto.Group, startDate, endDate time.Time, ownerName string) *gofpdf.Fpdf {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.AddPage()
-
-	pdf.SetFont("Arial", "B", 14)
-	pdf.SetTextColor(0, 51, 102)
-	pdf.Cell(0, 10, fmt.Sprintf("Monthly/Weekly Report for Groups Owned by %s", ownerName))
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "I", 9)
-	pdf.SetTextColor(33, 33, 33)
-	pdf.Cell(0, 10, fmt.Sprintf("Report Period: %s - %s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")))
-	pdf.Ln(12)
-
-	pdf.SetFillColor(200, 200, 255)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.SetFont("Arial", "B", 7)
-	headers := []string{"Group ID", "Group Name", "Total Amount", "Share Per Member", "Paid Amount", "Members", "Status", "Last Bill Date"}
-	headerWidths := []float64{15, 35, 25, 25, 15, 20, 25, 25}
-
-	for i, header := range headers {
-		pdf.CellFormat(headerWidths[i], 8, header, "1", 0, "C", true, 0, "")
-	}
-	pdf.Ln(-1)
-
-	pdf.SetFont("Arial", "", 7)
-	pdf.SetFillColor(255, 255, 255)
-
-	for _, group := range groupsInfo {
-		lastBillDate := "N/A"
-		if !group.Bills.Date.IsZero() {
-			lastBillDate = group.Bills.Date.Format("2006-01-02")
+// GenerateReportPDF draws a report laid out by a template.
+func GenerateReportPDF(doc layout.Document) *gofpdf.Fpdf {
+	d := newDocument(doc.Title, doc.Branding, doc.Locale)
+	titleWidth, logoBottom := d.contentWidth(), 0.0
+	if doc.Branding.Logo != "" {
+		w, h := d.logo(doc.Branding)
+		if w > 0 {
+			titleWidth -= w + 4
+			logoBottom = marginTop + h + 2
 		}
+	}
+	d.title(doc.Title, titleWidth)
+	if d.GetY() < logoBottom {
+		d.SetY(logoBottom)
+	}
 
-		rowData := []string{
-			group.ID,
-			group.Name,
-			fmt.Sprintf("$%.2f", group.TotalAmount),
-			fmt.Sprintf("$%.2f", group.PerUserSplitAmount),
-			fmt.Sprintf("$%.2f", group.PaidAmount),
-			fmt.Sprintf("%d", group.Members),
-			group.Status,
-			lastBillDate,
+	for _, b := range doc.Blocks {
+		if b.Hidden() {
+			continue
 		}
-
-		maxLines := 1
-		for i, data := range rowData {
-			numLines := pdf.GetStringWidth(data) / headerWidths[i]
-			if numLines > float64(maxLines) {
-				maxLines = int(numLines)
+		switch b.Type {
+		case layout.SectionText:
+			if b.Title == "" {
+				d.subtitle(b.Text)
+				continue
 			}
-		}
-		rowHeight := 5.0 * float64(maxLines)
-
-		for i, data := range rowData {
-			if i == 1 || i == 8 {
-				x, y := pdf.GetX(), pdf.GetY()
-				pdf.MultiCell(headerWidths[i], 5, data, "1", "C", true)
-				pdf.SetXY(x+headerWidths[i], y)
-			} else {
-				pdf.CellFormat(headerWidths[i], rowHeight, data, "1", 0, "C", true, 0, "")
+			d.section(b.Title)
+			d.paragraph(0, 6, b.Text)
+		case layout.SectionFields:
+			if b.Title != "" {
+				d.section(b.Title)
+			}
+			for _, f := range b.Fields {
+				d.field(f.Label, d.locale.Format(f.Value))
+			}
+		case layout.SectionTable:
+			if b.Title != "" {
+				d.section(b.Title)
+			}
+			if len(b.Rows) == 0 {
+				d.paragraph(0, 6, b.Empty)
+				continue
+			}
+			writeTable(d, b)
+		case layout.SectionChart:
+			drawChart(d, b.Title, b.Chart)
+		case layout.SectionComments:
+			d.AddPage()
+			title := b.Title
+			if title == "" {
+				title = "Comments"
+			}
+			d.section(title)
+			for _, comment := range b.Comments {
+				writeComment(d, comment, b.BillName, 0)
 			}
+		case layout.SectionPageBreak:
+			d.AddPage()
 		}
-		pdf.Ln(rowHeight)
 	}
-
-	return pdf
+	return d.Fpdf
 }
 
-func GenerateGroupDetailedReportPDF(report dto.GroupReportRequest) *gofpdf.Fpdf {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-
-	pdf.AddPage()
-	pdf.SetFont("Arial", "B", 16)
+// writeTable draws the rows of a table block, highlighting budgets in warning or exceeded.
+func writeTable(d *document, b layout.Block) {
+	size := 9.0
+	switch {
+	case len(b.Columns) > 8:
+		size = 7
+	case len(b.Columns) > 6:
+		size = 8
+	}
+	columns := make([]column, len(b.Columns))
+	for i, c := range b.Columns {
+		columns[i] = column{title: c.Title, width: c.Width, align: c.Align}
+	}
+	t := d.table(size, columns...)
+	for i, row := range b.Rows {
+		switch b.Highlights[i] {
+		case layout.HighlightExceeded:
+			d.SetFillColor(255, 204, 204)
+		case layout.HighlightWarning:
+			d.SetFillColor(255, 240, 200)
+		default:
+			d.SetFillColor(255, 255, 255)
+		}
+		values := make([]string, len(row))
+		for j, v := range row {
+			values[j] = d.locale.Format(v)
+		}
+		t.row(values...)
+	}
+	d.SetFillColor(255, 255, 255)
+}
 
-	pdf.SetTextColor(0, 51, 102)
-	pdf.Cell(0, 10, fmt.Sprintf("Report for Group: %s", report.Group.Name))
-	pdf.Ln(12)
+// logo draws the branding logo in the top right corner of the first page and returns its size.
+func (d *document) logo(branding layout.Branding) (w, h float64) {
+	const maxHeight, maxWidth = 14.0, 50.0
+	raw, format, err := branding.LogoImage()
+	if err != nil {
+		log.Warn("Skipping report logo", zap.Error(err))
+		return 0, 0
+	}
+	options := gofpdf.ImageOptions{ImageType: map[string]string{"png": "PNG", "jpeg": "JPG"}[format]}
+	info := d.RegisterImageOptionsReader("logo", options, bytes.NewReader(raw))
+	if d.Err() || info == nil || info.Height() == 0 {
+		log.Warn("Skipping report logo", zap.Error(d.Error()))
+		d.ClearError()
+		return 0, 0
+	}
+	h = maxHeight
+	w = info.Width() / info.Height() * h
+	if w > maxWidth {
+		w, h = maxWidth, maxWidth*info.Height()/info.Width()
+	}
+	d.ImageOptions("logo", marginLeft+d.contentWidth()-w, marginTop, w, h, false, options, 0, "")
+	return w, h
+}
 
-	pdf.SetFillColor(200, 200, 255)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 10, "Owner and Bill Details", "", 1, "L", true, 0, "")
-	pdf.Ln(2)
+// GenerateMemberReportPDF draws the personal statement of a member, with amounts and dates in the locale.
+func GenerateMemberReportPDF(report dto.MemberReport, locale layout.Locale) *gofpdf.Fpdf {
+	d := newDocument(fmt.Sprintf("Personal Statement for %s", report.Name), layout.Branding{}, locale)
+	d.title(fmt.Sprintf("Personal Statement for %s", report.Name), d.contentWidth())
+	d.subtitle(fmt.Sprintf("Report Period: %s - %s", locale.FormatTime(report.From, false), locale.FormatTime(report.To, false)))
+
+	t := d.table(7,
+		column{"Group", 26, "L"},
+		column{"Owner", 19, "L"},
+		column{"Bill", 24, "L"},
+		column{"Category", 17, "L"},
+		column{"Bill Date", 17, "C"},
+		column{"Bill Amount", 17, "R"},
+		column{"My Share", 16, "R"},
+		column{"Paid", 16, "R"},
+		column{"Paid At", 20, "C"},
+		column{"Outstanding", 18, "R"},
+	)
+	for _, entry := range report.Entries {
+		paidAt := "-"
+		if entry.PaidAt != nil {
+			paidAt = locale.FormatTime(*entry.PaidAt, true)
+		}
+		t.row(
+			entry.GroupName,
+			entry.Owner,
+			entry.BillName,
+			entry.Category,
+			locale.FormatTime(entry.BillDate, false),
+			d.money(entry.BillAmount),
+			d.money(entry.Share),
+			d.money(entry.PaidAmount),
+			paidAt,
+			d.money(entry.Outstanding),
+		)
+	}
 
-	pdf.SetFont("Arial", "", 10)
-	pdf.SetTextColor(33, 33, 33)
-	pdf.Cell(0, 10, fmt.Sprintf("Owner: %s", report.UserInfo[report.Group.CreatedBy]))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Bill Name: %s", report.Bill.Name))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Total Amount: $%.2f", report.Bill.Amount))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Group ID: %d", report.Bill.GroupID))
-	pdf.Ln(12)
+	d.section("Totals")
+	d.field("Groups", strconv.Itoa(report.Totals.Groups))
+	d.field("Total Share", d.money(report.Totals.Share))
+	d.field("Total Paid", d.money(report.Totals.Paid))
+	d.field("Outstanding", d.money(report.Totals.Outstanding))
 
-	pdf.SetFillColor(200, 200, 255)
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 10, "Group Details", "", 1, "L", true, 0, "")
-	pdf.Ln(2)
+	byCategory := map[string]float64{}
+	for _, entry := range report.Entries {
+		byCategory[entry.Category] += entry.Share
+	}
+	drawCategoryChart(d, "My Share by Category", layout.CategoryBars(byCategory))
 
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(0, 10, fmt.Sprintf("Group ID: %d", report.Group.ID))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Created At: %s", report.Group.CreatedAt.Format("2006-01-02 15:04:05")))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Updated At: %s", report.Group.UpdatedAt.Format("2006-01-02 15:04:05")))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Total Amount: $%.2f", report.Group.TotalAmount))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Paid Amount: $%.2f", report.Group.PaidAmount))
-	pdf.Ln(6)
-	pdf.Cell(0, 10, fmt.Sprintf("Status: %s", report.Group.Status))
-	pdf.Ln(12)
+	return d.Fpdf
+}
 
-	pdf.SetFillColor(200, 200, 255)
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 10, "Payment History", "", 1, "L", true, 0, "")
-	pdf.Ln(2)
+func writeComment(d *document, comment dto.CommentResponse, billName string, depth int) {
+	indent := 6.0 * float64(min(depth, 8))
 
-	pdf.SetFont("Arial", "", 10)
-	for _, history := range report.History {
-		pdf.Cell(0, 10, fmt.Sprintf("Paid By: %s, Amount: $%.2f, Paid At: %s",
-			history.PaidBy, history.Amount, history.PaidAt.Format("2006-01-02 15:04:05")))
-		pdf.Ln(6)
+	heading := fmt.Sprintf("%s - %s", comment.AuthorName, d.locale.FormatTime(comment.CreatedAt, true))
+	if comment.EditedAt != nil {
+		heading += " (edited)"
 	}
-	pdf.Ln(6)
-	if len(report.Members) > 0 {
-		pdf.SetFillColor(200, 200, 255)
-		pdf.SetFont("Arial", "B", 12)
-		pdf.CellFormat(0, 10, "Members", "", 1, "L", true, 0, "")
-		pdf.Ln(2)
+	if depth == 0 && comment.BillID != nil {
+		heading += fmt.Sprintf(" on bill: %s", billName)
 	}
-
-	pdf.SetFont("Arial", "", 10)
-	for _, member := range report.Members {
-		pdf.Cell(0, 10, fmt.Sprintf("Member Name: %s, Split Amount: $%.2f, Has Paid: %t",
-			report.UserInfo[member.UserID], member.SplitAmount, member.HasPaid))
-		pdf.Ln(6)
+	body := comment.Body
+	if comment.Removed {
+		body = "[comment deleted]"
 	}
 
-	return pdf
+	d.ensureSpace(12)
+	d.font("B", 9)
+	d.SetTextColor(0, 51, 102)
+	d.paragraph(indent, 6, heading)
+	d.font("", 9)
+	d.SetTextColor(33, 33, 33)
+	d.paragraph(indent, 5, body)
+	d.Ln(2)
+
+	for _, reply := range comment.Replies {
+		writeComment(d, reply, billName, depth+1)
+	}
 }
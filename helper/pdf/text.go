@@ -0,0 +1,105 @@
+package pdf
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+const replacementChar = '�'
+
+// segment is a piece of a line drawn with one face.
+type segment struct {
+	face int
+	text string
+}
+
+// visual prepares a line of text for drawing: emoji are spelled out, Devanagari vowel signs are moved
+// in front of their consonants, Arabic letters are joined, right-to-left runs are put in display
+// order, invisible formatting characters are dropped and characters that no font can draw (including
+// the rest of what lies outside the Basic Multilingual Plane, which the PDF library cannot embed)
+// become U+FFFD.
+func visual(s string) string {
+	s = reorder(shapeArabic(reorderDevanagari(spellEmoji(s))))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case unicode.Is(unicode.Variation_Selector, r), unicode.Is(unicode.Cf, r), unicode.IsControl(r):
+			return -1
+		case r > 0xFFFF, faceFor(r) < 0:
+			return replacementChar
+		}
+		return r
+	}, s)
+}
+
+// segments splits a prepared line into runs of characters that share a face.
+func segments(s string) []segment {
+	var segs []segment
+	for _, r := range s {
+		f := faceFor(r)
+		if f < 0 {
+			f = 0
+		}
+		// Spaces and combining marks stay with the run they are in rather than starting a new one.
+		if len(segs) > 0 && (unicode.IsSpace(r) || unicode.Is(unicode.Mn, r) || segs[len(segs)-1].face == f) {
+			segs[len(segs)-1].text += string(r)
+			continue
+		}
+		segs = append(segs, segment{face: f, text: string(r)})
+	}
+	return segs
+}
+
+// reorder puts the runs of a line containing right-to-left script in display order.
+func reorder(s string) string {
+	if !hasRTL(s) {
+		return s
+	}
+	var p bidi.Paragraph
+	if _, err := p.SetString(s); err != nil {
+		return s
+	}
+	ordering, err := p.Order()
+	if err != nil {
+		return s
+	}
+	runs := make([]string, ordering.NumRuns())
+	for i := range runs {
+		run := ordering.Run(i)
+		runs[i] = run.String()
+		if run.Direction() == bidi.RightToLeft {
+			runs[i] = reverseClusters(runs[i])
+		}
+	}
+	if !p.IsLeftToRight() {
+		slices.Reverse(runs)
+	}
+	return strings.Join(runs, "")
+}
+
+func hasRTL(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko) {
+			return true
+		}
+	}
+	return false
+}
+
+// reverseClusters reverses a run, keeping combining marks after the letter they belong to.
+func reverseClusters(s string) string {
+	var clusters []string
+	for _, r := range s {
+		if len(clusters) > 0 && unicode.Is(unicode.Mn, r) {
+			clusters[len(clusters)-1] += string(r)
+			continue
+		}
+		clusters = append(clusters, string(r))
+	}
+	slices.Reverse(clusters)
+	return strings.Join(clusters, "")
+}
@@ -0,0 +1,64 @@
+package pdf
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/helper/layout"
+)
+
+func TestMain(m *testing.M) {
+	config.SetConfig(config.Config{})
+	os.Exit(m.Run())
+}
+
+func TestVisual(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"latin", "Goa trip", "Goa trip"},
+		{"hindi", "भुगतान", "भुगतान"},
+		{"vowel sign i", "किताब", "िकताब"},
+		{"vowel sign i after a conjunct", "स्किन", "िस्कन"},
+		{"vowel sign i after a nukta", "ड़ि", "िड़"},
+		{"vowel sign i alone", "ि", "ि"},
+		{"emoji", "🍕 night", "[slice of pizza] night"},
+		{"emoji sequence", "👍🏽👨‍👩", "[thumbs up sign][man][woman]"},
+		{"flag", "Trip 🇮🇳", "Trip [IN]"},
+		{"emoji in the BMP", "☕", "☕"},
+		{"no font", "\U00020000", "�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := visual(tt.in); got != tt.want {
+				t.Errorf("visual(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFaces(t *testing.T) {
+	for _, tt := range []struct {
+		r    rune
+		face int
+	}{{'a', 0}, {'ب', 0}, {'क', 1}, {'ि', 1}, {'\U00020000', -1}} {
+		if got := faceFor(tt.r); got != tt.face {
+			t.Errorf("faceFor(%q) = %d, want %d", tt.r, got, tt.face)
+		}
+	}
+}
+
+func TestHindiReport(t *testing.T) {
+	doc := layout.Document{Title: "किराया 🏠", Blocks: []layout.Block{{Type: layout.SectionText, Title: "भुगतान", Text: "राहुल ने ₹1,200 का भुगतान किया 👍"}}}
+	var buf bytes.Buffer
+	if err := GenerateReportPDF(doc).Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("/FontName /utf8notodevanagari")) {
+		t.Error("the report does not embed the Devanagari font")
+	}
+}
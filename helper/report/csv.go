@@ -0,0 +1,84 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+// csvRenderer writes the fields and table sections of the template, each starting with a header row
+// and separated by an empty line. Amounts are written without the currency symbol so spreadsheets read
+// them as numbers; titles, texts, charts and comments are left out.
+type csvRenderer struct {
+	options
+}
+
+func (csvRenderer) Format() string      { return "csv" }
+func (csvRenderer) ContentType() string { return "text/csv" }
+func (csvRenderer) Extension() string   { return ".csv" }
+
+func (c csvRenderer) OwnerGroups(w io.Writer, groups []dto.Group, from, to time.Time, owner string) error {
+	return writeCSV(w, c.template(layout.KindGroups, c.Format()).Bind(layout.OwnerData(groups, from, to, owner)))
+}
+
+func (c csvRenderer) GroupDetail(w io.Writer, report dto.GroupReportRequest) error {
+	return writeCSV(w, c.template(layout.KindGroup, c.Format()).Bind(layout.GroupData(report)))
+}
+
+func writeCSV(w io.Writer, doc layout.Document) error {
+	cw := csv.NewWriter(w)
+	first := true
+	for _, b := range doc.Blocks {
+		if b.Type != layout.SectionFields && b.Type != layout.SectionTable {
+			continue
+		}
+		if !first {
+			_ = cw.Write(nil)
+		}
+		first = false
+
+		if b.Type == layout.SectionFields {
+			_ = cw.Write([]string{"Field", "Value"})
+			for _, f := range b.Fields {
+				_ = cw.Write([]string{escapeFormula(f.Label), plainCell(doc.Locale, f.Value)})
+			}
+			continue
+		}
+		header := make([]string, len(b.Columns))
+		for i, c := range b.Columns {
+			header[i] = escapeFormula(c.Title)
+		}
+		_ = cw.Write(header)
+		for _, row := range b.Rows {
+			record := make([]string, len(row))
+			for i, v := range row {
+				record[i] = plainCell(doc.Locale, v)
+			}
+			_ = cw.Write(record)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// plainCell writes v as Plain does. Text is escaped, numbers are left alone so a negative amount
+// stays a number.
+func plainCell(l layout.Locale, v layout.Value) string {
+	if v.Kind == layout.KindText {
+		return escapeFormula(l.Plain(v))
+	}
+	return l.Plain(v)
+}
+
+// escapeFormula prefixes text that a spreadsheet would run as a formula with a quote, so names and
+// remarks entered by users show up as typed instead of being evaluated.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,325 @@
+package report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+// htmlRenderer writes a standalone HTML page with inline styles, the logo as a data URI and the
+// charts as SVG, so it can be mailed or opened without the server.
+type htmlRenderer struct {
+	options
+}
+
+func (htmlRenderer) Format() string      { return "html" }
+func (htmlRenderer) ContentType() string { return "text/html" }
+func (htmlRenderer) Extension() string   { return ".html" }
+
+func (h htmlRenderer) OwnerGroups(w io.Writer, groups []dto.Group, from, to time.Time, owner string) error {
+	return writeHTML(w, h.template(layout.KindGroups, h.Format()).Bind(layout.OwnerData(groups, from, to, owner)))
+}
+
+func (h htmlRenderer) GroupDetail(w io.Writer, report dto.GroupReportRequest) error {
+	return writeHTML(w, h.template(layout.KindGroup, h.Format()).Bind(layout.GroupData(report)))
+}
+
+type htmlPage struct {
+	Title       string
+	Footer      string
+	Logo        template.URL
+	Color       string
+	AccentColor string
+	Generated   string
+	Locale      layout.Locale
+	Blocks      []htmlBlock
+}
+
+// htmlBlock is a block with its values written out for the page.
+type htmlBlock struct {
+	layout.Block
+	Pairs   [][2]string // fields
+	Widths  []string    // table
+	Aligns  []string    // table
+	Cells   [][]string  // table
+	Classes []string    // table, per row
+	SVG     template.HTML
+}
+
+func writeHTML(w io.Writer, doc layout.Document) error {
+	page := htmlPage{
+		Title:       doc.Title,
+		Footer:      doc.Branding.Footer,
+		Color:       colorOr(doc.Branding.Color, "#003366"),
+		AccentColor: colorOr(doc.Branding.AccentColor, "#c8c8ff"),
+		Generated:   doc.Locale.Stamp(time.Now()),
+		Locale:      doc.Locale,
+	}
+	if raw, format, err := doc.Branding.LogoImage(); doc.Branding.Logo != "" && err == nil {
+		page.Logo = template.URL(fmt.Sprintf("data:image/%s;base64,%s", format, base64.StdEncoding.EncodeToString(raw)))
+	}
+
+	for _, b := range doc.Blocks {
+		if b.Hidden() {
+			continue
+		}
+		hb := htmlBlock{Block: b}
+		switch b.Type {
+		case layout.SectionFields:
+			for _, f := range b.Fields {
+				hb.Pairs = append(hb.Pairs, [2]string{f.Label, doc.Locale.Format(f.Value)})
+			}
+		case layout.SectionTable:
+			total := 0.0
+			for _, c := range b.Columns {
+				total += c.Width
+			}
+			for _, c := range b.Columns {
+				hb.Widths = append(hb.Widths, fmt.Sprintf("%.1f%%", 100*c.Width/max(total, 1)))
+				hb.Aligns = append(hb.Aligns, map[string]string{"L": "left", "C": "center", "R": "right"}[c.Align])
+			}
+			for i, row := range b.Rows {
+				values := make([]string, len(row))
+				for j, v := range row {
+					values[j] = doc.Locale.Format(v)
+				}
+				hb.Cells = append(hb.Cells, values)
+				hb.Classes = append(hb.Classes, b.Highlights[i])
+			}
+		case layout.SectionChart:
+			hb.SVG = svgChart(b.Chart, doc.Locale)
+		}
+		page.Blocks = append(page.Blocks, hb)
+	}
+	return htmlTemplate.Execute(w, page)
+}
+
+func colorOr(color, fallback string) string {
+	if color == "" {
+		return fallback
+	}
+	return color
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"commentOf": func(c dto.CommentResponse, bill string, top bool, locale layout.Locale) commentView {
+		return commentView{Comment: c, Bill: bill, Top: top, Locale: locale}
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: "DejaVu Sans", Arial, sans-serif; font-size: 13px; color: #212121; max-width: 960px; margin: 24px auto; padding: 0 16px; }
+header { display: flex; justify-content: space-between; align-items: flex-start; gap: 16px; }
+header img { max-height: 56px; max-width: 200px; }
+h1 { color: {{.Color}}; font-size: 22px; margin: 0 0 8px; }
+h2 { background: {{.AccentColor}}; font-size: 16px; padding: 6px 8px; margin: 24px 0 8px; }
+p.subtitle { font-style: italic; }
+table { border-collapse: collapse; width: 100%; table-layout: fixed; }
+th, td { border: 1px solid #999; padding: 3px 5px; vertical-align: top; word-wrap: break-word; }
+th { background: {{.AccentColor}}; }
+tr.warning td { background: #fff0c8; }
+tr.exceeded td { background: #ffcccc; }
+dl { display: grid; grid-template-columns: max-content auto; gap: 2px 12px; }
+dt { font-weight: bold; }
+dd { margin: 0; }
+.comment { margin: 8px 0; }
+.comment .heading { color: #003366; font-weight: bold; }
+.comment .body { white-space: pre-wrap; }
+.comment .replies { margin-left: 24px; }
+footer { margin-top: 32px; color: #777; font-size: 11px; border-top: 1px solid #ccc; padding-top: 4px; }
+.page-break { page-break-after: always; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+{{if .Logo}}<img src="{{.Logo}}" alt="">{{end}}
+</header>
+{{range .Blocks}}
+{{- if eq .Type "text"}}{{if .Title}}<h2>{{.Title}}</h2><p>{{.Text}}</p>{{else}}<p class="subtitle">{{.Text}}</p>{{end}}
+{{- else if eq .Type "fields"}}{{if .Title}}<h2>{{.Title}}</h2>{{end}}
+<dl>{{range .Pairs}}<dt>{{index . 0}}</dt><dd>{{index . 1}}</dd>{{end}}</dl>
+{{- else if eq .Type "table"}}{{if .Title}}<h2>{{.Title}}</h2>{{end}}
+{{if .Cells}}{{$b := .}}<table>
+<thead><tr>{{range $i, $c := .Columns}}<th style="width: {{index $b.Widths $i}}">{{$c.Title}}</th>{{end}}</tr></thead>
+<tbody>{{range $r, $row := .Cells}}<tr{{with index $b.Classes $r}} class="{{.}}"{{end}}>{{range $i, $v := $row}}<td style="text-align: {{index $b.Aligns $i}}">{{$v}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>{{else}}<p>{{.Empty}}</p>{{end}}
+{{- else if eq .Type "chart"}}{{if .Title}}<h2>{{.Title}}</h2>{{end}}
+{{.SVG}}
+{{- else if eq .Type "comments"}}<h2>{{if .Title}}{{.Title}}{{else}}Comments{{end}}</h2>
+{{$bill := .BillName}}{{range .Comments}}{{template "comment" commentOf . $bill true $.Locale}}{{end}}
+{{- else if eq .Type "pageBreak"}}<div class="page-break"></div>
+{{- end}}
+{{end}}
+<footer>{{with .Footer}}{{.}} · {{end}}Generated {{.Generated}}</footer>
+</body>
+</html>
+{{define "comment"}}<div class="comment">
+<div class="heading">{{.Comment.AuthorName}} - {{.Locale.FormatTime .Comment.CreatedAt true}}{{if .Comment.EditedAt}} (edited){{end}}{{if and .Top .Comment.BillID}} on bill: {{.Bill}}{{end}}</div>
+<div class="body">{{if .Comment.Removed}}[comment deleted]{{else}}{{.Comment.Body}}{{end}}</div>
+{{if .Comment.Replies}}<div class="replies">{{$bill := .Bill}}{{range .Comment.Replies}}{{template "comment" commentOf . $bill false $.Locale}}{{end}}</div>{{end}}
+</div>{{end}}`))
+
+// commentView is a comment with what its template needs to know about where it is.
+type commentView struct {
+	Comment dto.CommentResponse
+	Bill    string
+	Top     bool
+	Locale  layout.Locale
+}
+
+const (
+	svgWidth    = 720.0
+	paidFill    = "#4caf50"
+	unpaidFill  = "#ff9800"
+	spendFill   = "#0066cc"
+	svgGridLine = "#dcdcdc"
+)
+
+// svgChart draws a chart as inline SVG.
+func svgChart(c *layout.Chart, locale layout.Locale) template.HTML {
+	var b strings.Builder
+	switch c.Type {
+	case layout.ChartPaid, layout.ChartContribution, layout.ChartCategories:
+		svgBars(&b, c, locale)
+	case layout.ChartProgress:
+		paid := min(max(c.Paid, 0), c.Total)
+		fmt.Fprintf(&b, `<svg width="100%%" viewBox="0 0 %g 44" xmlns="http://www.w3.org/2000/svg">`, svgWidth)
+		fmt.Fprintf(&b, `<rect x="0" y="0" width="%g" height="20" fill="%s"/>`, svgWidth, unpaidFill)
+		fmt.Fprintf(&b, `<rect x="0" y="0" width="%.1f" height="20" fill="%s"/>`, svgWidth*paid/c.Total, paidFill)
+		fmt.Fprintf(&b, `<text x="0" y="38" font-size="12">Paid %s (%.0f%%)</text>`, esc(locale.Money(paid)), 100*paid/c.Total)
+		fmt.Fprintf(&b, `<text x="%g" y="38" font-size="12" text-anchor="end">Outstanding %s</text></svg>`, svgWidth, esc(locale.Money(c.Total-paid)))
+	case layout.ChartSpending:
+		svgColumns(&b, c, locale)
+	case layout.ChartPayments:
+		svgPayments(&b, c, locale)
+	}
+	return template.HTML(b.String()) // #nosec G203 -- built from escaped values only
+}
+
+func svgBars(b *strings.Builder, c *layout.Chart, locale layout.Locale) {
+	const labelWidth, amountWidth, row = 170.0, 150.0, 22.0
+	largest := 0.0
+	for _, bar := range c.Bars {
+		largest = max(largest, max(bar.Paid, 0)+max(bar.Outstanding, 0)+max(bar.Amount, 0))
+	}
+	maxBar := svgWidth - labelWidth - amountWidth
+	fmt.Fprintf(b, `<svg width="100%%" viewBox="0 0 %g %g" xmlns="http://www.w3.org/2000/svg">`, svgWidth, row*float64(len(c.Bars))+4)
+	for i, bar := range c.Bars {
+		y := row * float64(i)
+		fmt.Fprintf(b, `<text x="0" y="%.1f" font-size="12">%s</text>`, y+15, esc(bar.Label))
+		x := labelWidth
+		segments := []struct {
+			amount float64
+			fill   string
+		}{{max(bar.Paid, 0), paidFill}, {max(bar.Outstanding, 0), unpaidFill}, {max(bar.Amount, 0), spendFill}}
+		for _, s := range segments {
+			if s.amount <= 0 || largest <= 0 {
+				continue
+			}
+			w := maxBar * s.amount / largest
+			fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="14" fill="%s"/>`, x, y+4, w, s.fill)
+			x += w
+		}
+		label := locale.Money(bar.Amount)
+		if c.Type != layout.ChartCategories {
+			label = fmt.Sprintf("%s of %s", locale.Money(bar.Paid), locale.Money(bar.Paid+bar.Outstanding))
+		}
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" font-size="12">%s</text>`, x+4, y+15, esc(label))
+	}
+	b.WriteString(`</svg>`)
+}
+
+// svgAxis draws the value grid of a chart and returns the y position of a value.
+func svgAxis(b *strings.Builder, largest, left, top, height float64, locale layout.Locale) func(float64) float64 {
+	step := niceStep(largest)
+	upper := step * math.Max(1, math.Ceil(largest/step))
+	y := func(v float64) float64 { return top + height - height*v/upper }
+	for v := 0.0; v <= upper+step/2; v += step {
+		fmt.Fprintf(b, `<line x1="%g" x2="%g" y1="%.1f" y2="%.1f" stroke="%s"/>`, left, svgWidth, y(v), y(v), svgGridLine)
+		fmt.Fprintf(b, `<text x="%g" y="%.1f" font-size="10" text-anchor="end">%s</text>`, left-4, y(v)+3, esc(locale.Amount(v, 0)))
+	}
+	return y
+}
+
+func niceStep(largest float64) float64 {
+	if largest <= 0 {
+		return 1
+	}
+	raw := largest / 4
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*magnitude >= raw {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func svgColumns(b *strings.Builder, c *layout.Chart, locale layout.Locale) {
+	const left, top, height = 70.0, 8.0, 180.0
+	largest := 0.0
+	for _, p := range c.Periods {
+		largest = max(largest, p.Amount)
+	}
+	fmt.Fprintf(b, `<svg width="100%%" viewBox="0 0 %g %g" xmlns="http://www.w3.org/2000/svg">`, svgWidth, top+height+24)
+	y := svgAxis(b, largest, left, top, height, locale)
+	slot := (svgWidth - left) / float64(len(c.Periods))
+	every := int(math.Ceil(float64(len(c.Periods)) / 12))
+	for i, p := range c.Periods {
+		x := left + float64(i)*slot
+		if p.Amount > 0 {
+			fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`,
+				x+slot*0.15, y(p.Amount), slot*0.7, top+height-y(p.Amount), spendFill, esc(p.Label), esc(locale.Money(p.Amount)))
+		}
+		if i%every == 0 {
+			fmt.Fprintf(b, `<text x="%.1f" y="%g" font-size="10" text-anchor="middle">%s</text>`, x+slot/2, top+height+16, esc(p.Label))
+		}
+	}
+	b.WriteString(`</svg>`)
+}
+
+func svgPayments(b *strings.Builder, c *layout.Chart, locale layout.Locale) {
+	const left, top, height = 70.0, 8.0, 180.0
+	total := 0.0
+	for _, p := range c.Points {
+		total += p.Amount
+	}
+	fmt.Fprintf(b, `<svg width="100%%" viewBox="0 0 %g %g" xmlns="http://www.w3.org/2000/svg">`, svgWidth, top+height+24)
+	y := svgAxis(b, max(total, c.Total), left, top, height, locale)
+	x := func(t time.Time) float64 {
+		return left + (svgWidth-left)*float64(t.Sub(c.Start))/float64(c.End.Sub(c.Start))
+	}
+	if c.Total > 0 {
+		fmt.Fprintf(b, `<line x1="%g" x2="%g" y1="%.1f" y2="%.1f" stroke="%s" stroke-width="1.5" stroke-dasharray="6 4"/>`, left, svgWidth, y(c.Total), y(c.Total), unpaidFill)
+	}
+	points := []string{fmt.Sprintf("%g,%.1f", left, y(0))}
+	running := 0.0
+	for _, p := range c.Points {
+		px := x(p.At)
+		points = append(points, fmt.Sprintf("%.1f,%.1f", px, y(running)))
+		running += p.Amount
+		points = append(points, fmt.Sprintf("%.1f,%.1f", px, y(running)))
+		fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s</title></circle>`, px, y(running), paidFill, esc(locale.Money(p.Amount)))
+	}
+	points = append(points, fmt.Sprintf("%g,%.1f", svgWidth, y(running)))
+	fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), paidFill)
+	fmt.Fprintf(b, `<text x="%g" y="%g" font-size="10">%s</text>`, left, top+height+16, esc(locale.FormatTime(c.Start, false)))
+	fmt.Fprintf(b, `<text x="%g" y="%g" font-size="10" text-anchor="end">%s</text>`, svgWidth, top+height+16, esc(locale.FormatTime(c.End, false)))
+	b.WriteString(`</svg>`)
+}
+
+func esc(s string) string {
+	return template.HTMLEscapeString(s)
+}
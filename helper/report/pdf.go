@@ -0,0 +1,26 @@
+package report
+
+import (
+	"io"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/helper/pdf"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+type pdfRenderer struct {
+	options
+}
+
+func (pdfRenderer) Format() string      { return "pdf" }
+func (pdfRenderer) ContentType() string { return "application/pdf" }
+func (pdfRenderer) Extension() string   { return ".pdf" }
+
+func (p pdfRenderer) OwnerGroups(w io.Writer, groups []dto.Group, from, to time.Time, owner string) error {
+	return pdf.GenerateReportPDF(p.template(layout.KindGroups, p.Format()).Bind(layout.OwnerData(groups, from, to, owner))).Output(w)
+}
+
+func (p pdfRenderer) GroupDetail(w io.Writer, report dto.GroupReportRequest) error {
+	return pdf.GenerateReportPDF(p.template(layout.KindGroup, p.Format()).Bind(layout.GroupData(report))).Output(w)
+}
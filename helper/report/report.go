@@ -0,0 +1,145 @@
+// Package report renders the owner and group reports in the formats clients can ask for.
+package report
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+// Renderer writes reports in one output format.
+type Renderer interface {
+	Format() string
+	ContentType() string
+	Extension() string
+	OwnerGroups(w io.Writer, groups []dto.Group, from, to time.Time, owner string) error
+	GroupDetail(w io.Writer, report dto.GroupReportRequest) error
+}
+
+var renderers = []Renderer{pdfRenderer{}, csvRenderer{}, xlsxRenderer{}, htmlRenderer{}}
+
+// options are the report options of the renderers that lay reports out with templates.
+type options struct {
+	noCharts bool
+	custom   *layout.Template
+	locale   layout.Locale
+}
+
+// template returns the custom template, or the bundled one for the format.
+func (o options) template(kind, format string) layout.Template {
+	t := layout.Default(kind, format)
+	if o.custom != nil {
+		t = *o.custom
+	}
+	if o.noCharts {
+		t = t.WithoutCharts()
+	}
+	t.Locale = t.Locale.Merge(o.locale)
+	return t
+}
+
+// configure applies the change to the options of a renderer that uses templates, other renderers are
+// returned unchanged.
+func configure(r Renderer, change func(*options)) Renderer {
+	switch r := r.(type) {
+	case pdfRenderer:
+		change(&r.options)
+		return r
+	case csvRenderer:
+		change(&r.options)
+		return r
+	case htmlRenderer:
+		change(&r.options)
+		return r
+	}
+	return r
+}
+
+// Formats lists the supported format names.
+func Formats() []string {
+	names := make([]string, len(renderers))
+	for i, r := range renderers {
+		names[i] = r.Format()
+	}
+	return names
+}
+
+// ForFormat returns the renderer of a format name, PDF when the name is empty.
+func ForFormat(format string) (Renderer, error) {
+	if format == "" {
+		return pdfRenderer{}, nil
+	}
+	format = strings.ToLower(format)
+	for _, renderer := range renderers {
+		if renderer.Format() == format {
+			return renderer, nil
+		}
+	}
+	return nil, fmt.Errorf("format must be one of %s", strings.Join(Formats(), ", "))
+}
+
+// ForRequest picks the renderer from the format query parameter, then from the Accept header.
+// PDF is used when neither asks for a supported format; an unknown format parameter is an error.
+func ForRequest(r *http.Request) (Renderer, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return ForFormat(format)
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		for _, renderer := range renderers {
+			if renderer.ContentType() == mediaType {
+				return renderer, nil
+			}
+		}
+	}
+	return pdfRenderer{}, nil
+}
+
+// WithoutCharts returns the renderer with the charts left out. Only PDF and HTML reports have charts.
+func WithoutCharts(r Renderer) Renderer {
+	return configure(r, func(o *options) { o.noCharts = true })
+}
+
+// WithTemplate returns the renderer laying reports out with the template instead of the bundled one.
+// XLSX workbooks keep their fixed sheets.
+func WithTemplate(r Renderer, t layout.Template) Renderer {
+	return configure(r, func(o *options) { o.custom = &t })
+}
+
+// WithLocale returns the renderer writing amounts and dates in the locale where the template does not
+// set its own. XLSX workbooks store plain numbers and dates.
+func WithLocale(r Renderer, l layout.Locale) Renderer {
+	return configure(r, func(o *options) { o.locale = l })
+}
+
+// memberName returns the name of a user in the report, falling back to their id.
+func memberName(report dto.GroupReportRequest, userID uint) string {
+	if name, ok := report.UserInfo[userID]; ok && name != "" {
+		return name
+	}
+	return fmt.Sprintf("User %d", userID)
+}
+
+func lastBillDate(group dto.Group) string {
+	if group.Bills.Date.IsZero() {
+		return "N/A"
+	}
+	return group.Bills.Date.Format("2006-01-02")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
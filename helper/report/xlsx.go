@@ -0,0 +1,168 @@
+package report
+
+import (
+	"io"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxRenderer writes workbooks with a summary sheet followed by one sheet per table.
+type xlsxRenderer struct{}
+
+func (xlsxRenderer) Format() string { return "xlsx" }
+func (xlsxRenderer) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+func (xlsxRenderer) Extension() string { return ".xlsx" }
+
+func (xlsxRenderer) OwnerGroups(w io.Writer, groups []dto.Group, from, to time.Time, owner string) error {
+	var total, paid float64
+	groupRows := make([][]any, 0, len(groups))
+	for _, group := range groups {
+		total += group.TotalAmount
+		paid += group.PaidAmount
+		groupRows = append(groupRows, []any{group.ID, group.Name, group.TotalAmount, group.PerUserSplitAmount, group.PaidAmount, group.Members, group.Status, group.Bills.Category, lastBillDate(group)})
+	}
+
+	b := newWorkbook()
+	b.sheet("Summary", []string{"Field", "Value"}, [][]any{
+		{"Owner", owner},
+		{"Report Period", from.Format("2006-01-02") + " - " + to.Format("2006-01-02")},
+		{"Groups", len(groups)},
+		{"Total Amount", total},
+		{"Paid Amount", paid},
+		{"Outstanding Amount", max(total-paid, 0)},
+	})
+	b.sheet("Groups", ownerGroupsHeader, groupRows)
+	return b.write(w)
+}
+
+func (xlsxRenderer) GroupDetail(w io.Writer, report dto.GroupReportRequest) error {
+	group := report.Group
+	members := make([][]any, 0, len(report.Members))
+	for _, member := range report.Members {
+		members = append(members, []any{memberName(report, member.UserID), member.SplitAmount, yesNo(member.HasPaid), member.Remarks})
+	}
+	history := make([][]any, 0, len(report.History))
+	for _, h := range report.History {
+		history = append(history, []any{h.PaidAt, h.PaidBy, h.Amount})
+	}
+
+	b := newWorkbook()
+	b.sheet("Summary", []string{"Field", "Value"}, [][]any{
+		{"Group ID", group.ID},
+		{"Group Name", group.Name},
+		{"Owner", memberName(report, group.CreatedBy)},
+		{"Status", group.Status},
+		{"Bill Name", report.Bill.Name},
+		{"Category", report.Bill.Category},
+		{"Bill Amount", report.Bill.Amount},
+		{"Total Amount", group.TotalAmount},
+		{"Share Per Member", group.PerUserSplitAmount},
+		{"Paid Amount", group.PaidAmount},
+		{"Outstanding Amount", max(group.TotalAmount-group.PaidAmount, 0)},
+		{"Members", len(report.Members)},
+		{"Created At", group.CreatedAt},
+		{"Updated At", group.UpdatedAt},
+	})
+	b.sheet("Members", membersHeader, members)
+	b.sheet("Payment History", historyHeader, history)
+	return b.write(w)
+}
+
+var (
+	ownerGroupsHeader = []string{"Group ID", "Group Name", "Total Amount", "Share Per Member", "Paid Amount", "Members", "Status", "Category", "Last Bill Date"}
+	membersHeader     = []string{"Member", "Split Amount", "Has Paid", "Remarks"}
+	historyHeader     = []string{"Paid At", "Paid By", "Amount"}
+)
+
+// workbook collects sheets and remembers the first error so callers can add sheets unconditionally.
+type workbook struct {
+	f        *excelize.File
+	sheets   int
+	header   int
+	money    int
+	dateTime int
+	err      error
+}
+
+func newWorkbook() *workbook {
+	b := &workbook{f: excelize.NewFile()}
+	b.header, b.err = b.f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"C8C8FF"}},
+	})
+	if b.err == nil {
+		b.money, b.err = b.f.NewStyle(&excelize.Style{NumFmt: 4}) // #,##0.00
+	}
+	if b.err == nil {
+		b.dateTime, b.err = b.f.NewStyle(&excelize.Style{NumFmt: 22}) // m/d/yy h:mm
+	}
+	return b
+}
+
+func (b *workbook) sheet(name string, header []string, rows [][]any) {
+	if b.err != nil {
+		return
+	}
+	if b.sheets == 0 {
+		b.err = b.f.SetSheetName("Sheet1", name)
+	} else {
+		_, b.err = b.f.NewSheet(name)
+	}
+	b.sheets++
+	if b.err != nil {
+		return
+	}
+
+	headerRow := make([]any, len(header))
+	for i, h := range header {
+		headerRow[i] = h
+	}
+	b.row(name, 1, headerRow, b.header)
+	for i, row := range rows {
+		b.row(name, i+2, row, 0)
+	}
+	if b.err == nil && len(header) > 0 {
+		last, _ := excelize.ColumnNumberToName(len(header))
+		b.err = b.f.SetColWidth(name, "A", last, 20)
+	}
+}
+
+func (b *workbook) row(sheet string, rowNum int, values []any, style int) {
+	for col, value := range values {
+		if b.err != nil {
+			return
+		}
+		cell, _ := excelize.CoordinatesToCellName(col+1, rowNum)
+		if text, ok := value.(string); ok {
+			value = escapeFormula(text)
+		}
+		if b.err = b.f.SetCellValue(sheet, cell, value); b.err != nil {
+			return
+		}
+		cellStyle := style
+		if cellStyle == 0 {
+			switch value.(type) {
+			case float64:
+				cellStyle = b.money
+			case time.Time:
+				cellStyle = b.dateTime
+			}
+		}
+		if cellStyle != 0 {
+			b.err = b.f.SetCellStyle(sheet, cell, cell, cellStyle)
+		}
+	}
+}
+
+func (b *workbook) write(w io.Writer) error {
+	defer b.f.Close()
+	if b.err != nil {
+		return b.err
+	}
+	b.f.SetActiveSheet(0)
+	return b.f.Write(w)
+}
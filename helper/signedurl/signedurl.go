@@ -0,0 +1,77 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	e "errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrExpired          = e.New("signed url has expired")
+	ErrInvalidSignature = e.New("signed url signature is invalid")
+)
+
+// Sign returns path with expires and signature query parameters that authorise access until expiresAt.
+func Sign(path string, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", signature(path, expires))
+	return path + "?" + q.Encode()
+}
+
+// Verify checks the expires and signature query parameters of a request for path.
+func Verify(path string, query url.Values) error {
+	expires := query.Get("expires")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature(path, expires)), []byte(query.Get("signature"))) {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > unix {
+		return ErrExpired
+	}
+	return nil
+}
+
+func signature(path, expires string) string {
+	mac := hmac.New(sha256.New, key())
+	fmt.Fprintf(mac, "%s\n%s", path, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var (
+	signingKey     []byte
+	signingKeyOnce sync.Once
+)
+
+// key returns storage.urlSigningKey. The JWT secret is never reused, so a leaked download link
+// says nothing about tokens. Without a configured key a random one is made, which keeps links
+// working only on this instance until it restarts.
+func key() []byte {
+	signingKeyOnce.Do(func() {
+		if k := config.GetConfig().Storage.URLSigningKey; k != "" {
+			signingKey = []byte(k)
+			return
+		}
+		log.Warn("storage.urlSigningKey is not set, signing download links with a random key")
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			log.Panic("Failed to generate a URL signing key", zap.Error(err))
+		}
+	})
+	return signingKey
+}
@@ -0,0 +1,63 @@
+package signedurl
+
+import (
+	e "errors"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+)
+
+func TestMain(m *testing.M) {
+	config.SetConfig(config.Config{Storage: config.Storage{URLSigningKey: "test-signing-key"}})
+	os.Exit(m.Run())
+}
+
+// query returns the query parameters of a signed URL.
+func query(t *testing.T, signed string) url.Values {
+	t.Helper()
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query()
+}
+
+func TestSignVerify(t *testing.T) {
+	const path = "/v1/attachments/7/download"
+	valid := query(t, Sign(path, time.Now().Add(time.Minute)))
+	if !strings.HasPrefix(Sign(path, time.Now()), path+"?") {
+		t.Fatalf("Sign() = %q, want the path with a query", Sign(path, time.Now()))
+	}
+
+	laterExpiry := query(t, Sign(path, time.Now().Add(time.Minute)))
+	laterExpiry.Set("expires", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	otherSignature := query(t, Sign(path, time.Now().Add(time.Minute)))
+	otherSignature.Set("signature", strings.Repeat("0", 64))
+
+	tests := []struct {
+		name  string
+		path  string
+		query url.Values
+		want  error
+	}{
+		{"valid", path, valid, nil},
+		{"other path", "/v1/attachments/8/download", valid, ErrInvalidSignature},
+		{"extended expiry", path, laterExpiry, ErrInvalidSignature},
+		{"wrong signature", path, otherSignature, ErrInvalidSignature},
+		{"no signature", path, url.Values{"expires": valid["expires"]}, ErrInvalidSignature},
+		{"no expiry", path, url.Values{"signature": valid["signature"]}, ErrInvalidSignature},
+		{"expired", path, query(t, Sign(path, time.Now().Add(-time.Second))), ErrExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.path, tt.query); !e.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
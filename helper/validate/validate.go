@@ -29,6 +29,12 @@ func ValidateStruct(s interface{}) error {
 
 			case "email":
 				return fmt.Errorf("field '%s' must be a valid email address", fieldName)
+			case "url":
+				return fmt.Errorf("field '%s' must be a valid URL", fieldName)
+			case "dateFormat":
+				return fmt.Errorf("field '%s' must be a date in the format YYYY-MM-DD", fieldName)
+			case "max":
+				return fmt.Errorf("field '%s' must be at most %s characters long", fieldName, err.Param())
 			case "password_complexity":
 				return fmt.Errorf("field '%s' must be at least 8 characters long and contain at least one uppercase letter, one lowercase letter, one digit and one special character", fieldName)
 			default:
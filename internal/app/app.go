@@ -1,22 +1,101 @@
 package app
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/broker"
+	"github.com/mohdjishin/SplitWise/internal/exportjob"
+	"github.com/mohdjishin/SplitWise/internal/handlers"
+	"github.com/mohdjishin/SplitWise/internal/notify"
+	"github.com/mohdjishin/SplitWise/internal/reminder"
+	"github.com/mohdjishin/SplitWise/internal/reportjob"
 	"github.com/mohdjishin/SplitWise/internal/routes"
+	"github.com/mohdjishin/SplitWise/internal/rpc"
 	"github.com/mohdjishin/SplitWise/internal/server"
+	"github.com/mohdjishin/SplitWise/internal/storage"
+	"github.com/mohdjishin/SplitWise/internal/webhook/dispatch"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
 )
 
+// workerShutdownTimeout bounds how long Run waits for background workers to finish their current job.
+const workerShutdownTimeout = 30 * time.Second
+
 type App struct {
-	server *server.Server
+	server     *server.Server
+	grpcServer *rpc.Server // nil when grpc.port is not set
+
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
 }
 
 func New() *App {
 	port := config.GetConfig().Port
+	_ = storage.GetStorage() // fail fast when the storage backend is unreachable
+	notify.Register()
+	broker.Register()
+	dispatch.Register()
 	handler := routes.NewRouter()
 	server := server.NewServer(port, handler)
-	return &App{server: server}
+	app := &App{server: server}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	app.stopWorkers = cancel
+	app.startWorker(ctx, dispatch.New(config.GetConfig().Webhooks).Run)
+	app.startWorker(ctx, reminder.NewJob(config.GetConfig().Reminders).Run)
+	app.startWorker(ctx, reportjob.New(config.GetConfig().ReportJobs, handlers.RenderReportJob).Run)
+	app.startWorker(ctx, exportjob.New(config.GetConfig().ExportJobs, handlers.RenderExportJob).Run)
+	if grpcConfig := config.GetConfig().GRPC; grpcConfig.Port != "" {
+		grpcServer, err := rpc.NewServer(grpcConfig)
+		if err != nil {
+			log.Fatal("Failed to set up the gRPC server", zap.Error(err))
+		}
+		app.grpcServer = grpcServer
+	}
+	return app
 }
 
+// startWorker runs a background worker until ctx is cancelled.
+func (a *App) startWorker(ctx context.Context, run func(context.Context)) {
+	a.workers.Add(1)
+	go func() {
+		defer a.workers.Done()
+		run(ctx)
+	}()
+}
+
+// Run serves HTTP and, when configured, gRPC until the process is interrupted, then stops the
+// background workers.
 func (a *App) Run() error {
+	defer a.shutdownWorkers()
+	if a.grpcServer != nil {
+		go func() {
+			if err := a.grpcServer.Start(); err != nil {
+				log.Error("Error starting gRPC server", zap.Error(err))
+			}
+		}()
+		defer a.grpcServer.Shutdown()
+	}
 	return a.server.Start()
 }
+
+// shutdownWorkers cancels the background workers and waits for them to return. Jobs they leave
+// unfinished are picked up again once their lease runs out.
+func (a *App) shutdownWorkers() {
+	log.Info("Stopping background workers...")
+	a.stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		log.Info("Background workers stopped")
+	case <-time.After(workerShutdownTimeout):
+		log.Warn("Background workers still running after shutdown timeout", zap.Duration("timeout", workerShutdownTimeout))
+	}
+}
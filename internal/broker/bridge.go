@@ -0,0 +1,59 @@
+package broker
+
+import (
+	"encoding/json"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// GroupState is the part of a group that changes with members, bills and payments. It is sent with
+// every update so clients can refresh their totals without another request.
+type GroupState struct {
+	TotalAmount        float64 `json:"totalAmount"`
+	PerUserSplitAmount float64 `json:"perUserSplitAmount"`
+	PaidAmount         float64 `json:"paidAmount"`
+	Status             string  `json:"status"`
+}
+
+// Update is the payload of a streamed message.
+type Update struct {
+	Event events.Event `json:"event"`
+	Group GroupState   `json:"group"`
+}
+
+// Register publishes every group event to the broker.
+func Register() {
+	events.Subscribe(func(ev events.Event) {
+		if ev.GroupID == 0 {
+			return
+		}
+		if err := publishEvent(GetBroker(), ev); err != nil {
+			log.Error("Failed to publish group update", zap.String("type", ev.Type), zap.Uint("groupId", ev.GroupID), zap.Error(err))
+		}
+	})
+}
+
+func publishEvent(b Broker, ev events.Event) error {
+	var group models.Group
+	if err := db.GetDb().Where("id = ?", ev.GroupID).First(&group).Error; err != nil {
+		return err
+	}
+	data, err := json.Marshal(Update{
+		Event: ev,
+		Group: GroupState{
+			TotalAmount:        group.TotalAmount,
+			PerUserSplitAmount: group.PerUserSplitAmount,
+			PaidAmount:         group.PaidAmount,
+			Status:             group.Status,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = b.Publish(Message{GroupID: ev.GroupID, Type: ev.Type, Data: data})
+	return err
+}
@@ -0,0 +1,64 @@
+// Package broker fans group updates out to the clients streaming them.
+package broker
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/mohdjishin/SplitWise/config"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// Message is an update of one group. IDs increase monotonically per broker so clients can
+// resume from the last one they saw.
+type Message struct {
+	ID      uint64
+	GroupID uint
+	Type    string
+	Data    json.RawMessage
+}
+
+// Subscription receives the messages of one group until it is closed.
+type Subscription interface {
+	Messages() <-chan Message
+	Close()
+}
+
+// Broker distributes group messages to subscribers. The in-memory implementation only reaches
+// clients connected to the same instance; an implementation backed by Postgres LISTEN/NOTIFY
+// (or any other pub/sub) can replace it for deployments with several instances.
+type Broker interface {
+	Publish(msg Message) (Message, error)
+	// Subscribe starts a subscription to a group. When lastEventID is not zero, messages after it
+	// that are still buffered are returned for replay; complete is false when some of them were
+	// already dropped and the client should reload the group instead.
+	Subscribe(groupID uint, lastEventID uint64) (sub Subscription, replay []Message, complete bool, err error)
+}
+
+var (
+	instance Broker
+	once     sync.Once
+)
+
+// SetBroker replaces the broker, e.g. with one shared between instances.
+func SetBroker(b Broker) {
+	instance = b
+}
+
+// GetBroker returns the broker configured through config.json.
+func GetBroker() Broker {
+	once.Do(func() {
+		if instance != nil {
+			return
+		}
+		cfg := config.GetConfig().Events
+		switch cfg.Broker {
+		case "", "memory":
+			instance = NewMemory(cfg.ReplayBuffer)
+		default:
+			log.Fatal("Unknown events broker", zap.String("broker", cfg.Broker))
+		}
+	})
+	return instance
+}
@@ -0,0 +1,118 @@
+package broker
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReplayBuffer = 256
+	subscriberBuffer    = 64
+)
+
+// Memory is an in-process broker that keeps the latest messages of every group for replay.
+type Memory struct {
+	mu      sync.Mutex
+	firstID uint64 // messages published before this broker started have IDs below it
+	nextID  uint64
+	size    int
+	history map[uint][]Message
+	evicted map[uint]uint64 // ID of the newest message dropped from each group's buffer
+	subs    map[uint]map[*memorySub]struct{}
+}
+
+// NewMemory returns a broker keeping up to bufferSize messages per group. IDs start at the
+// current time in nanoseconds so they keep increasing across restarts.
+func NewMemory(bufferSize int) *Memory {
+	if bufferSize <= 0 {
+		bufferSize = defaultReplayBuffer
+	}
+	start := uint64(time.Now().UnixNano())
+	return &Memory{
+		firstID: start + 1,
+		nextID:  start,
+		size:    bufferSize,
+		history: map[uint][]Message{},
+		evicted: map[uint]uint64{},
+		subs:    map[uint]map[*memorySub]struct{}{},
+	}
+}
+
+func (m *Memory) Publish(msg Message) (Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	msg.ID = m.nextID
+	history := append(m.history[msg.GroupID], msg)
+	if len(history) > m.size {
+		m.evicted[msg.GroupID] = history[len(history)-m.size-1].ID
+		history = history[len(history)-m.size:]
+	}
+	m.history[msg.GroupID] = history
+
+	for sub := range m.subs[msg.GroupID] {
+		select {
+		case sub.ch <- msg:
+		default:
+			// A client that cannot keep up is dropped; it reconnects and resumes with Last-Event-ID.
+			log.Warn("Dropping slow event subscriber", zap.Uint("groupId", msg.GroupID))
+			m.remove(sub)
+		}
+	}
+	return msg, nil
+}
+
+func (m *Memory) Subscribe(groupID uint, lastEventID uint64) (Subscription, []Message, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	complete := true
+	var replay []Message
+	if lastEventID != 0 {
+		complete = lastEventID+1 >= m.firstID && lastEventID >= m.evicted[groupID]
+		for _, msg := range m.history[groupID] {
+			if msg.ID > lastEventID {
+				replay = append(replay, msg)
+			}
+		}
+	}
+
+	sub := &memorySub{broker: m, groupID: groupID, ch: make(chan Message, subscriberBuffer)}
+	if m.subs[groupID] == nil {
+		m.subs[groupID] = map[*memorySub]struct{}{}
+	}
+	m.subs[groupID][sub] = struct{}{}
+	return sub, replay, complete, nil
+}
+
+// remove must be called with m.mu held.
+func (m *Memory) remove(sub *memorySub) {
+	if _, ok := m.subs[sub.groupID][sub]; !ok {
+		return
+	}
+	delete(m.subs[sub.groupID], sub)
+	if len(m.subs[sub.groupID]) == 0 {
+		delete(m.subs, sub.groupID)
+	}
+	close(sub.ch)
+}
+
+type memorySub struct {
+	broker  *Memory
+	groupID uint
+	ch      chan Message
+}
+
+func (s *memorySub) Messages() <-chan Message {
+	return s.ch
+}
+
+func (s *memorySub) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
@@ -0,0 +1,180 @@
+package budget
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultThresholds are the alert thresholds, in percent, of a budget that does not set its own.
+var DefaultThresholds = []int{80, 100}
+
+// NormaliseThresholds sorts the thresholds and drops duplicates, falling back to the defaults when empty.
+func NormaliseThresholds(thresholds []int) []int {
+	if len(thresholds) == 0 {
+		return append([]int(nil), DefaultThresholds...)
+	}
+	sorted := append([]int(nil), thresholds...)
+	sort.Ints(sorted)
+	unique := sorted[:1]
+	for _, t := range sorted[1:] {
+		if t != unique[len(unique)-1] {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// Evaluate compares a budget with what has been spent.
+func Evaluate(budget models.Budget, spent float64) dto.BudgetStatus {
+	status := dto.BudgetStatus{
+		ID:         budget.ID,
+		Category:   budget.Category,
+		Amount:     budget.Amount,
+		Spent:      spent,
+		Remaining:  budget.Amount - spent,
+		Thresholds: budget.Thresholds,
+		Status:     dto.BudgetOK,
+	}
+	if budget.Amount > 0 {
+		status.PercentUsed = math.Round(spent/budget.Amount*10000) / 100
+	}
+	for _, t := range budget.Thresholds {
+		if status.PercentUsed >= float64(t) {
+			status.Reached = t
+		}
+	}
+	switch {
+	case spent > budget.Amount || status.Reached >= 100:
+		status.Status = dto.BudgetExceeded
+	case status.Reached > 0:
+		status.Status = dto.BudgetWarning
+	}
+	return status
+}
+
+// Statuses returns the budget statuses of each of the groups, group budget first and then by category.
+func Statuses(groupIDs []uint) (map[uint][]dto.BudgetStatus, error) {
+	statuses := make(map[uint][]dto.BudgetStatus, len(groupIDs))
+	if len(groupIDs) == 0 {
+		return statuses, nil
+	}
+	var budgets []models.Budget
+	if err := db.GetDb().Where("group_id IN ?", groupIDs).Order("category").Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return statuses, nil
+	}
+	spending, err := spent(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		statuses[budget.GroupID] = append(statuses[budget.GroupID], Evaluate(budget, spending[budget.GroupID].of(budget.Category)))
+	}
+	return statuses, nil
+}
+
+// Check evaluates the budgets of a group after its bills changed and publishes a budget alert for each
+// threshold crossed since the last check. Dropping back below a threshold re-arms its alert.
+func Check(group models.Group, actorID uint) {
+	var budgets []models.Budget
+	if err := db.GetDb().Where("group_id = ?", group.ID).Find(&budgets).Error; err != nil {
+		log.Error("Failed to fetch budgets", zap.Uint("groupId", group.ID), zap.Error(err))
+		return
+	}
+	if len(budgets) == 0 {
+		return
+	}
+	spending, err := spent([]uint{group.ID})
+	if err != nil {
+		log.Error("Failed to sum group spending", zap.Uint("groupId", group.ID), zap.Error(err))
+		return
+	}
+
+	for _, budget := range budgets {
+		status := Evaluate(budget, spending[group.ID].of(budget.Category))
+		if status.Reached == budget.LastAlerted {
+			continue
+		}
+		// Only the request that moves LastAlerted announces the crossing.
+		res := db.GetDb().Model(&models.Budget{}).
+			Where("id = ? AND last_alerted = ?", budget.ID, budget.LastAlerted).
+			Update("last_alerted", status.Reached)
+		if res.Error != nil {
+			log.Error("Failed to update budget alert state", zap.Uint("budgetId", budget.ID), zap.Error(res.Error))
+			continue
+		}
+		if res.RowsAffected == 0 {
+			continue
+		}
+		// A single bill can cross several thresholds at once, e.g. 80% and 100%.
+		for _, threshold := range budget.Thresholds {
+			if threshold <= budget.LastAlerted || threshold > status.Reached {
+				continue
+			}
+			events.Publish(events.Event{
+				Type:      events.BudgetAlert,
+				GroupID:   group.ID,
+				GroupName: group.Name,
+				ActorID:   actorID,
+				Data: map[string]any{
+					"budgetId":    budget.ID,
+					"category":    budget.Category,
+					"threshold":   threshold,
+					"amount":      budget.Amount,
+					"spent":       status.Spent,
+					"percentUsed": status.PercentUsed,
+					"status":      status.Status,
+				},
+			})
+		}
+	}
+}
+
+type groupSpending struct {
+	total      float64
+	byCategory map[string]float64
+}
+
+func (s groupSpending) of(category string) float64 {
+	if category == "" {
+		return s.total
+	}
+	return s.byCategory[category]
+}
+
+// spent sums the bills of the groups per category.
+func spent(groupIDs []uint) (map[uint]groupSpending, error) {
+	var rows []struct {
+		GroupID  uint
+		Category string
+		Amount   float64
+	}
+	err := db.GetDb().Model(&models.Bill{}).
+		Select("group_id, category, SUM(amount) AS amount").
+		Where("group_id IN ?", groupIDs).
+		Group("group_id, category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	spending := make(map[uint]groupSpending, len(groupIDs))
+	for _, row := range rows {
+		s, ok := spending[row.GroupID]
+		if !ok {
+			s.byCategory = map[string]float64{}
+		}
+		s.total += row.Amount
+		s.byCategory[row.Category] += row.Amount
+		spending[row.GroupID] = s
+	}
+	return spending, nil
+}
@@ -0,0 +1,72 @@
+package budget
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+var alerts = make(chan events.Event, 16)
+
+func init() {
+	events.Subscribe(func(e events.Event) {
+		if e.Type == events.BudgetAlert {
+			alerts <- e
+		}
+	})
+}
+
+// thresholdsAlerted collects the thresholds of the alerts published by one Check.
+func thresholdsAlerted(t *testing.T) []int {
+	t.Helper()
+	var got []int
+	for {
+		select {
+		case e := <-alerts:
+			got = append(got, e.Data["threshold"].(int))
+		case <-time.After(100 * time.Millisecond):
+			sort.Ints(got)
+			return got
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	conn := dbtest.Open(t, &models.Budget{}, &models.Bill{})
+	group := models.Group{ID: 1, Name: "Goa"}
+	budget := models.Budget{GroupID: group.ID, Amount: 1000, Thresholds: []int{50, 80, 100}}
+	if err := conn.Create(&budget).Error; err != nil {
+		t.Fatal(err)
+	}
+	bill := models.Bill{Name: "Hotel", GroupID: group.ID}
+	if err := conn.Create(&bill).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		spent float64
+		want  []int
+	}{
+		{"below", 400, nil},
+		{"several at once", 850, []int{50, 80}},
+		{"no new threshold", 900, nil},
+		{"the last one", 1200, []int{100}},
+		{"back below", 600, nil},
+		{"re-armed", 1000, []int{80, 100}},
+	}
+	for _, tt := range tests {
+		if err := conn.Model(&bill).Update("amount", tt.spent).Error; err != nil {
+			t.Fatal(err)
+		}
+		Check(group, 0)
+		if got := thresholdsAlerted(t); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: alerts for %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -39,9 +39,18 @@ func GetDbManagerInstance() DBManagerInterface {
 	return dbManagerInstance
 }
 
-func init() {
-	_ = GetDbManagerInstance()
-}
+// auditLogImmutableSQL makes Postgres reject any UPDATE, DELETE or TRUNCATE of audit_logs.
+const auditLogImmutableSQL = `
+CREATE OR REPLACE FUNCTION audit_logs_immutable() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit log entries cannot be changed';
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS audit_logs_immutable ON audit_logs;
+CREATE TRIGGER audit_logs_immutable BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_logs
+	FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_immutable();
+`
+
 func (m *DBManager) Connect() {
 	log.Info("Connecting to database")
 	var err error
@@ -51,10 +60,25 @@ func (m *DBManager) Connect() {
 	}
 	log.Info("Connected to database")
 	log.Info("Migrating database")
-	err = m.db.AutoMigrate(&models.User{}, &models.Group{}, models.BillHistory{}, &models.Bill{}, &models.GroupMember{})
+	err = m.db.AutoMigrate(&models.User{}, &models.Group{}, models.BillHistory{}, &models.Bill{}, &models.GroupMember{}, &models.ExportJob{}, &models.LoginThrottle{}, &models.LoginEvent{}, &models.AuditLog{}, &models.Comment{}, &models.Attachment{}, &models.Notification{}, &models.NotificationPreference{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.ReminderSchedule{}, &models.Reminder{}, &models.ReminderClaim{}, &models.CustomCategory{}, &models.Budget{}, &models.ReportJob{}, &models.ReportTemplate{})
 	if err != nil {
 		log.Fatal("failed to migrate database", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
 	}
+	// Bills created before categories existed
+	if err := m.db.Model(&models.Bill{}).Where("category IS NULL OR category = ''").Update("category", models.Uncategorised).Error; err != nil {
+		log.Fatal("failed to migrate bill categories", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+	}
+	// Webhook responses are no longer kept, drop the ones stored before
+	if m.db.Migrator().HasColumn(&models.WebhookDelivery{}, "response_body") {
+		if err := m.db.Migrator().DropColumn(&models.WebhookDelivery{}, "response_body"); err != nil {
+			log.Fatal("failed to drop webhook response bodies", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+		}
+	}
+	// The model hooks only see updates and deletes made through GORM models, the trigger also stops
+	// bulk, raw and out-of-band statements.
+	if err := m.db.Exec(auditLogImmutableSQL).Error; err != nil {
+		log.Fatal("failed to protect the audit log", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+	}
 	log.Info("Database migration successful")
 }
 
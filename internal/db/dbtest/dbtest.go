@@ -0,0 +1,44 @@
+// Package dbtest lets tests run against an in-memory SQLite database instead of PostgreSQL.
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type manager struct {
+	db *gorm.DB
+}
+
+func (m manager) Connect() {}
+
+func (m manager) GetDB() *gorm.DB {
+	return m.db
+}
+
+// Open makes db.GetDb return a new empty database holding the tables of models until the test ends.
+// SQLite has no row locks, so FOR UPDATE and SKIP LOCKED clauses are left out of its queries; the
+// database has a single connection, which serialises transactions instead.
+func Open(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	// Every connection to :memory: opens a database of its own
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := conn.AutoMigrate(models...); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	db.SetDbManager(manager{db: conn})
+	return conn
+}
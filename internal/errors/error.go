@@ -34,6 +34,7 @@ var (
 	ErrInvalidToken                  = &Error{Code: "INVALID_TOKEN", Message: "Invalid token"}
 	ErrInvalidAuthHeader             = &Error{Code: "INVALID_AUTH_HEADER", Message: "Invalid Authorization header format. Expected"}
 	ErrNoPendingPayments             = &Error{Code: "NO_PENDING_PAYMENTS", Message: "No pending payments found"}
+	ErrForbidden                     = &Error{Code: "FORBIDDEN", Message: "You are not allowed to perform this action"}
 )
 
 var (
@@ -45,6 +46,8 @@ var (
 	ErrUserNotFound      = &Error{Code: "USER_NOT_FOUND", Message: "The specified user could not be found"}
 	ErrUserAlreadyExists = &Error{Code: "USER_ALREADY_EXISTS", Message: "A user with this email or username already exists"}
 	ErrInvalidCredential = &Error{Code: "INVALID_CREDENTIAL", Message: "username or password incorrect"}
+	ErrTooManyAttempts   = &Error{Code: "TOO_MANY_ATTEMPTS", Message: "Too many failed login attempts, please try again later"}
+	ErrAccountLocked     = &Error{Code: "ACCOUNT_LOCKED", Message: "Login is temporarily locked after too many failed attempts"}
 )
 
 var (
@@ -56,6 +59,65 @@ var (
 	ErrWhileFetchingBill    = &Error{Code: "WHILE_FETCHING_BILL", Message: "Error while fetching bill"}
 )
 
+var (
+	ErrCommentNotFound = &Error{Code: "COMMENT_NOT_FOUND", Message: "The specified comment could not be found"}
+	ErrBillNotFound    = &Error{Code: "BILL_NOT_FOUND", Message: "The specified bill could not be found"}
+)
+
+var (
+	ErrAttachmentNotFound  = &Error{Code: "ATTACHMENT_NOT_FOUND", Message: "The specified attachment could not be found"}
+	ErrAttachmentTooLarge  = &Error{Code: "ATTACHMENT_TOO_LARGE", Message: "The uploaded file is too large"}
+	ErrUnsupportedFileType = &Error{Code: "UNSUPPORTED_FILE_TYPE", Message: "Only JPEG, PNG, WEBP, GIF and PDF files are accepted"}
+	ErrInvalidSignedURL    = &Error{Code: "INVALID_SIGNED_URL", Message: "The download link is invalid"}
+	ErrSignedURLExpired    = &Error{Code: "SIGNED_URL_EXPIRED", Message: "The download link has expired"}
+)
+
+var (
+	ErrExportNotFound = &Error{Code: "EXPORT_NOT_FOUND", Message: "The specified export could not be found"}
+	ErrExportNotReady = &Error{Code: "EXPORT_NOT_READY", Message: "The export is still being generated"}
+	ErrExportFailed   = &Error{Code: "EXPORT_FAILED", Message: "The export could not be generated"}
+	ErrExportExpired  = &Error{Code: "EXPORT_EXPIRED", Message: "The export is no longer available, please request it again"}
+)
+
+var (
+	ErrNotificationNotFound = &Error{Code: "NOTIFICATION_NOT_FOUND", Message: "The specified notification could not be found"}
+)
+
+var (
+	ErrWebhookNotFound         = &Error{Code: "WEBHOOK_NOT_FOUND", Message: "The specified webhook could not be found"}
+	ErrWebhookDeliveryNotFound = &Error{Code: "WEBHOOK_DELIVERY_NOT_FOUND", Message: "The specified webhook delivery could not be found"}
+	ErrWebhookURLNotAllowed    = &Error{Code: "WEBHOOK_URL_NOT_ALLOWED", Message: "Webhook URLs must use http or https and point to a public address"}
+)
+
+var (
+	ErrCategoryNotFound = &Error{Code: "CATEGORY_NOT_FOUND", Message: "The specified category could not be found"}
+	ErrCategoryExists   = &Error{Code: "CATEGORY_EXISTS", Message: "A category with this name already exists"}
+)
+
+var (
+	ErrBudgetNotFound = &Error{Code: "BUDGET_NOT_FOUND", Message: "The specified budget could not be found"}
+)
+
+var (
+	ErrReportJobNotFound = &Error{Code: "REPORT_JOB_NOT_FOUND", Message: "The specified report job could not be found"}
+	ErrReportNotReady    = &Error{Code: "REPORT_NOT_READY", Message: "The report is still being generated"}
+	ErrReportFailed      = &Error{Code: "REPORT_FAILED", Message: "The report could not be generated"}
+	ErrReportCancelled   = &Error{Code: "REPORT_CANCELLED", Message: "The report job was cancelled"}
+	ErrReportExpired     = &Error{Code: "REPORT_EXPIRED", Message: "The report is no longer available, please request it again"}
+	ErrReportJobFinished = &Error{Code: "REPORT_JOB_FINISHED", Message: "The report job has already finished"}
+	ErrTooManyReportJobs = &Error{Code: "TOO_MANY_REPORT_JOBS", Message: "Too many reports are being generated, please wait for them to finish"}
+)
+
+var (
+	ErrReportTemplateNotFound = &Error{Code: "REPORT_TEMPLATE_NOT_FOUND", Message: "No report template has been set"}
+)
+
+var (
+	ErrMemberNotFound  = &Error{Code: "MEMBER_NOT_FOUND", Message: "The specified user is not a member of the group"}
+	ErrNudgeCooldown   = &Error{Code: "NUDGE_COOLDOWN", Message: "This member was nudged recently, please try again later"}
+	ErrNothingToRemind = &Error{Code: "NOTHING_TO_REMIND", Message: "This member has already paid"}
+)
+
 // Validation error functions
 func ErrRequired(t any) error {
 	return &Error{Code: "VALIDATION_REQUIRED", Message: fmt.Sprintf("%s is required", reflect.TypeOf(t).Name())}
@@ -0,0 +1,103 @@
+package errors
+
+import "golang.org/x/text/language"
+
+// Languages are the languages error messages are available in, English first as the fallback.
+var Languages = []language.Tag{language.English, language.German, language.Spanish, language.French}
+
+// translations has the German, Spanish and French messages of the errors above, in that order.
+// Messages with details, such as validation failures, are only available in English.
+var translations = map[*Error][3]string{
+	ErrInternalError:                 {"Ein interner Serverfehler ist aufgetreten", "Se ha producido un error interno del servidor", "Une erreur interne du serveur s'est produite"},
+	ErrInvalidInput:                  {"Die Eingabedaten sind ungültig", "Los datos de entrada no son válidos", "Les données saisies ne sont pas valides"},
+	ErrBadRequest:                    {"Ungültige Anfrage", "Solicitud incorrecta", "Requête invalide"},
+	ErrInternalServerError:           {"Interner Serverfehler", "Error interno del servidor", "Erreur interne du serveur"},
+	ErrUnauthorizationHeaderNotFound: {"Authorization-Header erforderlich", "Se requiere la cabecera Authorization", "L'en-tête Authorization est requis"},
+	ErrInvalidToken:                  {"Ungültiges Token", "Token no válido", "Jeton invalide"},
+	ErrInvalidAuthHeader:             {"Ungültiges Format des Authorization-Headers, erwartet wird Bearer <Token>", "Formato de la cabecera Authorization no válido, se espera Bearer <token>", "Format de l'en-tête Authorization invalide, Bearer <jeton> attendu"},
+	ErrNoPendingPayments:             {"Keine offenen Zahlungen gefunden", "No se encontraron pagos pendientes", "Aucun paiement en attente trouvé"},
+	ErrForbidden:                     {"Sie dürfen diese Aktion nicht ausführen", "No tiene permiso para realizar esta acción", "Vous n'êtes pas autorisé à effectuer cette action"},
+
+	ErrGroupNotFound: {"Die angegebene Gruppe wurde nicht gefunden", "No se encontró el grupo indicado", "Le groupe indiqué est introuvable"},
+
+	ErrUserNotFound:      {"Der angegebene Benutzer wurde nicht gefunden", "No se encontró el usuario indicado", "L'utilisateur indiqué est introuvable"},
+	ErrUserAlreadyExists: {"Ein Benutzer mit dieser E-Mail-Adresse oder diesem Benutzernamen existiert bereits", "Ya existe un usuario con este correo electrónico o nombre de usuario", "Un utilisateur avec cette adresse e-mail ou ce nom existe déjà"},
+	ErrInvalidCredential: {"Benutzername oder Passwort falsch", "Usuario o contraseña incorrectos", "Nom d'utilisateur ou mot de passe incorrect"},
+	ErrTooManyAttempts:   {"Zu viele fehlgeschlagene Anmeldeversuche, bitte versuchen Sie es später erneut", "Demasiados intentos de inicio de sesión fallidos, inténtelo de nuevo más tarde", "Trop de tentatives de connexion échouées, veuillez réessayer plus tard"},
+	ErrAccountLocked:     {"Die Anmeldung ist nach zu vielen Fehlversuchen vorübergehend gesperrt", "El inicio de sesión está bloqueado temporalmente tras demasiados intentos fallidos", "La connexion est temporairement bloquée après trop de tentatives échouées"},
+
+	ErrPaymentAlreadyMade:   {"Die Zahlung wurde von diesem Benutzer bereits geleistet", "Este usuario ya ha realizado el pago", "Le paiement a déjà été effectué par cet utilisateur"},
+	ErrPaymentFailed:        {"Der Zahlungsstatus konnte nicht aktualisiert werden", "No se pudo actualizar el estado del pago", "Impossible de mettre à jour l'état du paiement"},
+	ErrBillCompletionFailed: {"Die Rechnung konnte nicht als abgeschlossen markiert werden", "No se pudo marcar la factura como completada", "Impossible de marquer la facture comme réglée"},
+	ErrGroupUpdateFailed:    {"Die Gruppeninformationen konnten nicht aktualisiert werden", "No se pudo actualizar la información del grupo", "Impossible de mettre à jour les informations du groupe"},
+	ErrWhileFetchingMembers: {"Fehler beim Laden der Gruppenmitglieder", "Error al obtener los miembros del grupo", "Erreur lors de la récupération des membres du groupe"},
+	ErrWhileFetchingBill:    {"Fehler beim Laden der Rechnung", "Error al obtener la factura", "Erreur lors de la récupération de la facture"},
+
+	ErrCommentNotFound: {"Der angegebene Kommentar wurde nicht gefunden", "No se encontró el comentario indicado", "Le commentaire indiqué est introuvable"},
+	ErrBillNotFound:    {"Die angegebene Rechnung wurde nicht gefunden", "No se encontró la factura indicada", "La facture indiquée est introuvable"},
+
+	ErrAttachmentNotFound:  {"Der angegebene Anhang wurde nicht gefunden", "No se encontró el archivo adjunto indicado", "La pièce jointe indiquée est introuvable"},
+	ErrAttachmentTooLarge:  {"Die hochgeladene Datei ist zu groß", "El archivo subido es demasiado grande", "Le fichier envoyé est trop volumineux"},
+	ErrUnsupportedFileType: {"Es werden nur JPEG-, PNG-, WEBP-, GIF- und PDF-Dateien akzeptiert", "Solo se aceptan archivos JPEG, PNG, WEBP, GIF y PDF", "Seuls les fichiers JPEG, PNG, WEBP, GIF et PDF sont acceptés"},
+	ErrInvalidSignedURL:    {"Der Download-Link ist ungültig", "El enlace de descarga no es válido", "Le lien de téléchargement est invalide"},
+	ErrSignedURLExpired:    {"Der Download-Link ist abgelaufen", "El enlace de descarga ha caducado", "Le lien de téléchargement a expiré"},
+
+	ErrExportNotFound: {"Der angegebene Export wurde nicht gefunden", "No se encontró la exportación indicada", "L'export indiqué est introuvable"},
+	ErrExportNotReady: {"Der Export wird noch erstellt", "La exportación todavía se está generando", "L'export est encore en cours de génération"},
+	ErrExportFailed:   {"Der Export konnte nicht erstellt werden", "No se pudo generar la exportación", "L'export n'a pas pu être généré"},
+	ErrExportExpired:  {"Der Export ist nicht mehr verfügbar, bitte fordern Sie ihn erneut an", "La exportación ya no está disponible, vuelva a solicitarla", "L'export n'est plus disponible, veuillez le demander à nouveau"},
+
+	ErrNotificationNotFound: {"Die angegebene Benachrichtigung wurde nicht gefunden", "No se encontró la notificación indicada", "La notification indiquée est introuvable"},
+
+	ErrWebhookNotFound:         {"Der angegebene Webhook wurde nicht gefunden", "No se encontró el webhook indicado", "Le webhook indiqué est introuvable"},
+	ErrWebhookDeliveryNotFound: {"Die angegebene Webhook-Zustellung wurde nicht gefunden", "No se encontró la entrega de webhook indicada", "La livraison de webhook indiquée est introuvable"},
+	ErrWebhookURLNotAllowed:    {"Webhook-URLs müssen http oder https verwenden und auf eine öffentliche Adresse zeigen", "Las URL de webhook deben usar http o https y apuntar a una dirección pública", "Les URL de webhook doivent utiliser http ou https et pointer vers une adresse publique"},
+
+	ErrCategoryNotFound: {"Die angegebene Kategorie wurde nicht gefunden", "No se encontró la categoría indicada", "La catégorie indiquée est introuvable"},
+	ErrCategoryExists:   {"Eine Kategorie mit diesem Namen existiert bereits", "Ya existe una categoría con este nombre", "Une catégorie portant ce nom existe déjà"},
+
+	ErrBudgetNotFound: {"Das angegebene Budget wurde nicht gefunden", "No se encontró el presupuesto indicado", "Le budget indiqué est introuvable"},
+
+	ErrReportJobNotFound: {"Der angegebene Berichtsauftrag wurde nicht gefunden", "No se encontró el informe solicitado", "La demande de rapport indiquée est introuvable"},
+	ErrReportNotReady:    {"Der Bericht wird noch erstellt", "El informe todavía se está generando", "Le rapport est encore en cours de génération"},
+	ErrReportFailed:      {"Der Bericht konnte nicht erstellt werden", "No se pudo generar el informe", "Le rapport n'a pas pu être généré"},
+	ErrReportCancelled:   {"Der Berichtsauftrag wurde abgebrochen", "La generación del informe se ha cancelado", "La demande de rapport a été annulée"},
+	ErrReportExpired:     {"Der Bericht ist nicht mehr verfügbar, bitte fordern Sie ihn erneut an", "El informe ya no está disponible, vuelva a solicitarlo", "Le rapport n'est plus disponible, veuillez le demander à nouveau"},
+	ErrReportJobFinished: {"Der Berichtsauftrag ist bereits abgeschlossen", "La generación del informe ya ha terminado", "La demande de rapport est déjà terminée"},
+	ErrTooManyReportJobs: {"Es werden zu viele Berichte erstellt, bitte warten Sie, bis sie fertig sind", "Se están generando demasiados informes, espere a que terminen", "Trop de rapports sont en cours de génération, veuillez attendre qu'ils soient terminés"},
+
+	ErrReportTemplateNotFound: {"Es wurde keine Berichtsvorlage festgelegt", "No se ha definido ninguna plantilla de informe", "Aucun modèle de rapport n'a été défini"},
+
+	ErrMemberNotFound:  {"Der angegebene Benutzer ist kein Mitglied der Gruppe", "El usuario indicado no es miembro del grupo", "L'utilisateur indiqué n'est pas membre du groupe"},
+	ErrNudgeCooldown:   {"Dieses Mitglied wurde kürzlich erinnert, bitte versuchen Sie es später erneut", "Ya se recordó a este miembro hace poco, inténtelo de nuevo más tarde", "Ce membre a été relancé récemment, veuillez réessayer plus tard"},
+	ErrNothingToRemind: {"Dieses Mitglied hat bereits bezahlt", "Este miembro ya ha pagado", "Ce membre a déjà payé"},
+}
+
+// byMessage indexes the translations by English message.
+var byMessage = func() map[string][3]string {
+	index := make(map[string][3]string, len(translations))
+	for err, messages := range translations {
+		index[err.Message] = messages
+	}
+	return index
+}()
+
+// Translate returns the message in the language, or the message itself when the language is English
+// or the message has no translation.
+func Translate(message string, lang language.Tag) string {
+	var i int
+	switch lang {
+	case language.German:
+		i = 0
+	case language.Spanish:
+		i = 1
+	case language.French:
+		i = 2
+	default:
+		return message
+	}
+	if messages, ok := byMessage[message]; ok {
+		return messages[i]
+	}
+	return message
+}
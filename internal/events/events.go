@@ -0,0 +1,101 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// Event types
+const (
+	MemberAdded    = "member.added"
+	BillCreated    = "bill.created"
+	BillUpdated    = "bill.updated"
+	PaymentMarked  = "payment.marked"
+	GroupCompleted = "group.completed"
+	ReminderDue    = "reminder.due"
+	BudgetAlert    = "budget.threshold"
+)
+
+// Types lists every event type, e.g. for preference screens and validation.
+var Types = []string{MemberAdded, BillCreated, BillUpdated, PaymentMarked, GroupCompleted, ReminderDue, BudgetAlert}
+
+// Event is a domain event raised after a state change has been committed.
+type Event struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	OccurredAt time.Time      `json:"occurredAt"`
+	GroupID    uint           `json:"groupId,omitempty"`
+	GroupName  string         `json:"groupName,omitempty"`
+	ActorID    uint           `json:"actorId,omitempty"` // user who caused the event, 0 for the system
+	UserIDs    []uint         `json:"userIds,omitempty"` // users the event is about, e.g. the added members
+	Data       map[string]any `json:"data,omitempty"`
+}
+
+// Handler consumes events. Handlers run on their own goroutine and must not block for long.
+type Handler func(Event)
+
+// Bus fans events out to subscribers within the process.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish fills in the event id and time when missing and hands the event to every subscriber.
+func (b *Bus) Publish(e Event) {
+	if e.ID == "" {
+		e.ID = NewID()
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+	log.Debug("Publishing event", zap.String("type", e.Type), zap.String("id", e.ID), zap.Uint("groupId", e.GroupID))
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, h := range b.handlers {
+		go dispatch(h, e)
+	}
+}
+
+func dispatch(h Handler, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Event handler panicked", zap.String("type", e.Type), zap.Any("panic", r))
+		}
+	}()
+	h(e)
+}
+
+// NewID returns a random event id.
+func NewID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+var defaultBus = NewBus()
+
+// Subscribe registers a handler on the default bus.
+func Subscribe(h Handler) {
+	defaultBus.Subscribe(h)
+}
+
+// Publish publishes an event on the default bus.
+func Publish(e Event) {
+	defaultBus.Publish(e)
+}
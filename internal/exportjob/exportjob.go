@@ -0,0 +1,56 @@
+// Package exportjob builds personal data exports in the background, one at a time per instance.
+package exportjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/jobqueue"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+// Generator builds the archive of a job and returns its file name. It must give up when ctx is done.
+type Generator func(ctx context.Context, job models.ExportJob) (fileName string, data []byte, err error)
+
+var queue = jobqueue.New[models.ExportJob]("export")
+
+// Wake makes the worker of this instance look at the queue now instead of at its next poll.
+func Wake() { queue.Wake() }
+
+// Worker builds export jobs one at a time.
+type Worker struct {
+	cfg      config.ExportJobs
+	generate Generator
+}
+
+func New(cfg config.ExportJobs, generate Generator) *Worker {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 10 * time.Second
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = 10 * time.Minute
+	}
+	if cfg.ResultTTL <= 0 {
+		cfg.ResultTTL = 24 * time.Hour
+	}
+	return &Worker{cfg: cfg, generate: generate}
+}
+
+// Run builds queued exports and expires old archives until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	queue.Run(ctx, jobqueue.Options{
+		Workers:      1,
+		PollInterval: w.cfg.PollInterval,
+		JobTimeout:   w.cfg.JobTimeout,
+		ResultTTL:    w.cfg.ResultTTL,
+	}, w.handle)
+}
+
+func (w *Worker) handle(ctx context.Context, job models.ExportJob) (map[string]any, error) {
+	fileName, data, err := w.generate(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"file_name": fileName, "data": data}, nil
+}
@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/ast"
+)
+
+// complexity estimates the cost of an operation: one per field, with the selections under a list
+// counted once per item asked for with first, or maxPageSize for a list without it. Introspection
+// fields are answered from memory and count once. The query must have passed validation.
+func complexity(schema *ast.Schema, doc *document, op *operation, vars map[string]interface{}) int {
+	c := coster{schema: schema, doc: doc, vars: vars, defaults: op.defaults}
+	return c.selections(op.selections, schema.RootOperationTypes[op.kind])
+}
+
+type coster struct {
+	schema   *ast.Schema
+	doc      *document
+	vars     map[string]interface{}
+	defaults map[string]interface{}
+}
+
+func (c *coster) selections(set []selection, parent ast.NamedType) int {
+	total := 0
+	for _, s := range set {
+		switch {
+		case s.spread != "":
+			if f := c.doc.fragments[s.spread]; f != nil {
+				total += c.selections(f.selections, c.schema.Types[f.on])
+			}
+		case s.field == "":
+			t := parent
+			if s.on != "" {
+				t = c.schema.Types[s.on]
+			}
+			total += c.selections(s.selections, t)
+		default:
+			total++
+			if strings.HasPrefix(s.field, "__") || len(s.selections) == 0 {
+				continue
+			}
+			def := fieldsOf(parent).Get(s.field)
+			if def == nil {
+				continue
+			}
+			t, list := unwrap(def.Type)
+			child := c.selections(s.selections, t)
+			if list {
+				child *= c.expectedItems(s, def)
+			}
+			total += child
+		}
+	}
+	return total
+}
+
+// expectedItems is the first argument of a list field, or maxPageSize when it has none.
+func (c *coster) expectedItems(s selection, def *ast.FieldDefinition) int {
+	arg := def.Arguments.Get("first")
+	if arg == nil {
+		return maxPageSize
+	}
+	v := s.first
+	if ref, ok := v.(variable); ok {
+		var set bool
+		if v, set = c.vars[string(ref)]; !set {
+			v = c.defaults[string(ref)]
+		}
+	}
+	if v == nil && arg.Default != nil {
+		v = arg.Default.Deserialize(nil)
+	}
+	switch n := v.(type) {
+	case int32:
+		return max(int(n), 1)
+	case int64:
+		return max(int(n), 1)
+	case float64:
+		return max(int(n), 1)
+	}
+	return maxPageSize
+}
+
+func fieldsOf(t ast.NamedType) ast.FieldsDefinition {
+	switch t := t.(type) {
+	case *ast.ObjectTypeDefinition:
+		return t.Fields
+	case *ast.InterfaceTypeDefinition:
+		return t.Fields
+	}
+	return nil
+}
+
+// unwrap returns the named type under the non-null and list wrappers of t, and whether it is a list.
+func unwrap(t ast.Type) (named ast.NamedType, list bool) {
+	for {
+		switch u := t.(type) {
+		case *ast.NonNull:
+			t = u.OfType
+		case *ast.List:
+			list = true
+			t = u.OfType
+		case ast.NamedType:
+			return u, list
+		default:
+			return nil, list
+		}
+	}
+}
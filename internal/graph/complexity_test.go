@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+func TestComplexity(t *testing.T) {
+	schema := graphql.MustParseSchema(schemaSDL, nil, graphql.UseStringDescriptions())
+
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		vars      map[string]interface{}
+		want      int
+	}{
+		{"scalars", `{ me { id name } }`, "", nil, 3},
+		{"default first", `{ ownedGroups { id } }`, "", nil, 1 + 20},
+		{"literal first", `{ ownedGroups(first: 5, status: DONE) { id name } }`, "", nil, 1 + 5*2},
+		{"variable first", `query Q($n: Int) { ownedGroups(first: $n) { id } }`, "", map[string]interface{}{"n": float64(3)}, 1 + 3},
+		{"variable default", `query Q($n: Int = 4) { ownedGroups(first: $n) { id } }`, "", nil, 1 + 4},
+		{"nested lists", `{ group(id: "1") { bills(first: 2) { payments(first: 3) { id } } } }`, "", nil, 1 + 1 + 2*(1+3)},
+		{"members default", `{ group(id: "1") { members { isOwner } } }`, "", nil, 1 + 1 + 20},
+		{"alias", `{ a: me { id } b: me { id } }`, "", nil, 4},
+		{"fragments", `
+			query { memberGroups(first: 2) { ...g ... on Group { name } ... @include(if: true) { id } } }
+			fragment g on Group { status }`, "", nil, 1 + 2*3},
+		{"introspection", `{ __schema { types { name fields { name } } } }`, "", nil, 1},
+		{"strings and comments", `
+			# "{ ignored }"
+			mutation { createGroup(name: "a } \" {", bill: {name: "b", amount: 1}) { id } }`, "", nil, 2},
+		{"named operation", `query A { me { id } } query B { ownedGroups(first: 1) { id } }`, "B", nil, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := schema.ValidateWithVariables(tt.query, tt.vars); len(errs) > 0 {
+				t.Fatalf("invalid query: %v", errs)
+			}
+			doc := parseQuery(tt.query)
+			op, err := doc.operation(tt.operation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := complexity(schema.ASTSchema(), doc, op, tt.vars); got != tt.want {
+				t.Errorf("complexity = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperation(t *testing.T) {
+	doc := parseQuery(`query A { me { id } } mutation B { setEmailVisibility(visibility: PRIVATE) { id } }`)
+	if op, err := doc.operation("B"); err != nil || op.kind != "mutation" {
+		t.Errorf("operation(B) = %v, %v, want the mutation", op, err)
+	}
+	if _, err := doc.operation(""); err == nil {
+		t.Error("operation without a name of two operations succeeded")
+	}
+	if _, err := doc.operation("C"); err == nil {
+		t.Error("operation of an unknown name succeeded")
+	}
+}
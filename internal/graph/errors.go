@@ -0,0 +1,55 @@
+package graph
+
+import (
+	e "errors"
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// resolverError is an API error returned by a resolver. Its code and the HTTP status the REST API
+// would answer with are added to the extensions of the GraphQL error.
+type resolverError struct {
+	status int
+	err    error
+}
+
+func (r *resolverError) Error() string {
+	var apiErr *errors.Error
+	if e.As(r.err, &apiErr) {
+		return apiErr.Message
+	}
+	return r.err.Error()
+}
+
+func (r *resolverError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"status": r.status}
+	var apiErr *errors.Error
+	if e.As(r.err, &apiErr) {
+		ext["code"] = apiErr.Code
+	}
+	return ext
+}
+
+func failure(status int, err error) error {
+	return &resolverError{status: status, err: err}
+}
+
+// wrap turns an error of a ledger operation into a resolver error.
+func wrap(err error) error {
+	var f *ledger.Error
+	if e.As(err, &f) {
+		return failure(f.Status, f.Err)
+	}
+	log.Error("GraphQL operation failed", zap.Error(err))
+	return failure(http.StatusInternalServerError, errors.ErrInternalError)
+}
+
+// internalError logs a failed lookup and hides it from the client.
+func internalError(message string, err error) error {
+	log.Error(message, zap.Error(err))
+	return failure(http.StatusInternalServerError, errors.ErrInternalError)
+}
@@ -0,0 +1,121 @@
+// Package graph serves the GraphQL API over users, groups, bills, members, payments and balances.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Defaults for the limits of config.GraphQL.
+const (
+	defaultMaxComplexity = 1000
+	defaultMaxDepth      = 8
+	maxRequestBytes      = 1 << 20
+)
+
+type contextKey int
+
+const loadersKey contextKey = 0
+
+// request is a GraphQL request, sent as JSON in a POST body or as query parameters of a GET.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type handler struct {
+	schema        *graphql.Schema
+	maxComplexity int
+}
+
+// Handler serves the GraphQL endpoint. It must run behind middleware.AuthMiddleware.
+func Handler() http.Handler {
+	cfg := config.GetConfig().GraphQL
+	maxComplexity, maxDepth := cfg.MaxComplexity, cfg.MaxDepth
+	if maxComplexity <= 0 {
+		maxComplexity = defaultMaxComplexity
+	}
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	return &handler{
+		schema:        graphql.MustParseSchema(schemaSDL, &resolver{}, graphql.UseStringDescriptions(), graphql.MaxDepth(maxDepth)),
+		maxComplexity: maxComplexity,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+			return
+		}
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(errors.ErrInvalidQueryParameter("variables must be a JSON object"))
+				return
+			}
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	op, errs := h.check(req)
+	if len(errs) > 0 {
+		_ = json.NewEncoder(w).Encode(&graphql.Response{Errors: errs})
+		return
+	}
+	if r.Method == http.MethodGet && op == "mutation" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(&graphql.Response{Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("mutations must be sent with POST")}})
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), loadersKey, newLoaders(uint(middleware.GetCurrentUserId(r))))
+	_ = json.NewEncoder(w).Encode(h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// check validates the request and rejects operations more complex than allowed, returning the
+// type of the operation to run.
+func (h *handler) check(req request) (string, []*gqlerrors.QueryError) {
+	if errs := h.schema.ValidateWithVariables(req.Query, req.Variables); len(errs) > 0 {
+		return "", errs
+	}
+	doc := parseQuery(req.Query)
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return "", []*gqlerrors.QueryError{err}
+	}
+	if cost := complexity(h.schema.ASTSchema(), doc, op, req.Variables); cost > h.maxComplexity {
+		err := gqlerrors.Errorf("query complexity %d exceeds the limit of %d, ask for fewer fields or smaller pages", cost, h.maxComplexity)
+		err.Extensions = map[string]interface{}{"code": "QUERY_TOO_COMPLEX", "complexity": cost, "limit": h.maxComplexity}
+		return "", []*gqlerrors.QueryError{err}
+	}
+	return op.kind, nil
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey).(*loaders)
+}
@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"sync"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"gorm.io/gorm"
+)
+
+// batch loads values by key with one query for all the keys wanted so far. Resolvers of a list want the
+// keys of every item up front, so the first item to load fetches them all instead of one query per item.
+type batch[K comparable, V any] struct {
+	mu     sync.Mutex
+	wanted map[K]struct{}
+	loaded map[K]V
+	fetch  func(keys []K) (map[K]V, error)
+}
+
+func newBatch[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *batch[K, V] {
+	return &batch[K, V]{wanted: map[K]struct{}{}, loaded: map[K]V{}, fetch: fetch}
+}
+
+// want queues keys for the next fetch.
+func (b *batch[K, V]) want(keys ...K) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := b.loaded[key]; !ok {
+			b.wanted[key] = struct{}{}
+		}
+	}
+}
+
+// load returns the value of the key, fetching it with every wanted key when it is not loaded yet.
+// Keys without a value get the zero value.
+func (b *batch[K, V]) load(key K) (V, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if v, ok := b.loaded[key]; ok {
+		return v, nil
+	}
+	b.wanted[key] = struct{}{}
+	keys := make([]K, 0, len(b.wanted))
+	for k := range b.wanted {
+		keys = append(keys, k)
+	}
+	b.wanted = map[K]struct{}{}
+
+	values, err := b.fetch(keys)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	for _, k := range keys {
+		b.loaded[k] = values[k]
+	}
+	return b.loaded[key], nil
+}
+
+// loaders batch the lookups of one request.
+type loaders struct {
+	viewer  uint
+	users   *batch[uint, models.User]
+	groups  *batch[uint, models.Group]
+	members *batch[uint, []models.GroupMember] // by group
+	bills   *batch[uint, []models.Bill]        // by group, with their payments
+}
+
+func newLoaders(viewer uint) *loaders {
+	l := &loaders{viewer: viewer}
+	l.users = newBatch(func(ids []uint) (map[uint]models.User, error) {
+		var users []models.User
+		if err := db.GetDb().Select("id", "name", "email", "email_visibility").Where("id IN ?", ids).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		byID := make(map[uint]models.User, len(users))
+		for _, u := range users {
+			byID[u.ID] = u
+		}
+		return byID, nil
+	})
+	l.groups = newBatch(func(ids []uint) (map[uint]models.Group, error) {
+		var groups []models.Group
+		if err := db.GetDb().Where("id IN ?", ids).Find(&groups).Error; err != nil {
+			return nil, err
+		}
+		byID := make(map[uint]models.Group, len(groups))
+		for _, g := range groups {
+			byID[g.ID] = g
+		}
+		l.prime(groups)
+		return byID, nil
+	})
+	l.members = newBatch(func(groupIDs []uint) (map[uint][]models.GroupMember, error) {
+		var members []models.GroupMember
+		if err := db.GetDb().Where("group_id IN ?", groupIDs).Order("id").Find(&members).Error; err != nil {
+			return nil, err
+		}
+		byGroup := make(map[uint][]models.GroupMember, len(groupIDs))
+		for _, m := range members {
+			byGroup[m.GroupID] = append(byGroup[m.GroupID], m)
+			l.users.want(m.UserID)
+		}
+		return byGroup, nil
+	})
+	l.bills = newBatch(func(groupIDs []uint) (map[uint][]models.Bill, error) {
+		var bills []models.Bill
+		if err := db.GetDb().Preload("History", func(db *gorm.DB) *gorm.DB { return db.Order("paid_at") }).
+			Where("group_id IN ?", groupIDs).Order("id").Find(&bills).Error; err != nil {
+			return nil, err
+		}
+		byGroup := make(map[uint][]models.Bill, len(groupIDs))
+		for _, b := range bills {
+			byGroup[b.GroupID] = append(byGroup[b.GroupID], b)
+			for _, h := range b.History {
+				l.users.want(h.PaidByID)
+			}
+		}
+		return byGroup, nil
+	})
+	return l
+}
+
+// prime wants the owners, members and bills of groups about to be resolved.
+func (l *loaders) prime(groups []models.Group) {
+	for _, g := range groups {
+		l.users.want(g.CreatedBy)
+		l.members.want(g.ID)
+		l.bills.want(g.ID)
+	}
+}
@@ -0,0 +1,268 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// document is what costing needs of a query: its operations and fragments, with fields reduced to
+// their name, their first argument and their selections.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query, mutation or subscription
+	name       string
+	defaults   map[string]interface{} // default values of the variables
+	selections []selection
+}
+
+type fragment struct {
+	on         string
+	selections []selection
+}
+
+// selection is a field, an inline fragment (no field or spread) or a fragment spread.
+type selection struct {
+	field      string
+	first      interface{} // int64, a variable, or nil when not given
+	on         string
+	spread     string
+	selections []selection
+}
+
+type variable string
+
+// operation returns the operation to run, the named one or the only one of the document.
+func (d *document) operation(name string) (*operation, *gqlerrors.QueryError) {
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	if name == "" && len(d.operations) > 1 {
+		return nil, gqlerrors.Errorf("operationName is required when the document has more than one operation")
+	}
+	if name != "" || len(d.operations) == 0 {
+		return nil, gqlerrors.Errorf("no operation named %q", name)
+	}
+	return d.operations[0], nil
+}
+
+// parseQuery reads a query that passed validation, so it skips what costing does not need and
+// does not report syntax errors.
+func parseQuery(src string) *document {
+	p := parser{lexer: lexer{src: src}}
+	p.next()
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.tok != "" {
+		switch p.tok {
+		case "{":
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: p.selectionSet()})
+		case "fragment":
+			p.next()
+			name := p.name()
+			p.next() // on
+			f := &fragment{on: p.name()}
+			p.directives()
+			f.selections = p.selectionSet()
+			doc.fragments[name] = f
+		default:
+			op := &operation{kind: p.name(), defaults: map[string]interface{}{}}
+			if p.tok != "(" && p.tok != "@" && p.tok != "{" {
+				op.name = p.name()
+			}
+			if p.accept("(") {
+				for !p.accept(")") && p.tok != "" {
+					p.next() // $
+					name := p.name()
+					p.next() // :
+					p.typeRef()
+					if p.accept("=") {
+						op.defaults[name] = p.value()
+					}
+					p.directives()
+				}
+			}
+			p.directives()
+			op.selections = p.selectionSet()
+			doc.operations = append(doc.operations, op)
+		}
+	}
+	return doc
+}
+
+type parser struct {
+	lexer
+}
+
+// name returns the current token and moves past it.
+func (p *parser) name() string {
+	tok := p.tok
+	p.next()
+	return tok
+}
+
+// accept moves past the current token when it is tok.
+func (p *parser) accept(tok string) bool {
+	if p.tok != tok {
+		return false
+	}
+	p.next()
+	return true
+}
+
+func (p *parser) selectionSet() []selection {
+	var set []selection
+	if !p.accept("{") {
+		return nil
+	}
+	for !p.accept("}") && p.tok != "" {
+		var s selection
+		switch {
+		case p.accept("..."):
+			if p.tok == "on" {
+				p.next()
+				s.on = p.name()
+			} else if p.tok != "{" && p.tok != "@" {
+				s.spread = p.name()
+			}
+			p.directives()
+			s.selections = p.selectionSet()
+		default:
+			s.field = p.name()
+			if p.accept(":") {
+				s.field = p.name()
+			}
+			if p.accept("(") {
+				for !p.accept(")") && p.tok != "" {
+					name := p.name()
+					p.next() // :
+					if v := p.value(); name == "first" {
+						s.first = v
+					}
+				}
+			}
+			p.directives()
+			s.selections = p.selectionSet()
+		}
+		set = append(set, s)
+	}
+	return set
+}
+
+// value reads a value, returning integers and variables and skipping the rest.
+func (p *parser) value() interface{} {
+	switch tok := p.tok; {
+	case p.accept("$"):
+		return variable(p.name())
+	case p.accept("["):
+		for !p.accept("]") && p.tok != "" {
+			p.value()
+		}
+	case p.accept("{"):
+		for !p.accept("}") && p.tok != "" {
+			p.next() // name
+			p.next() // :
+			p.value()
+		}
+	default:
+		p.next()
+		if n, err := strconv.ParseInt(tok, 10, 64); err == nil {
+			return n
+		}
+	}
+	return nil
+}
+
+func (p *parser) typeRef() {
+	if p.accept("[") {
+		p.typeRef()
+		p.next() // ]
+	} else {
+		p.next()
+	}
+	p.accept("!")
+}
+
+func (p *parser) directives() {
+	for p.accept("@") {
+		p.next()
+		if p.accept("(") {
+			for !p.accept(")") && p.tok != "" {
+				p.next() // name
+				p.next() // :
+				p.value()
+			}
+		}
+	}
+}
+
+// lexer splits a query into tokens: names, numbers, strings with their quotes, "..." and single
+// punctuators. tok is empty at the end of the query.
+type lexer struct {
+	src string
+	pos int
+	tok string
+}
+
+func (l *lexer) next() {
+	l.skipIgnored()
+	start := l.pos
+	if l.pos >= len(l.src) {
+		l.tok = ""
+		return
+	}
+	switch c := l.src[l.pos]; {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+	case c == '"':
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != '"' {
+			if l.src[l.pos] == '\\' {
+				l.pos++
+			}
+			l.pos++
+		}
+		l.pos = min(l.pos+1, len(l.src))
+	case isNameChar(c) || c == '-':
+		number := !isLetter(c)
+		l.pos++
+		for l.pos < len(l.src) && (isNameChar(l.src[l.pos]) || number && strings.IndexByte(".+-", l.src[l.pos]) >= 0) {
+			l.pos++
+		}
+	default:
+		l.pos++
+	}
+	l.tok = l.src[start:l.pos]
+}
+
+// skipIgnored moves past white space, commas, comments and byte order marks.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return isLetter(c) || c >= '0' && c <= '9'
+}
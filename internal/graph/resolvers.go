@@ -0,0 +1,216 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"gorm.io/gorm"
+)
+
+const maxPageSize = 100
+
+// resolver is the root of the schema.
+type resolver struct{}
+
+func (*resolver) Me(ctx context.Context) (*userResolver, error) {
+	l := loadersFrom(ctx)
+	user, err := l.users.load(l.viewer)
+	if err != nil {
+		return nil, internalError("Failed to fetch user", err)
+	}
+	if user.ID == 0 {
+		return nil, failure(http.StatusNotFound, errors.ErrUserNotFound)
+	}
+	return &userResolver{user: user, viewer: l.viewer}, nil
+}
+
+func (*resolver) Group(ctx context.Context, args struct{ ID graphql.ID }) (*groupResolver, error) {
+	l := loadersFrom(ctx)
+	groupID, err := parseID(args.ID)
+	if err != nil {
+		return nil, nil
+	}
+	var groups []models.Group
+	if err := memberOf(l.viewer).Where("groups.id = ?", groupID).Find(&groups).Error; err != nil {
+		return nil, internalError("Failed to fetch group", err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	return newGroups(l, groups)[0], nil
+}
+
+type groupsArgs struct {
+	First  int32
+	After  *graphql.ID
+	Status *string
+}
+
+func (*resolver) OwnedGroups(ctx context.Context, args groupsArgs) ([]*groupResolver, error) {
+	return listGroups(loadersFrom(ctx), true, args)
+}
+
+func (*resolver) MemberGroups(ctx context.Context, args groupsArgs) ([]*groupResolver, error) {
+	return listGroups(loadersFrom(ctx), false, args)
+}
+
+func (*resolver) PendingPayments(ctx context.Context, args struct{ First int32 }) ([]*pendingPaymentResolver, error) {
+	l := loadersFrom(ctx)
+	if err := checkPageSize(args.First); err != nil {
+		return nil, err
+	}
+	var members []models.GroupMember
+	if err := db.GetDb().Joins("JOIN groups ON groups.id = group_members.group_id AND groups.deleted_at IS NULL").
+		Where("group_members.user_id = ? AND group_members.has_paid = ?", l.viewer, false).
+		Order("groups.created_at DESC, group_members.id DESC").Limit(int(args.First)).Find(&members).Error; err != nil {
+		return nil, internalError("Failed to fetch pending payments", err)
+	}
+	resolvers := make([]*pendingPaymentResolver, len(members))
+	for i, m := range members {
+		l.groups.want(m.GroupID)
+		resolvers[i] = &pendingPaymentResolver{groupID: m.GroupID, amount: m.SplitAmount, l: l}
+	}
+	return resolvers, nil
+}
+
+func (*resolver) Balance(ctx context.Context) (*balanceResolver, error) {
+	l := loadersFrom(ctx)
+	var b balanceResolver
+	unpaid := db.GetDb().Model(&models.GroupMember{}).
+		Joins("JOIN groups ON groups.id = group_members.group_id AND groups.deleted_at IS NULL").
+		Where("group_members.has_paid = ?", false).
+		Select("COALESCE(SUM(group_members.split_amount), 0)").
+		Session(&gorm.Session{})
+	if err := unpaid.Where("group_members.user_id = ?", l.viewer).Scan(&b.owed).Error; err != nil {
+		return nil, internalError("Failed to total balance", err)
+	}
+	if err := unpaid.Where("groups.created_by = ? AND group_members.user_id <> ?", l.viewer, l.viewer).Scan(&b.owedToYou).Error; err != nil {
+		return nil, internalError("Failed to total balance", err)
+	}
+	return &b, nil
+}
+
+type createGroupArgs struct {
+	Name string
+	Bill struct {
+		Name     string
+		Amount   float64
+		Category *string
+	}
+}
+
+func (*resolver) CreateGroup(ctx context.Context, args createGroupArgs) (*groupResolver, error) {
+	l := loadersFrom(ctx)
+	input := dto.CreateGroupWithBillRequest{GroupName: args.Name}
+	input.Bill.Name = args.Bill.Name
+	input.Bill.Amount = args.Bill.Amount
+	if args.Bill.Category != nil {
+		input.Bill.Category = *args.Bill.Category
+	}
+	group, _, err := ledger.CreateGroup(ctx, l.viewer, input)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	return newGroups(l, []models.Group{group})[0], nil
+}
+
+func (*resolver) AddMembers(ctx context.Context, args struct {
+	GroupID graphql.ID
+	Emails  []string
+}) (*groupResolver, error) {
+	l := loadersFrom(ctx)
+	groupID, err := parseID(args.GroupID)
+	if err != nil {
+		return nil, failure(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+	group, err := ledger.AddMembers(ctx, l.viewer, groupID, args.Emails)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	return newGroups(l, []models.Group{group})[0], nil
+}
+
+func (*resolver) MarkPayment(ctx context.Context, args struct {
+	GroupID graphql.ID
+	Remarks *string
+}) (*memberResolver, error) {
+	l := loadersFrom(ctx)
+	groupID, err := parseID(args.GroupID)
+	if err != nil {
+		return nil, failure(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+	var remarks string
+	if args.Remarks != nil {
+		remarks = *args.Remarks
+	}
+	member, err := ledger.MarkPayment(ctx, l.viewer, groupID, remarks)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	var group models.Group
+	if err := db.GetDb().Select("id", "created_by").Where("id = ?", groupID).First(&group).Error; err != nil {
+		return nil, internalError("Failed to fetch group", err)
+	}
+	return &memberResolver{member: member, owner: group.CreatedBy, l: l}, nil
+}
+
+func (*resolver) SetEmailVisibility(ctx context.Context, args struct{ Visibility string }) (*userResolver, error) {
+	l := loadersFrom(ctx)
+	visibility := models.EmailPrivate
+	if args.Visibility == "MEMBERS" {
+		visibility = models.EmailMembers
+	}
+	user, err := ledger.SetEmailVisibility(ctx, l.viewer, visibility)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	return &userResolver{user: user, viewer: l.viewer}, nil
+}
+
+// memberOf selects the groups the user is a member of, the same rule the REST API applies.
+func memberOf(userId uint) *gorm.DB {
+	return db.GetDb().
+		Joins("JOIN group_members ON groups.id = group_members.group_id AND group_members.deleted_at IS NULL").
+		Where("group_members.user_id = ?", userId)
+}
+
+func listGroups(l *loaders, owned bool, args groupsArgs) ([]*groupResolver, error) {
+	if err := checkPageSize(args.First); err != nil {
+		return nil, err
+	}
+	filter := ledger.GroupFilter{Owned: owned, Limit: int(args.First)}
+	if args.After != nil {
+		after, err := parseID(*args.After)
+		if err != nil {
+			return nil, failure(http.StatusBadRequest, errors.ErrInvalidQueryParameter("after must be a group id"))
+		}
+		filter.After = after
+	}
+	if args.Status != nil {
+		filter.Status = *args.Status
+	}
+	groups, err := ledger.Groups(l.viewer, filter)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	return newGroups(l, groups), nil
+}
+
+func checkPageSize(first int32) error {
+	if first < 1 || first > maxPageSize {
+		return failure(http.StatusBadRequest, errors.ErrInvalidQueryParameter("first must be between 1 and "+strconv.Itoa(maxPageSize)))
+	}
+	return nil
+}
+
+func parseID(v graphql.ID) (uint, error) {
+	n, err := strconv.ParseUint(string(v), 10, 64)
+	return uint(n), err
+}
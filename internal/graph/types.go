@@ -0,0 +1,227 @@
+package graph
+
+import (
+	"strconv"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+func id(v uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(v), 10))
+}
+
+type userResolver struct {
+	user   models.User
+	viewer uint
+}
+
+func (r *userResolver) ID() graphql.ID { return id(r.user.ID) }
+
+func (r *userResolver) Name() string { return r.user.Name }
+
+func (r *userResolver) Email() *string {
+	if !r.user.EmailVisibleTo(r.viewer) {
+		return nil
+	}
+	return &r.user.Email
+}
+
+type groupResolver struct {
+	group models.Group
+	l     *loaders
+}
+
+// newGroups resolves a list of groups, batching the lookups of their owners, members and bills.
+func newGroups(l *loaders, groups []models.Group) []*groupResolver {
+	l.prime(groups)
+	resolvers := make([]*groupResolver, len(groups))
+	for i, g := range groups {
+		resolvers[i] = &groupResolver{group: g, l: l}
+	}
+	return resolvers
+}
+
+func (r *groupResolver) ID() graphql.ID { return id(r.group.ID) }
+
+func (r *groupResolver) Name() string { return r.group.Name }
+
+func (r *groupResolver) Status() string { return r.group.Status }
+
+func (r *groupResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.group.CreatedAt} }
+
+func (r *groupResolver) TotalAmount() float64 { return r.group.TotalAmount }
+
+func (r *groupResolver) PerUserSplitAmount() float64 { return r.group.PerUserSplitAmount }
+
+func (r *groupResolver) PaidAmount() float64 { return r.group.PaidAmount }
+
+func (r *groupResolver) Owner() (*userResolver, error) {
+	user, err := r.l.users.load(r.group.CreatedBy)
+	if err != nil {
+		return nil, internalError("Failed to fetch group owner", err)
+	}
+	return &userResolver{user: user, viewer: r.l.viewer}, nil
+}
+
+func (r *groupResolver) Bills(args struct{ First int32 }) ([]*billResolver, error) {
+	if err := checkPageSize(args.First); err != nil {
+		return nil, err
+	}
+	bills, err := r.l.bills.load(r.group.ID)
+	if err != nil {
+		return nil, internalError("Failed to fetch bills", err)
+	}
+	bills = bills[:min(len(bills), int(args.First))]
+	resolvers := make([]*billResolver, len(bills))
+	for i, b := range bills {
+		resolvers[i] = &billResolver{bill: b, l: r.l}
+	}
+	return resolvers, nil
+}
+
+func (r *groupResolver) Members(args struct{ First int32 }) ([]*memberResolver, error) {
+	if err := checkPageSize(args.First); err != nil {
+		return nil, err
+	}
+	members, err := r.l.members.load(r.group.ID)
+	if err != nil {
+		return nil, internalError("Failed to fetch group members", err)
+	}
+	members = members[:min(len(members), int(args.First))]
+	resolvers := make([]*memberResolver, len(members))
+	for i, m := range members {
+		resolvers[i] = &memberResolver{member: m, owner: r.group.CreatedBy, l: r.l}
+	}
+	return resolvers, nil
+}
+
+func (r *groupResolver) Balance() (*balanceResolver, error) {
+	members, err := r.l.members.load(r.group.ID)
+	if err != nil {
+		return nil, internalError("Failed to fetch group members", err)
+	}
+	var b balanceResolver
+	for _, m := range members {
+		switch {
+		case m.HasPaid:
+		case m.UserID == r.l.viewer:
+			b.owed += m.SplitAmount
+		case r.group.CreatedBy == r.l.viewer:
+			b.owedToYou += m.SplitAmount
+		}
+	}
+	return &b, nil
+}
+
+type billResolver struct {
+	bill models.Bill
+	l    *loaders
+}
+
+func (r *billResolver) ID() graphql.ID { return id(r.bill.ID) }
+
+func (r *billResolver) Name() string { return r.bill.Name }
+
+func (r *billResolver) Category() string { return r.bill.Category }
+
+func (r *billResolver) Amount() float64 { return r.bill.Amount }
+
+func (r *billResolver) Completed() bool { return r.bill.Completed }
+
+func (r *billResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.bill.CreatedAt} }
+
+func (r *billResolver) Payments(args struct{ First int32 }) ([]*paymentResolver, error) {
+	if err := checkPageSize(args.First); err != nil {
+		return nil, err
+	}
+	history := r.bill.History[:min(len(r.bill.History), int(args.First))]
+	resolvers := make([]*paymentResolver, len(history))
+	for i, h := range history {
+		resolvers[i] = &paymentResolver{payment: h, l: r.l}
+	}
+	return resolvers, nil
+}
+
+type paymentResolver struct {
+	payment models.BillHistory
+	l       *loaders
+}
+
+func (r *paymentResolver) ID() graphql.ID { return id(r.payment.ID) }
+
+func (r *paymentResolver) Amount() float64 { return r.payment.Amount }
+
+func (r *paymentResolver) PaidAt() graphql.Time { return graphql.Time{Time: r.payment.PaidAt} }
+
+func (r *paymentResolver) PaidBy() (*userResolver, error) {
+	user, err := r.l.users.load(r.payment.PaidByID)
+	if err != nil {
+		return nil, internalError("Failed to fetch payer", err)
+	}
+	if user.ID == 0 {
+		// Payments made before payer ids were recorded only have the name.
+		user.Name = r.payment.PaidBy
+	}
+	return &userResolver{user: user, viewer: r.l.viewer}, nil
+}
+
+type memberResolver struct {
+	member models.GroupMember
+	owner  uint
+	l      *loaders
+}
+
+func (r *memberResolver) User() (*userResolver, error) {
+	user, err := r.l.users.load(r.member.UserID)
+	if err != nil {
+		return nil, internalError("Failed to fetch member", err)
+	}
+	return &userResolver{user: user, viewer: r.l.viewer}, nil
+}
+
+func (r *memberResolver) IsOwner() bool { return r.member.UserID == r.owner }
+
+func (r *memberResolver) SplitAmount() float64 { return r.member.SplitAmount }
+
+func (r *memberResolver) HasPaid() bool { return r.member.HasPaid }
+
+func (r *memberResolver) PaidAt() *graphql.Time {
+	if r.member.PaidAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.member.PaidAt}
+}
+
+func (r *memberResolver) Remarks() *string {
+	if r.member.Remarks == "" {
+		return nil
+	}
+	return &r.member.Remarks
+}
+
+type pendingPaymentResolver struct {
+	groupID uint
+	amount  float64
+	l       *loaders
+}
+
+func (r *pendingPaymentResolver) Group() (*groupResolver, error) {
+	group, err := r.l.groups.load(r.groupID)
+	if err != nil {
+		return nil, internalError("Failed to fetch group", err)
+	}
+	return &groupResolver{group: group, l: r.l}, nil
+}
+
+func (r *pendingPaymentResolver) Amount() float64 { return r.amount }
+
+type balanceResolver struct {
+	owed, owedToYou float64
+}
+
+func (r *balanceResolver) Owed() float64 { return r.owed }
+
+func (r *balanceResolver) OwedToYou() float64 { return r.owedToYou }
+
+func (r *balanceResolver) Net() float64 { return r.owedToYou - r.owed }
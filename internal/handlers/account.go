@@ -3,14 +3,20 @@ package handlers
 import (
 	"encoding/json"
 	e "errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/mohdjishin/SplitWise/helper"
+	"github.com/mohdjishin/SplitWise/helper/audit"
 	jUtil "github.com/mohdjishin/SplitWise/helper/jwt"
 	"github.com/mohdjishin/SplitWise/helper/validate"
 	"github.com/mohdjishin/SplitWise/internal/db"
 	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
 	"github.com/mohdjishin/SplitWise/internal/models"
 	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"github.com/mohdjishin/SplitWise/internal/throttle"
 	log "github.com/mohdjishin/SplitWise/logger"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -24,7 +30,7 @@ import (
 // @Accept  json
 // @Produce  json
 // @Param user body dto.RegisterRequest true "User details"
-// @Success 200 {object} map[string]string "User registered"
+// @Success 200 {object} dto.RegisterResponse "User registered"
 // @Failure 400 {object} errors.Error" Bad Request"
 // @Failure 409 {object} errors.Error "Conflict"
 // @Router /auth/register [post]
@@ -37,7 +43,7 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		_ = json.NewEncoder(w).Encode((errors.ErrBadRequest))
 		return
 	}
-	log.Debug("Register request", zap.Any("request", input))
+	log.Debug("Register request", zap.String("email", input.Email))
 	if err := validate.ValidateStruct(input); err != nil {
 		log.Error("Error validating request body", zap.Any("error", err))
 		w.WriteHeader(http.StatusBadRequest)
@@ -61,13 +67,19 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	log.Info("User registered", zap.String("email", user.Email))
-	// TODO: Creating a Response Model.
-	_ = json.NewEncoder(w).Encode(map[string]string{"message": "User registered"})
+	audit.Record(r.Context(), user.ID, audit.Entry{
+		Action:     audit.ActionAccountCreated,
+		EntityType: audit.EntityAccount,
+		EntityID:   user.ID,
+		After:      map[string]any{"id": user.ID, "email": user.Email, "name": user.Name},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.RegisterResponse{Message: "User registered", User: userResponse(user)})
 }
 
 // Login handles user login
 // @Summary Login a user
-// @Description Logs in a user with email and password.
+// @Description Logs in a user with email and password. Repeated failures per account and per IP are throttled with exponential backoff and a temporary lockout.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -75,6 +87,7 @@ func Register(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} map[string]string "User logged in successfully, returns token"
 // @Failure 400 {object} map[string]string "Bad Request"
 // @Failure 401 {object} map[string]string "Unauthorized - Invalid credentials"
+// @Failure 429 {object} errors.Error "Too many failed attempts, see the Retry-After header"
 // @Failure 500 {object} map[string]string "Internal Server Error"
 // @Router /auth/login [post]
 func Login(w http.ResponseWriter, r *http.Request) {
@@ -85,13 +98,29 @@ func Login(w http.ResponseWriter, r *http.Request) {
 		_ = json.NewEncoder(w).Encode((errors.ErrBadRequest))
 		return
 	}
-	log.Debug("Login request", zap.Any("request", input))
+	log.Debug("Login request", zap.String("email", input.Email))
 	if err := validate.ValidateStruct(input); err != nil {
 		log.Error("Error validating request body", zap.Any("error", err))
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
 		return
 	}
+
+	guard := throttle.GetGuard()
+	ip := helper.ClientIP(r)
+	attempt, err := guard.Begin(r.Context(), input.Email, ip)
+	if err != nil {
+		log.Error("Error checking login throttle", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalServerError)
+		return
+	}
+	if !attempt.Allowed {
+		log.Warn("Login throttled", zap.String("ip", ip), zap.Duration("retryAfter", attempt.RetryAfter))
+		writeThrottled(w, attempt.Decision)
+		return
+	}
+
 	var user models.User
 	if err := db.GetDb().Where("email = ?", input.Email).First(&user).Error; err != nil {
 		log.Error("Error fetching user", zap.Any("error", err))
@@ -102,6 +131,11 @@ func Login(w http.ResponseWriter, r *http.Request) {
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
 		log.Error("Error comparing password", zap.Any("error", err))
+		reason := "invalid password"
+		if attempt.LocksOut {
+			reason = "invalid password, account locked"
+		}
+		recordLoginEvent(r, user.ID, false, reason)
 		w.WriteHeader(http.StatusUnauthorized)
 		_ = json.NewEncoder(w).Encode(errors.ErrInvalidCredential)
 		return
@@ -113,6 +147,105 @@ func Login(w http.ResponseWriter, r *http.Request) {
 		log.Error("Error generating token", zap.Any("error", err))
 		w.WriteHeader(http.StatusInternalServerError)
 		_ = json.NewEncoder(w).Encode(errors.ErrInternalServerError)
+		return
+	}
+	if err := guard.Succeed(r.Context(), attempt); err != nil {
+		log.Error("Error resetting login throttle", zap.Error(err))
 	}
+	recordLoginEvent(r, user.ID, true, "")
 	_ = json.NewEncoder(w).Encode(map[string]string{"token": token})
 }
+
+// ListLoginEvents lists recent login attempts on the current user's account
+// @Summary List recent login attempts
+// @Description Returns the most recent successful and failed login attempts for the current user, newest first.
+// @Tags me
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Attempts on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Attempts on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Success 200 {object} dto.LoginEventListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/logins [get]
+func ListLoginEvents(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+
+	lq, ok := listRequest(w, r, loginEventListSpec)
+	if !ok {
+		return
+	}
+	query, err := lq.page(db.GetDb().Where("user_id = ?", userId))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+
+	var events []models.LoginEvent
+	if err := query.Find(&events).Error; err != nil {
+		log.Error("Failed to fetch login events", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	resp := dto.LoginEventListResponse{Events: []dto.LoginEventResponse{}}
+	events, resp.Pagination = pageOf(lq, events, func(event models.LoginEvent) sortValue {
+		return sortValue{time: event.CreatedAt, id: event.ID}
+	})
+	for _, event := range events {
+		resp.Events = append(resp.Events, dto.LoginEventResponse{
+			ID:        event.ID,
+			CreatedAt: event.CreatedAt,
+			IP:        event.IP,
+			UserAgent: event.UserAgent,
+			Success:   event.Success,
+			Reason:    event.Reason,
+		})
+	}
+
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// loginEventListSpec is how login attempts are sorted and filtered.
+var loginEventListSpec = listSpec{
+	id:           "id",
+	sorts:        map[string]sortKey{"createdAt": {"created_at", sortTime}},
+	defaultSort:  "-createdAt",
+	created:      "created_at",
+	defaultLimit: 50,
+	maxLimit:     200,
+}
+
+func writeThrottled(w http.ResponseWriter, decision throttle.Decision) {
+	seconds := int(math.Ceil(decision.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+	if decision.Locked {
+		_ = json.NewEncoder(w).Encode(errors.ErrAccountLocked)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(errors.ErrTooManyAttempts)
+}
+
+func recordLoginEvent(r *http.Request, userID uint, success bool, reason string) {
+	event := models.LoginEvent{
+		UserID:    userID,
+		IP:        helper.ClientIP(r),
+		UserAgent: r.UserAgent(),
+		Success:   success,
+		Reason:    reason,
+	}
+	if err := db.GetDb().Create(&event).Error; err != nil {
+		log.Error("Failed to record login event", zap.Error(err))
+	}
+}
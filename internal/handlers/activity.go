@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// Activity entry types
+const (
+	activityGroupCreated   = "group.created"
+	activityMemberJoined   = "member.joined"
+	activityBillCreated    = "bill.created"
+	activityBillUpdated    = "bill.updated"
+	activityBillCompleted  = "bill.completed"
+	activityPaymentMarked  = "payment.marked"
+	activityGroupCompleted = "group.completed"
+)
+
+// GetGroupActivity returns the activity feed of a group
+// @Summary Get the activity feed of a group
+// @Description Returns a chronological feed of everything that happened in the group: creation, members joining, bills created or edited, payments with remarks and status changes. Entries carry the names of the people involved. Only members can see it.
+// @Tags groups
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param sort query string false "createdAt (oldest first, the default) or -createdAt (newest first)"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Success 200 {object} dto.ActivityFeedResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/activity [get]
+func GetGroupActivity(w http.ResponseWriter, r *http.Request) {
+	member, ok := requireGroupMember(w, chi.URLParam(r, "id"), middleware.GetCurrentUserId(r))
+	if !ok {
+		return
+	}
+	lq, ok := listRequest(w, r, activityListSpec)
+	if !ok {
+		return
+	}
+
+	entries, err := groupActivity(member.GroupID)
+	if err != nil {
+		log.Error("Failed to build activity feed", zap.Uint("groupId", member.GroupID), zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	// Entries come from several tables, so their place in the feed stands in for an id.
+	positions := make([]int, len(entries))
+	for i := range positions {
+		positions[i] = i
+	}
+	entryKey := func(i int) sortValue {
+		return sortValue{time: entries[i].OccurredAt, id: uint(i) + 1}
+	}
+	positions, err = pageSlice(lq, positions, entryKey)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+
+	resp := dto.ActivityFeedResponse{Entries: []dto.ActivityEntry{}, Total: len(entries)}
+	positions, resp.Pagination = pageOf(lq, positions, entryKey)
+	for _, i := range positions {
+		resp.Entries = append(resp.Entries, entries[i])
+	}
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// activityListSpec is how the activity feed is sorted. The feed is built in memory, so it has no
+// filters.
+var activityListSpec = listSpec{
+	sorts:        map[string]sortKey{"createdAt": {kind: sortTime}},
+	defaultSort:  "createdAt",
+	defaultLimit: 50,
+	maxLimit:     200,
+}
+
+// groupActivity assembles the feed from the group, its members, bills, payment history and the
+// audit log of bill edits, oldest first.
+func groupActivity(groupID uint) ([]dto.ActivityEntry, error) {
+	var group models.Group
+	if err := db.GetDb().Where("id = ?", groupID).First(&group).Error; err != nil {
+		return nil, err
+	}
+	var members []models.GroupMember
+	if err := db.GetDb().Where("group_id = ?", groupID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	var bills []models.Bill
+	if err := db.GetDb().Where("group_id = ?", groupID).Find(&bills).Error; err != nil {
+		return nil, err
+	}
+	billIDs := make([]uint, len(bills))
+	billNames := make(map[uint]string, len(bills))
+	for i, bill := range bills {
+		billIDs[i] = bill.ID
+		billNames[bill.ID] = bill.Name
+	}
+	var payments []models.BillHistory
+	if len(billIDs) > 0 {
+		if err := db.GetDb().Where("bill_id IN ?", billIDs).Order("created_at").Find(&payments).Error; err != nil {
+			return nil, err
+		}
+	}
+	var billEdits []models.AuditLog
+	if err := db.GetDb().Where("group_id = ? AND action = ?", groupID, audit.ActionBillUpdated).Find(&billEdits).Error; err != nil {
+		return nil, err
+	}
+
+	userIDs := []uint{group.CreatedBy}
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+	}
+	for _, edit := range billEdits {
+		userIDs = append(userIDs, edit.ActorID)
+	}
+	names, err := userNames(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	owner := names[group.CreatedBy]
+
+	entries := []dto.ActivityEntry{{
+		Type:        activityGroupCreated,
+		OccurredAt:  group.CreatedAt,
+		ActorID:     group.CreatedBy,
+		ActorName:   owner,
+		Description: fmt.Sprintf("%s created the group %s", owner, group.Name),
+	}}
+
+	remarks := make(map[uint]string, len(members))
+	for _, m := range members {
+		remarks[m.UserID] = m.Remarks
+		if m.UserID == group.CreatedBy {
+			continue // joined by creating the group
+		}
+		entries = append(entries, dto.ActivityEntry{
+			Type:        activityMemberJoined,
+			OccurredAt:  m.CreatedAt,
+			ActorID:     group.CreatedBy,
+			ActorName:   owner,
+			MemberID:    m.UserID,
+			MemberName:  names[m.UserID],
+			Description: fmt.Sprintf("%s added %s", owner, names[m.UserID]),
+		})
+	}
+
+	for _, bill := range bills {
+		entries = append(entries, dto.ActivityEntry{
+			Type:        activityBillCreated,
+			OccurredAt:  bill.CreatedAt,
+			ActorID:     group.CreatedBy,
+			ActorName:   owner,
+			BillID:      bill.ID,
+			BillName:    bill.Name,
+			Amount:      bill.Amount,
+			Description: fmt.Sprintf("%s added the bill %s of %.2f", owner, bill.Name, bill.Amount),
+		})
+	}
+
+	for _, edit := range billEdits {
+		entries = append(entries, billEditEntry(edit, names[edit.ActorID], billNames[edit.EntityID]))
+	}
+
+	for _, payment := range payments {
+		name := names[payment.PaidByID]
+		if name == "" {
+			name = payment.PaidBy
+		}
+		entry := dto.ActivityEntry{
+			Type:        activityPaymentMarked,
+			OccurredAt:  payment.CreatedAt,
+			ActorID:     payment.PaidByID,
+			ActorName:   name,
+			BillID:      payment.BillID,
+			BillName:    billNames[payment.BillID],
+			Amount:      payment.Amount,
+			Remarks:     remarks[payment.PaidByID],
+			Description: fmt.Sprintf("%s paid %.2f", name, payment.Amount),
+		}
+		if entry.Remarks != "" {
+			entry.Description += fmt.Sprintf(" (%s)", entry.Remarks)
+		}
+		entries = append(entries, entry)
+	}
+
+	if group.Status == done {
+		entry := dto.ActivityEntry{
+			Type:        activityGroupCompleted,
+			OccurredAt:  group.UpdatedAt,
+			Description: fmt.Sprintf("%s is settled", group.Name),
+		}
+		if n := len(payments); n > 0 {
+			entry.OccurredAt = payments[n-1].CreatedAt
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OccurredAt.Before(entries[j].OccurredAt)
+	})
+	return entries, nil
+}
+
+func billEditEntry(edit models.AuditLog, actor, billName string) dto.ActivityEntry {
+	var before, after models.Bill
+	_ = json.Unmarshal(edit.Before, &before)
+	_ = json.Unmarshal(edit.After, &after)
+	if after.Name != "" {
+		billName = after.Name
+	}
+
+	entry := dto.ActivityEntry{
+		Type:        activityBillUpdated,
+		OccurredAt:  edit.CreatedAt,
+		ActorID:     edit.ActorID,
+		ActorName:   actor,
+		BillID:      edit.EntityID,
+		BillName:    billName,
+		Amount:      after.Amount,
+		Description: fmt.Sprintf("%s edited the bill %s", actor, billName),
+	}
+	if after.Completed && !before.Completed {
+		entry.Type = activityBillCompleted
+		entry.Description = fmt.Sprintf("The bill %s is fully paid", billName)
+	}
+	return entry
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/signedurl"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"github.com/mohdjishin/SplitWise/internal/storage"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxUploadBytes = 10 << 20
+	defaultSignedURLTTL   = 15 * time.Minute
+)
+
+// allowedAttachmentTypes maps the sniffed content types accepted for receipts to their file extension.
+var allowedAttachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+}
+
+// UploadBillAttachment attaches a receipt to a bill
+// @Summary Attach a receipt to a bill
+// @Description Uploads a photo (JPEG, PNG, WEBP, GIF) or PDF of a receipt for a bill of the group. Any member can upload.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param billId path int true "Bill ID"
+// @Param file formData file true "Receipt file"
+// @Success 201 {object} dto.AttachmentResponse
+// @Failure 400 {object} errors.Error "Missing file"
+// @Failure 404 {object} errors.Error "Group or bill not found"
+// @Failure 413 {object} errors.Error "File too large"
+// @Failure 415 {object} errors.Error "Unsupported file type"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/bills/{billId}/attachments [post]
+func UploadBillAttachment(w http.ResponseWriter, r *http.Request) {
+	uploadAttachment(w, r, true)
+}
+
+// UploadPaymentAttachment attaches a proof of payment to the current user's payment
+// @Summary Attach a proof of payment
+// @Description Uploads a photo (JPEG, PNG, WEBP, GIF) or PDF proving the current user's payment in the group.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param file formData file true "Proof of payment"
+// @Success 201 {object} dto.AttachmentResponse
+// @Failure 400 {object} errors.Error "Missing file"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 413 {object} errors.Error "File too large"
+// @Failure 415 {object} errors.Error "Unsupported file type"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/payments/attachments [post]
+func UploadPaymentAttachment(w http.ResponseWriter, r *http.Request) {
+	uploadAttachment(w, r, false)
+}
+
+// ListAttachments lists the attachments of a group
+// @Summary List attachments of a group
+// @Description Lists receipts and payment proofs attached in the group. Only members of the group can see them.
+// @Tags attachments
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param billId query int false "Only attachments of this bill"
+// @Param paymentId query int false "Only attachments of this payment (group member id)"
+// @Success 200 {array} dto.AttachmentResponse
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/attachments [get]
+func ListAttachments(w http.ResponseWriter, r *http.Request) {
+	groupID := chi.URLParam(r, "id")
+	userId := middleware.GetCurrentUserId(r)
+	if _, ok := requireGroupMember(w, groupID, userId); !ok {
+		return
+	}
+
+	query := db.GetDb().Where("group_id = ?", groupID)
+	if v := r.URL.Query().Get("billId"); v != "" {
+		query = query.Where("bill_id = ?", v)
+	}
+	if v := r.URL.Query().Get("paymentId"); v != "" {
+		query = query.Where("payment_id = ?", v)
+	}
+
+	var attachments []models.Attachment
+	if err := query.Order("created_at").Find(&attachments).Error; err != nil {
+		log.Error("Failed to fetch attachments", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	resp := make([]dto.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		resp = append(resp, attachmentResponse(attachment))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// GetAttachmentURL returns a signed download url for an attachment
+// @Summary Get a signed download url
+// @Description Returns a signed, time-limited url to download the attachment. Only members of the group can request it.
+// @Tags attachments
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {object} dto.AttachmentURLResponse
+// @Failure 404 {object} errors.Error "Group or attachment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/attachments/{attachmentId}/url [get]
+func GetAttachmentURL(w http.ResponseWriter, r *http.Request) {
+	attachment, ok := findGroupAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	ttl := config.GetConfig().Storage.SignedURLTTL
+	if ttl <= 0 {
+		ttl = defaultSignedURLTTL
+	}
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.AttachmentURLResponse{
+		URL:       signedurl.Sign(attachmentDownloadPath(attachment.ID), expiresAt),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// DeleteAttachment deletes an attachment
+// @Summary Delete an attachment
+// @Description Deletes an attachment and its stored file. The uploader and the group owner can delete it.
+// @Tags attachments
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {object} map[string]string "Attachment deleted"
+// @Failure 403 {object} errors.Error "Not allowed"
+// @Failure 404 {object} errors.Error "Group or attachment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/attachments/{attachmentId} [delete]
+func DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	attachment, ok := findGroupAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	userId := middleware.GetCurrentUserId(r)
+	if attachment.UploadedBy != uint(userId) {
+		var group models.Group
+		if err := db.GetDb().Select("created_by").Where("id = ?", attachment.GroupID).First(&group).Error; err != nil || group.CreatedBy != uint(userId) {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(errors.ErrForbidden)
+			return
+		}
+	}
+
+	if err := db.GetDb().Delete(&attachment).Error; err != nil {
+		log.Error("Failed to delete attachment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	if err := storage.GetStorage().Delete(r.Context(), attachment.StorageKey); err != nil {
+		log.Error("Failed to delete attachment object", zap.String("key", attachment.StorageKey), zap.Error(err))
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionAttachmentDeleted, EntityType: audit.EntityAttachment, EntityID: attachment.ID, GroupID: attachment.GroupID, Before: attachment})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Attachment deleted"})
+}
+
+// DownloadAttachment serves an attachment through a signed url
+// @Summary Download an attachment
+// @Description Downloads an attachment. Authorisation comes from the signature of the url returned by the signed url endpoint, so no Authorization header is needed.
+// @Tags attachments
+// @Produce octet-stream
+// @Param attachmentId path int true "Attachment ID"
+// @Param expires query int true "Expiry as unix timestamp"
+// @Param signature query string true "Url signature"
+// @Success 200 {file} file "Attachment content"
+// @Failure 403 {object} errors.Error "Invalid or expired signature"
+// @Failure 404 {object} errors.Error "Attachment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /attachments/{attachmentId}/download [get]
+func DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID := chi.URLParam(r, "attachmentId")
+	id, err := strconv.ParseUint(attachmentID, 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrAttachmentNotFound)
+		return
+	}
+
+	if err := signedurl.Verify(attachmentDownloadPath(uint(id)), r.URL.Query()); err != nil {
+		log.Warn("Rejected attachment download", zap.String("attachmentId", attachmentID), zap.Error(err))
+		w.WriteHeader(http.StatusForbidden)
+		if e.Is(err, signedurl.ErrExpired) {
+			_ = json.NewEncoder(w).Encode(errors.ErrSignedURLExpired)
+		} else {
+			_ = json.NewEncoder(w).Encode(errors.ErrInvalidSignedURL)
+		}
+		return
+	}
+
+	var attachment models.Attachment
+	if err := db.GetDb().Where("id = ?", id).First(&attachment).Error; err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrAttachmentNotFound)
+		return
+	}
+
+	obj, err := storage.GetStorage().Get(r.Context(), attachment.StorageKey)
+	if err != nil {
+		log.Error("Failed to read attachment object", zap.String("key", attachment.StorageKey), zap.Error(err))
+		if e.Is(err, storage.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrAttachmentNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	defer obj.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, obj); err != nil {
+		log.Error("Failed to write attachment to response", zap.Error(err))
+	}
+}
+
+func uploadAttachment(w http.ResponseWriter, r *http.Request, onBill bool) {
+	groupID := chi.URLParam(r, "id")
+	userId := middleware.GetCurrentUserId(r)
+	log.Debug("UploadAttachment request", zap.String("groupId", groupID), zap.Bool("onBill", onBill))
+
+	member, ok := requireGroupMember(w, groupID, userId)
+	if !ok {
+		return
+	}
+	attachment := models.Attachment{GroupID: member.GroupID, UploadedBy: uint(userId)}
+	if onBill {
+		bill, ok := findGroupBill(w, r, groupID)
+		if !ok {
+			return
+		}
+		attachment.BillID = &bill.ID
+	} else {
+		attachment.PaymentID = &member.ID
+	}
+
+	maxBytes := config.GetConfig().Storage.MaxUploadBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxUploadBytes
+	}
+	// Leave room for the multipart envelope around the file.
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+1<<20)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if e.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_ = json.NewEncoder(w).Encode(errors.ErrAttachmentTooLarge)
+			return
+		}
+		log.Error("Invalid multipart form", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("field 'file' is required"))
+		return
+	}
+	defer file.Close()
+	if header.Size > maxBytes {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		_ = json.NewEncoder(w).Encode(errors.ErrAttachmentTooLarge)
+		return
+	}
+
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && !e.Is(err, io.ErrUnexpectedEOF) && !e.Is(err, io.EOF) {
+		log.Error("Failed to read uploaded file", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(sniff[:n]))
+	ext, allowed := allowedAttachmentTypes[contentType]
+	if !allowed {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		_ = json.NewEncoder(w).Encode(errors.ErrUnsupportedFileType)
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		log.Error("Failed to rewind uploaded file", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	key, err := attachmentKey(attachment.GroupID, ext)
+	if err != nil {
+		log.Error("Failed to generate storage key", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	if err := storage.GetStorage().Put(r.Context(), key, file, header.Size, contentType); err != nil {
+		log.Error("Failed to store attachment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	attachment.FileName = filepath.Base(header.Filename)
+	attachment.ContentType = contentType
+	attachment.Size = header.Size
+	attachment.StorageKey = key
+	if err := db.GetDb().Create(&attachment).Error; err != nil {
+		log.Error("Failed to save attachment", zap.Error(err))
+		if err := storage.GetStorage().Delete(r.Context(), key); err != nil {
+			log.Error("Failed to clean up attachment object", zap.String("key", key), zap.Error(err))
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionAttachmentCreated, EntityType: audit.EntityAttachment, EntityID: attachment.ID, GroupID: attachment.GroupID, After: attachment})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(attachmentResponse(attachment))
+}
+
+// attachmentResponse is an attachment without the key of its stored file.
+func attachmentResponse(attachment models.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          attachment.ID,
+		CreatedAt:   attachment.CreatedAt,
+		GroupID:     attachment.GroupID,
+		BillID:      attachment.BillID,
+		PaymentID:   attachment.PaymentID,
+		UploadedBy:  attachment.UploadedBy,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+}
+
+func findGroupAttachment(w http.ResponseWriter, r *http.Request) (models.Attachment, bool) {
+	groupID := chi.URLParam(r, "id")
+	var attachment models.Attachment
+	if _, ok := requireGroupMember(w, groupID, middleware.GetCurrentUserId(r)); !ok {
+		return attachment, false
+	}
+	if err := db.GetDb().Where("id = ? AND group_id = ?", chi.URLParam(r, "attachmentId"), groupID).First(&attachment).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrAttachmentNotFound)
+			return attachment, false
+		}
+		log.Error("Failed to fetch attachment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return attachment, false
+	}
+	return attachment, true
+}
+
+func attachmentKey(groupID uint, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("groups/%d/%s%s", groupID, hex.EncodeToString(b), ext), nil
+}
+
+func attachmentDownloadPath(id uint) string {
+	return fmt.Sprintf("/attachments/%d/download", id)
+}
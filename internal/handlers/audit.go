@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListGroupAuditLogs lists the audit log of a group
+// @Summary List audit log of a group
+// @Description Returns the audit log entries of a group, newest first. Only members of the group can see it.
+// @Tags audit
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param action query string false "Filter by action, e.g. payment.marked"
+// @Param entityType query string false "Filter by entity type: group, member, bill, payment"
+// @Param from query string false "Only entries on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Only entries on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; -createdAt by default"
+// @Success 200 {object} dto.AuditLogListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/audit [get]
+func ListGroupAuditLogs(w http.ResponseWriter, r *http.Request) {
+	groupID := chi.URLParam(r, "id")
+	userId := middleware.GetCurrentUserId(r)
+	log.Debug("ListGroupAuditLogs request", zap.String("groupId", groupID), zap.Float64("userId", userId))
+
+	if _, ok := requireGroupMember(w, groupID, userId); !ok {
+		return
+	}
+	writeAuditLogs(w, r, db.GetDb().Where("group_id = ?", groupID))
+}
+
+// ListAuditLogs lists the global audit log
+// @Summary List global audit log (admin only)
+// @Description Returns audit log entries across the whole system, newest first.
+// @Tags audit
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param actorId query int false "Filter by the user who performed the action"
+// @Param groupId query int false "Filter by group"
+// @Param action query string false "Filter by action, e.g. account.created"
+// @Param entityType query string false "Filter by entity type: account, group, member, bill, payment"
+// @Param from query string false "Only entries at or after this date (YYYY-MM-DD, in the timezone of the admin's preferences)"
+// @Param to query string false "Only entries before the end of this date (YYYY-MM-DD, in the timezone of the admin's preferences)"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; -createdAt by default"
+// @Success 200 {object} dto.AuditLogListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 403 {object} errors.Error "Forbidden"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/admin/audit [get]
+func ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := db.GetDb()
+	if v := q.Get("actorId"); v != "" {
+		query = query.Where("actor_id = ?", v)
+	}
+	if v := q.Get("groupId"); v != "" {
+		query = query.Where("group_id = ?", v)
+	}
+	writeAuditLogs(w, r, query)
+}
+
+// auditListSpec is how audit log entries are sorted and filtered.
+var auditListSpec = listSpec{
+	id:           "id",
+	sorts:        map[string]sortKey{"createdAt": {"created_at", sortTime}},
+	defaultSort:  "-createdAt",
+	created:      "created_at",
+	defaultLimit: 50,
+	maxLimit:     200,
+}
+
+func writeAuditLogs(w http.ResponseWriter, r *http.Request, query *gorm.DB) {
+	lq, ok := listRequest(w, r, auditListSpec)
+	if !ok {
+		return
+	}
+	if v := r.URL.Query().Get("action"); v != "" {
+		query = query.Where("action = ?", v)
+	}
+	if v := r.URL.Query().Get("entityType"); v != "" {
+		query = query.Where("entity_type = ?", v)
+	}
+
+	query = query.Model(&models.AuditLog{}).Session(&gorm.Session{})
+
+	resp := dto.AuditLogListResponse{Entries: []models.AuditLog{}}
+	if err := lq.filtered(query).Count(&resp.Total).Error; err != nil {
+		log.Error("Failed to count audit logs", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	query, err := lq.page(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	if err := query.Find(&resp.Entries).Error; err != nil {
+		log.Error("Failed to fetch audit logs", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	resp.Entries, resp.Pagination = pageOf(lq, resp.Entries, func(entry models.AuditLog) sortValue {
+		return sortValue{time: entry.CreatedAt, id: entry.ID}
+	})
+
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
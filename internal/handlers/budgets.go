@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	e "errors"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/budget"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListBudgets returns the budgets of a group with what has been spent against them
+// @Summary List the budgets of a group
+// @Description Returns the group budget and the category budgets of the group, each compared with the bill totals.
+// @Tags budgets
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Success 200 {array} dto.BudgetStatus
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/budgets [get]
+func ListBudgets(w http.ResponseWriter, r *http.Request) {
+	member, ok := requireGroupMember(w, chi.URLParam(r, "id"), middleware.GetCurrentUserId(r))
+	if !ok {
+		return
+	}
+	statuses, err := budget.Statuses([]uint{member.GroupID})
+	if err != nil {
+		log.Error("Failed to fetch budgets", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(orEmpty(statuses[member.GroupID]))
+}
+
+// SetBudget creates or replaces a budget of a group
+// @Summary Set a group or category budget
+// @Description Sets the budget of the whole group, or of one category when a category is given, replacing any previous budget for it. Members are alerted when spending crosses one of the thresholds (80% and 100% by default). Only the group owner can set budgets.
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param request body dto.SetBudgetRequest true "Budget"
+// @Success 200 {object} dto.BudgetStatus
+// @Failure 400 {object} errors.Error "Invalid amount, thresholds or category"
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/budgets [put]
+func SetBudget(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+
+	var input dto.SetBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	if err := validate.ValidateStruct(input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+	if input.Amount <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("amount must be greater than zero"))
+		return
+	}
+	for _, t := range input.Thresholds {
+		if t < 1 || t > 1000 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("thresholds must be percentages between 1 and 1000"))
+			return
+		}
+	}
+
+	category := ""
+	if ledger.NormaliseCategory(input.Category) != "" {
+		var err error
+		if category, err = ledger.ResolveCategory(group.CreatedBy, input.Category); err != nil {
+			writeLedgerError(w, ledger.CategoryError(err))
+			return
+		}
+	}
+
+	var b models.Budget
+	err := db.GetDb().Where("group_id = ? AND category = ?", group.ID, category).First(&b).Error
+	if err != nil && !e.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to fetch budget", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	before := b
+	b.GroupID, b.Category, b.Amount = group.ID, category, input.Amount
+	b.Thresholds = budget.NormaliseThresholds(input.Thresholds)
+	if b.ID == 0 {
+		b.CreatedBy = uint(userId)
+	}
+	if err := db.GetDb().Save(&b).Error; err != nil {
+		log.Error("Failed to save budget", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	entry := audit.Entry{Action: audit.ActionBudgetSet, EntityType: audit.EntityBudget, EntityID: b.ID, GroupID: group.ID, After: b}
+	if before.ID != 0 {
+		entry.Before = before
+	}
+	audit.Record(r.Context(), uint(userId), entry)
+
+	// A new or lowered budget may already be over a threshold.
+	budget.Check(group, uint(userId))
+
+	statuses, err := budget.Statuses([]uint{group.ID})
+	if err != nil {
+		log.Error("Failed to fetch budgets", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	for _, status := range statuses[group.ID] {
+		if status.ID == b.ID {
+			_ = json.NewEncoder(w).Encode(status)
+			return
+		}
+	}
+	_ = json.NewEncoder(w).Encode(budget.Evaluate(b, 0))
+}
+
+// DeleteBudget removes a budget of a group
+// @Summary Delete a budget
+// @Description Removes a group or category budget. Only the group owner can delete budgets.
+// @Tags budgets
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param budgetId path int true "Budget ID"
+// @Success 200 {object} map[string]string "Budget deleted"
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group or budget not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/budgets/{budgetId} [delete]
+func DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+	var b models.Budget
+	if err := db.GetDb().Where("id = ? AND group_id = ?", chi.URLParam(r, "budgetId"), group.ID).First(&b).Error; err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrBudgetNotFound)
+		return
+	}
+	if err := db.GetDb().Delete(&b).Error; err != nil {
+		log.Error("Failed to delete budget", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionBudgetDeleted, EntityType: audit.EntityBudget, EntityID: b.ID, GroupID: group.ID, Before: b})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Budget deleted"})
+}
+
+func orEmpty(statuses []dto.BudgetStatus) []dto.BudgetStatus {
+	if statuses == nil {
+		return []dto.BudgetStatus{}
+	}
+	return statuses
+}
@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListCategories lists the bill categories available to the user
+// @Summary List bill categories
+// @Description Returns the predefined categories and the custom categories created by the user.
+// @Tags categories
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dto.CategoryListResponse
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/categories [get]
+func ListCategories(w http.ResponseWriter, r *http.Request) {
+	resp := dto.CategoryListResponse{Predefined: models.PredefinedCategories, Custom: []models.CustomCategory{}}
+	if err := db.GetDb().Where("user_id = ?", middleware.GetCurrentUserId(r)).Order("name").Find(&resp.Custom).Error; err != nil {
+		log.Error("Failed to fetch custom categories", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// CreateCategory creates a custom bill category
+// @Summary Create a custom category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dto.CreateCategoryRequest true "Category"
+// @Success 201 {object} models.CustomCategory
+// @Failure 400 {object} errors.Error "Invalid name"
+// @Failure 409 {object} errors.Error "Category already exists"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/categories [post]
+func CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	input.Name = ledger.NormaliseCategory(input.Name)
+	if err := validate.ValidateStruct(input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+
+	userId := middleware.GetCurrentUserId(r)
+	if _, err := ledger.ResolveCategory(uint(userId), input.Name); err == nil {
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(errors.ErrCategoryExists)
+		return
+	}
+
+	category := models.CustomCategory{UserID: uint(userId), Name: input.Name}
+	if err := db.GetDb().Create(&category).Error; err != nil {
+		log.Error("Failed to create category", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionCategoryCreated, EntityType: audit.EntityCategory, EntityID: category.ID, After: category})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(category)
+}
+
+// DeleteCategory deletes a custom bill category
+// @Summary Delete a custom category
+// @Description Deletes a custom category. Bills of the user's groups in this category become uncategorised.
+// @Tags categories
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Category ID"
+// @Success 200 {object} map[string]string "Category deleted"
+// @Failure 404 {object} errors.Error "Category not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/categories/{id} [delete]
+func DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	var category models.CustomCategory
+	if err := db.GetDb().Where("id = ? AND user_id = ?", chi.URLParam(r, "id"), userId).First(&category).Error; err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrCategoryNotFound)
+		return
+	}
+
+	err := db.GetDb().Transaction(func(tx *gorm.DB) error {
+		owned := tx.Model(&models.Group{}).Select("id").Where("created_by = ?", userId)
+		if err := tx.Model(&models.Bill{}).Where("category = ? AND group_id IN (?)", category.Name, owned).Update("category", models.Uncategorised).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+	if err != nil {
+		log.Error("Failed to delete category", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionCategoryDeleted, EntityType: audit.EntityCategory, EntityID: category.ID, Before: category})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Category deleted"})
+}
+
+// UpdateBill edits the name or category of a bill
+// @Summary Edit a bill
+// @Description Changes the name or category of a bill of the group. Only the group owner can edit bills.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param billId path int true "Bill ID"
+// @Param request body dto.UpdateBillRequest true "Changes"
+// @Success 200 {object} dto.BillResponse
+// @Failure 400 {object} errors.Error "Invalid name or unknown category"
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group or bill not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/bills/{billId} [patch]
+func UpdateBill(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
+		return
+	}
+	billID, err := strconv.ParseUint(chi.URLParam(r, "billId"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrBillNotFound)
+		return
+	}
+	var input dto.UpdateBillRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+
+	bill, err := ledger.UpdateBill(r.Context(), uint(middleware.GetCurrentUserId(r)), uint(groupID), uint(billID), input)
+	if err != nil {
+		writeLedgerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.BillResponse{
+		ID:        bill.ID,
+		GroupID:   bill.GroupID,
+		Name:      bill.Name,
+		Category:  bill.Category,
+		Amount:    bill.Amount,
+		Completed: bill.Completed,
+		CreatedAt: bill.CreatedAt,
+		UpdatedAt: bill.UpdatedAt,
+	})
+}
+
+// GetSpendingAnalytics aggregates spending per category and month
+// @Summary Spending by category and month
+// @Description Aggregates spending per category and per month for bills created in the date range (last twelve months by default). Without groupId it covers the user's share in every group they belong to; with groupId it covers the group's bill totals and requires membership. Dates and months are those of the user's timezone preference.
+// @Tags analytics
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param groupId query int false "Group to analyse instead of the user"
+// @Param from query string false "from date in the format YYYY-MM-DD"
+// @Param to query string false "to date in the format YYYY-MM-DD"
+// @Success 200 {object} dto.SpendingAnalyticsResponse
+// @Failure 400 {object} errors.Error "Invalid date range"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/analytics/spending [get]
+func GetSpendingAnalytics(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	loc, ok := requestTimezone(w, r)
+	if !ok {
+		return
+	}
+	req := dto.GetGroupReportRequest{}
+	if v := r.URL.Query().Get("from"); v != "" {
+		req.From = &v
+	} else {
+		from := time.Now().In(loc).AddDate(-1, 0, 0).Format("2006-01-02")
+		req.From = &from
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		req.To = &v
+	}
+	from, to, err := ledger.ReportRange(req, loc)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+
+	resp := dto.SpendingAnalyticsResponse{Scope: "user", From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}
+	var rows []categoryMonthAmount
+	if groupID := r.URL.Query().Get("groupId"); groupID != "" {
+		member, ok := requireGroupMember(w, groupID, userId)
+		if !ok {
+			return
+		}
+		resp.Scope, resp.GroupID = "group", member.GroupID
+		rows, err = groupSpending(member.GroupID, from, to)
+	} else {
+		rows, err = userSpending(uint(userId), from, to)
+	}
+	if err != nil {
+		log.Error("Failed to aggregate spending", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	resp.Total, resp.ByCategory, resp.ByMonth = summariseSpending(rows)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+type categoryMonthAmount struct {
+	Category string
+	Month    string
+	Amount   float64
+}
+
+// userSpending sums the user's share of bills per category and month, months being those of the range's timezone.
+func userSpending(userId uint, from, to time.Time) ([]categoryMonthAmount, error) {
+	var rows []categoryMonthAmount
+	err := db.GetDb().Table("group_members").
+		Select("bills.category AS category, to_char(bills.created_at AT TIME ZONE ?, 'YYYY-MM') AS month, SUM(group_members.split_amount) AS amount", from.Location().String()).
+		Joins("JOIN groups ON groups.id = group_members.group_id AND groups.deleted_at IS NULL").
+		Joins("JOIN bills ON bills.id = groups.bill_id AND bills.deleted_at IS NULL").
+		Where("group_members.user_id = ? AND group_members.deleted_at IS NULL AND bills.created_at BETWEEN ? AND ?", userId, from, to).
+		Group("1, 2").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// groupSpending sums the bill totals of a group per category and month, months being those of the range's timezone.
+func groupSpending(groupID uint, from, to time.Time) ([]categoryMonthAmount, error) {
+	var rows []categoryMonthAmount
+	err := db.GetDb().Model(&models.Bill{}).
+		Select("category, to_char(created_at AT TIME ZONE ?, 'YYYY-MM') AS month, SUM(amount) AS amount", from.Location().String()).
+		Where("group_id = ? AND created_at BETWEEN ? AND ?", groupID, from, to).
+		Group("1, 2").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// summariseSpending returns the total, the categories by amount (largest first) and the months in order.
+func summariseSpending(rows []categoryMonthAmount) (float64, []dto.CategoryAmount, []dto.MonthlySpending) {
+	var total float64
+	byCategory := map[string]float64{}
+	byMonth := map[string]*dto.MonthlySpending{}
+	for _, row := range rows {
+		total += row.Amount
+		byCategory[row.Category] += row.Amount
+		month, ok := byMonth[row.Month]
+		if !ok {
+			month = &dto.MonthlySpending{Month: row.Month, Categories: []dto.CategoryAmount{}}
+			byMonth[row.Month] = month
+		}
+		month.Amount += row.Amount
+		month.Categories = append(month.Categories, dto.CategoryAmount{Category: row.Category, Amount: row.Amount})
+	}
+
+	categories := make([]dto.CategoryAmount, 0, len(byCategory))
+	for category, amount := range byCategory {
+		categories = append(categories, dto.CategoryAmount{Category: category, Amount: amount})
+	}
+	sortCategoryAmounts(categories)
+
+	months := make([]dto.MonthlySpending, 0, len(byMonth))
+	for _, month := range byMonth {
+		sortCategoryAmounts(month.Categories)
+		months = append(months, *month)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Month < months[j].Month })
+	return total, categories, months
+}
+
+func sortCategoryAmounts(amounts []dto.CategoryAmount) {
+	sort.Slice(amounts, func(i, j int) bool {
+		if amounts[i].Amount != amounts[j].Amount {
+			return amounts[i].Amount > amounts[j].Amount
+		}
+		return amounts[i].Category < amounts[j].Category
+	})
+}
@@ -0,0 +1,485 @@
+package handlers
+
+import (
+	"encoding/json"
+	e "errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ListGroupComments lists the comments posted on a group
+// @Summary List comments on a group
+// @Description Returns top-level comments posted on the group, oldest first, each with its replies. Only members of the group can read them.
+// @Tags comments
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param limit query int false "Number of top-level comments (default 20, max 100)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; createdAt by default"
+// @Param from query string false "Posted on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Posted on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Success 200 {object} dto.CommentListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/comments [get]
+func ListGroupComments(w http.ResponseWriter, r *http.Request) {
+	listComments(w, r, false)
+}
+
+// ListBillComments lists the comments posted on a bill
+// @Summary List comments on a bill
+// @Description Returns top-level comments posted on a bill of the group, oldest first, each with its replies.
+// @Tags comments
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param billId path int true "Bill ID"
+// @Param limit query int false "Number of top-level comments (default 20, max 100)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; createdAt by default"
+// @Param from query string false "Posted on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Posted on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Success 200 {object} dto.CommentListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 404 {object} errors.Error "Group or bill not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/bills/{billId}/comments [get]
+func ListBillComments(w http.ResponseWriter, r *http.Request) {
+	listComments(w, r, true)
+}
+
+// CreateGroupComment posts a comment on a group
+// @Summary Comment on a group
+// @Description Posts a comment on the group, or a reply when parentId is given.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param request body dto.CreateCommentRequest true "Comment"
+// @Success 201 {object} dto.CommentResponse
+// @Failure 400 {object} errors.Error "Bad Request"
+// @Failure 404 {object} errors.Error "Group or parent comment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/comments [post]
+func CreateGroupComment(w http.ResponseWriter, r *http.Request) {
+	createComment(w, r, false)
+}
+
+// CreateBillComment posts a comment on a bill
+// @Summary Comment on a bill
+// @Description Posts a comment on a bill of the group, or a reply when parentId is given.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param billId path int true "Bill ID"
+// @Param request body dto.CreateCommentRequest true "Comment"
+// @Success 201 {object} dto.CommentResponse
+// @Failure 400 {object} errors.Error "Bad Request"
+// @Failure 404 {object} errors.Error "Group, bill or parent comment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/bills/{billId}/comments [post]
+func CreateBillComment(w http.ResponseWriter, r *http.Request) {
+	createComment(w, r, true)
+}
+
+// UpdateComment edits a comment
+// @Summary Edit a comment
+// @Description Changes the text of a comment. Only its author can edit it.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param commentId path int true "Comment ID"
+// @Param request body dto.UpdateCommentRequest true "New text"
+// @Success 200 {object} dto.CommentResponse
+// @Failure 400 {object} errors.Error "Bad Request"
+// @Failure 403 {object} errors.Error "Not the author"
+// @Failure 404 {object} errors.Error "Group or comment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/comments/{commentId} [patch]
+func UpdateComment(w http.ResponseWriter, r *http.Request) {
+	var input dto.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	if err := validate.ValidateStruct(input); err != nil {
+		log.Error("Error validating request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+
+	userId := middleware.GetCurrentUserId(r)
+	comment, ok := findGroupComment(w, r, userId)
+	if !ok {
+		return
+	}
+	if comment.AuthorID != uint(userId) || comment.Removed {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(errors.ErrForbidden)
+		return
+	}
+
+	before := comment
+	now := time.Now()
+	comment.Body = input.Body
+	comment.EditedAt = &now
+	if err := db.GetDb().Save(&comment).Error; err != nil {
+		log.Error("Failed to update comment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionCommentUpdated, EntityType: audit.EntityComment, EntityID: comment.ID, GroupID: comment.GroupID, Before: before, After: comment})
+
+	names, err := userNames([]uint{comment.AuthorID})
+	if err != nil {
+		log.Error("Failed to fetch comment author", zap.Error(err))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(commentResponse(comment, names))
+}
+
+// DeleteComment deletes a comment
+// @Summary Delete a comment
+// @Description Deletes a comment. The author and the group owner can delete it. Comments with replies are kept as a removed placeholder so the thread stays readable.
+// @Tags comments
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} map[string]string "Comment deleted"
+// @Failure 403 {object} errors.Error "Not allowed"
+// @Failure 404 {object} errors.Error "Group or comment not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/comments/{commentId} [delete]
+func DeleteComment(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	comment, ok := findGroupComment(w, r, userId)
+	if !ok {
+		return
+	}
+
+	if comment.AuthorID != uint(userId) {
+		var group models.Group
+		if err := db.GetDb().Select("created_by").Where("id = ?", comment.GroupID).First(&group).Error; err != nil || group.CreatedBy != uint(userId) {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(errors.ErrForbidden)
+			return
+		}
+	}
+
+	var replies int64
+	if err := db.GetDb().Model(&models.Comment{}).Where("parent_id = ?", comment.ID).Count(&replies).Error; err != nil {
+		log.Error("Failed to count comment replies", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	var err error
+	if replies > 0 {
+		err = db.GetDb().Model(&comment).Updates(map[string]any{"body": "", "removed": true}).Error
+	} else {
+		err = db.GetDb().Delete(&comment).Error
+	}
+	if err != nil {
+		log.Error("Failed to delete comment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionCommentDeleted, EntityType: audit.EntityComment, EntityID: comment.ID, GroupID: comment.GroupID, Before: comment})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Comment deleted"})
+}
+
+func listComments(w http.ResponseWriter, r *http.Request, onBill bool) {
+	groupID := chi.URLParam(r, "id")
+	userId := middleware.GetCurrentUserId(r)
+	log.Debug("ListComments request", zap.String("groupId", groupID), zap.Bool("onBill", onBill))
+
+	if _, ok := requireGroupMember(w, groupID, userId); !ok {
+		return
+	}
+	lq, ok := listRequest(w, r, commentListSpec)
+	if !ok {
+		return
+	}
+
+	query := db.GetDb().Model(&models.Comment{}).Where("group_id = ?", groupID)
+	if onBill {
+		bill, ok := findGroupBill(w, r, groupID)
+		if !ok {
+			return
+		}
+		query = query.Where("bill_id = ?", bill.ID)
+	} else {
+		query = query.Where("bill_id IS NULL")
+	}
+	query = query.Session(&gorm.Session{})
+
+	resp := dto.CommentListResponse{Comments: []dto.CommentResponse{}}
+	if err := lq.filtered(query.Where("parent_id IS NULL")).Count(&resp.Total).Error; err != nil {
+		log.Error("Failed to count comments", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	paged, err := lq.page(query.Where("parent_id IS NULL"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	var roots []models.Comment
+	if err := paged.Find(&roots).Error; err != nil {
+		log.Error("Failed to fetch comments", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	roots, resp.Pagination = pageOf(lq, roots, func(c models.Comment) sortValue {
+		return sortValue{time: c.CreatedAt, id: c.ID}
+	})
+
+	var replies []models.Comment
+	if len(roots) > 0 {
+		rootIDs := make([]uint, len(roots))
+		for i, root := range roots {
+			rootIDs[i] = root.ID
+		}
+		if err := query.Where("root_id IN ?", rootIDs).Order("created_at, id").Find(&replies).Error; err != nil {
+			log.Error("Failed to fetch comment replies", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+			return
+		}
+	}
+
+	all := append(append([]models.Comment{}, roots...), replies...)
+	names, err := userNames(commentAuthorIDs(all))
+	if err != nil {
+		log.Error("Failed to fetch comment authors", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	resp.Comments = buildCommentTree(all, names)
+
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// commentListSpec is how top-level comments are sorted and filtered.
+var commentListSpec = listSpec{
+	id:           "id",
+	sorts:        map[string]sortKey{"createdAt": {"created_at", sortTime}},
+	defaultSort:  "createdAt",
+	created:      "created_at",
+	defaultLimit: 20,
+	maxLimit:     100,
+}
+
+func createComment(w http.ResponseWriter, r *http.Request, onBill bool) {
+	groupID := chi.URLParam(r, "id")
+	var input dto.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	if err := validate.ValidateStruct(input); err != nil {
+		log.Error("Error validating request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+
+	userId := middleware.GetCurrentUserId(r)
+	member, ok := requireGroupMember(w, groupID, userId)
+	if !ok {
+		return
+	}
+
+	comment := models.Comment{GroupID: member.GroupID, AuthorID: uint(userId), Body: input.Body}
+	if onBill {
+		bill, ok := findGroupBill(w, r, groupID)
+		if !ok {
+			return
+		}
+		comment.BillID = &bill.ID
+	}
+
+	if input.ParentID != nil {
+		var parent models.Comment
+		query := db.GetDb().Where("id = ? AND group_id = ?", *input.ParentID, comment.GroupID)
+		if comment.BillID != nil {
+			query = query.Where("bill_id = ?", *comment.BillID)
+		} else {
+			query = query.Where("bill_id IS NULL")
+		}
+		if err := query.First(&parent).Error; err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrCommentNotFound)
+			return
+		}
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+	}
+
+	if err := db.GetDb().Create(&comment).Error; err != nil {
+		log.Error("Failed to create comment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionCommentCreated, EntityType: audit.EntityComment, EntityID: comment.ID, GroupID: comment.GroupID, After: comment})
+
+	names, err := userNames([]uint{comment.AuthorID})
+	if err != nil {
+		log.Error("Failed to fetch comment author", zap.Error(err))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(commentResponse(comment, names))
+}
+
+func findGroupBill(w http.ResponseWriter, r *http.Request, groupID string) (models.Bill, bool) {
+	var bill models.Bill
+	if err := db.GetDb().Where("id = ? AND group_id = ?", chi.URLParam(r, "billId"), groupID).First(&bill).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrBillNotFound)
+			return bill, false
+		}
+		log.Error("Failed to fetch bill", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return bill, false
+	}
+	return bill, true
+}
+
+func findGroupComment(w http.ResponseWriter, r *http.Request, userId float64) (models.Comment, bool) {
+	groupID := chi.URLParam(r, "id")
+	var comment models.Comment
+	if _, ok := requireGroupMember(w, groupID, userId); !ok {
+		return comment, false
+	}
+	if err := db.GetDb().Where("id = ? AND group_id = ?", chi.URLParam(r, "commentId"), groupID).First(&comment).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrCommentNotFound)
+			return comment, false
+		}
+		log.Error("Failed to fetch comment", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return comment, false
+	}
+	return comment, true
+}
+
+// buildCommentTree nests replies under their parents. Comments must be ordered oldest first.
+func buildCommentTree(comments []models.Comment, names map[uint]string) []dto.CommentResponse {
+	children := make(map[uint][]models.Comment)
+	var roots []models.Comment
+	for _, c := range comments {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var build func(c models.Comment) dto.CommentResponse
+	build = func(c models.Comment) dto.CommentResponse {
+		resp := commentResponse(c, names)
+		for _, child := range children[c.ID] {
+			resp.Replies = append(resp.Replies, build(child))
+		}
+		return resp
+	}
+
+	tree := make([]dto.CommentResponse, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
+
+func commentResponse(c models.Comment, names map[uint]string) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:         c.ID,
+		GroupID:    c.GroupID,
+		BillID:     c.BillID,
+		ParentID:   c.ParentID,
+		AuthorID:   c.AuthorID,
+		AuthorName: names[c.AuthorID],
+		Body:       c.Body,
+		Removed:    c.Removed,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+		EditedAt:   c.EditedAt,
+		Replies:    []dto.CommentResponse{},
+	}
+}
+
+func commentAuthorIDs(comments []models.Comment) []uint {
+	seen := make(map[uint]bool, len(comments))
+	ids := make([]uint, 0, len(comments))
+	for _, c := range comments {
+		if !seen[c.AuthorID] {
+			seen[c.AuthorID] = true
+			ids = append(ids, c.AuthorID)
+		}
+	}
+	return ids
+}
+
+// userNames resolves user ids to display names.
+func userNames(ids []uint) (map[uint]string, error) {
+	names := make(map[uint]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+	var users []models.User
+	if err := db.GetDb().Select("id, name").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return names, err
+	}
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+	return names, nil
+}
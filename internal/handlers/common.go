@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	e "errors"
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// requireGroupMember loads the membership of the user in the group, writing a 404 when the user is not a member.
+func requireGroupMember(w http.ResponseWriter, groupID any, userId float64) (models.GroupMember, bool) {
+	var member models.GroupMember
+	if err := db.GetDb().Where("group_id = ? AND user_id = ?", groupID, userId).First(&member).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
+			return member, false
+		}
+		log.Error("Failed to fetch group membership", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return member, false
+	}
+	return member, true
+}
+
+// requireGroupOwner loads the group, writing a 404 when it does not exist and a 403 when the user did not create it.
+func requireGroupOwner(w http.ResponseWriter, groupID any, userId float64) (models.Group, bool) {
+	var group models.Group
+	if err := db.GetDb().Where("id = ?", groupID).First(&group).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
+			return group, false
+		}
+		log.Error("Failed to fetch group", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return group, false
+	}
+	if group.CreatedBy != uint(userId) {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(errors.ErrForbidden)
+		return group, false
+	}
+	return group, true
+}
+
+// writeLedgerError answers with a failed ledger operation.
+func writeLedgerError(w http.ResponseWriter, err error) {
+	var failure *ledger.Error
+	if e.As(err, &failure) {
+		w.WriteHeader(failure.Status)
+		_ = json.NewEncoder(w).Encode(failure.Err)
+		return
+	}
+	log.Error("Ledger operation failed", zap.Error(err))
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+}
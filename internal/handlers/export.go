@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/helper/export"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/exportjob"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const defaultExportAsyncThreshold = 500
+
+// ExportPersonalData exports every record related to the current user as a ZIP archive
+// @Summary Export personal data
+// @Description Builds a ZIP archive with JSON and CSV files covering the user's profile, groups, bills, splits, payments and bill history. Large exports (or async=true) are generated in the background and return 202 with a job to poll.
+// @Tags me
+// @Produce application/zip
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param async query bool false "Force asynchronous generation"
+// @Success 200 {file} file "ZIP archive with the user's data"
+// @Success 202 {object} dto.ExportJobResponse "Export queued"
+// @Failure 404 {object} errors.Error "User not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/export [get]
+func ExportPersonalData(w http.ResponseWriter, r *http.Request) {
+	userId := uint(middleware.GetCurrentUserId(r))
+	log.Debug("ExportPersonalData request", zap.Uint("userId", userId))
+
+	var records int64
+	if err := db.GetDb().Table("group_members").
+		Joins("LEFT JOIN groups ON groups.id = group_members.group_id").
+		Joins("LEFT JOIN bill_histories ON bill_histories.bill_id = groups.bill_id").
+		Where("group_members.user_id = ?", userId).
+		Count(&records).Error; err != nil {
+		log.Error("Failed to count export records", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	threshold := config.GetConfig().ExportAsyncThreshold
+	if threshold <= 0 {
+		threshold = defaultExportAsyncThreshold
+	}
+
+	if r.URL.Query().Get("async") == "true" || records > int64(threshold) {
+		job := models.ExportJob{UserID: userId, Status: models.ExportStatusPending}
+		if err := db.GetDb().Create(&job).Error; err != nil {
+			log.Error("Failed to create export job", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+			return
+		}
+		exportjob.Wake()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", exportStatusURL(job.ID))
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(exportJobResponse(job))
+		return
+	}
+
+	data, err := collectUserExport(r.Context(), userId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrUserNotFound)
+			return
+		}
+		log.Error("Failed to collect export data", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteArchive(&buf, data); err != nil {
+		log.Error("Failed to write export archive", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrExportFailed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", exportFileName(userId, data.GeneratedAt)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Error("Failed to write export to response", zap.Error(err))
+	}
+}
+
+// GetExportStatus returns the status of an asynchronous data export
+// @Summary Get export status
+// @Description Returns the status of a personal data export job owned by the current user.
+// @Tags me
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param jobId path int true "Export job ID"
+// @Success 200 {object} dto.ExportJobResponse
+// @Failure 404 {object} errors.Error "Export not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/export/{jobId} [get]
+func GetExportStatus(w http.ResponseWriter, r *http.Request) {
+	job, ok := findExportJob(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(exportJobResponse(job))
+}
+
+// DownloadExport downloads the archive of a finished data export
+// @Summary Download export archive
+// @Description Downloads the ZIP archive of a finished personal data export job.
+// @Tags me
+// @Produce application/zip
+// @Param Authorization header string true "Bearer token"
+// @Param jobId path int true "Export job ID"
+// @Success 200 {file} file "ZIP archive with the user's data"
+// @Failure 404 {object} errors.Error "Export not found"
+// @Failure 409 {object} errors.Error "Export not ready"
+// @Failure 410 {object} errors.Error "Export expired"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/export/{jobId}/download [get]
+func DownloadExport(w http.ResponseWriter, r *http.Request) {
+	job, ok := findExportJob(w, r)
+	if !ok {
+		return
+	}
+
+	switch job.Status {
+	case models.ExportStatusDone:
+	case models.ExportStatusFailed:
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrExportFailed)
+		return
+	case models.ExportStatusExpired:
+		w.WriteHeader(http.StatusGone)
+		_ = json.NewEncoder(w).Encode(errors.ErrExportExpired)
+		return
+	default:
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(errors.ErrExportNotReady)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", job.FileName))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(job.Data); err != nil {
+		log.Error("Failed to write export to response", zap.Error(err))
+	}
+}
+
+func findExportJob(w http.ResponseWriter, r *http.Request) (models.ExportJob, bool) {
+	jobId := chi.URLParam(r, "jobId")
+	userId := middleware.GetCurrentUserId(r)
+
+	var job models.ExportJob
+	if err := db.GetDb().Where("id = ? AND user_id = ?", jobId, userId).First(&job).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrExportNotFound)
+			return job, false
+		}
+		log.Error("Failed to fetch export job", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return job, false
+	}
+	return job, true
+}
+
+// RenderExportJob builds the archive of an export job for the background worker.
+func RenderExportJob(ctx context.Context, job models.ExportJob) (string, []byte, error) {
+	data, err := collectUserExport(ctx, job.UserID)
+	if err != nil {
+		return "", nil, err
+	}
+	var buf bytes.Buffer
+	if err := export.WriteArchive(&buf, data); err != nil {
+		return "", nil, err
+	}
+	return exportFileName(job.UserID, data.GeneratedAt), buf.Bytes(), nil
+}
+
+func collectUserExport(ctx context.Context, userId uint) (dto.UserExport, error) {
+	data := dto.UserExport{
+		Groups:      []models.Group{},
+		Bills:       []models.Bill{},
+		Splits:      []models.GroupMember{},
+		Payments:    []dto.ExportPayment{},
+		BillHistory: []models.BillHistory{},
+		GeneratedAt: time.Now(),
+	}
+	dbc := db.GetDb().WithContext(ctx)
+
+	var user models.User
+	if err := dbc.Where("id = ?", userId).First(&user).Error; err != nil {
+		return data, err
+	}
+	data.Profile = dto.ExportProfile{
+		ID:              user.ID,
+		Email:           user.Email,
+		Name:            user.Name,
+		EmailVisibility: user.EmailVisibility,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+
+	if err := dbc.Where("user_id = ?", userId).Order("id").Find(&data.Splits).Error; err != nil {
+		return data, err
+	}
+	if len(data.Splits) == 0 {
+		return data, nil
+	}
+
+	groupIDs := make([]uint, 0, len(data.Splits))
+	for _, split := range data.Splits {
+		groupIDs = append(groupIDs, split.GroupID)
+	}
+	if err := dbc.Where("id IN ?", groupIDs).Order("id").Find(&data.Groups).Error; err != nil {
+		return data, err
+	}
+	if err := dbc.Where("group_id IN ?", groupIDs).Order("id").Find(&data.Bills).Error; err != nil {
+		return data, err
+	}
+
+	groupNames := make(map[uint]string, len(data.Groups))
+	for _, group := range data.Groups {
+		groupNames[group.ID] = group.Name
+	}
+	for _, split := range data.Splits {
+		if !split.HasPaid {
+			continue
+		}
+		paidAt := split.UpdatedAt // paid before the payment time was recorded
+		if split.PaidAt != nil {
+			paidAt = *split.PaidAt
+		}
+		data.Payments = append(data.Payments, dto.ExportPayment{
+			GroupID:   split.GroupID,
+			GroupName: groupNames[split.GroupID],
+			Amount:    split.SplitAmount,
+			Remarks:   split.Remarks,
+			PaidAt:    paidAt,
+		})
+	}
+
+	if len(data.Bills) > 0 {
+		billIDs := make([]uint, 0, len(data.Bills))
+		for _, bill := range data.Bills {
+			billIDs = append(billIDs, bill.ID)
+		}
+		// Only the user's own payments: the history of a bill also names the other members who paid.
+		if err := dbc.Where("bill_id IN ? AND paid_by_id = ?", billIDs, userId).Order("paid_at").Find(&data.BillHistory).Error; err != nil {
+			return data, err
+		}
+	}
+
+	return data, nil
+}
+
+func exportJobResponse(job models.ExportJob) dto.ExportJobResponse {
+	resp := dto.ExportJobResponse{
+		JobID:       job.ID,
+		Status:      job.Status,
+		StatusURL:   exportStatusURL(job.ID),
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+		ExpiresAt:   job.ExpiresAt,
+	}
+	if job.Status == models.ExportStatusDone {
+		resp.DownloadURL = exportStatusURL(job.ID) + "/download"
+	}
+	return resp
+}
+
+func exportStatusURL(jobID uint) string {
+	return fmt.Sprintf("/v1/me/export/%d", jobID)
+}
+
+func exportFileName(userId uint, at time.Time) string {
+	return fmt.Sprintf("splitwise_export_%d_%s.zip", userId, at.Format("20060102150405"))
+}
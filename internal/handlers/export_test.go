@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+func TestCollectUserExport(t *testing.T) {
+	conn := dbtest.Open(t, &models.User{}, &models.Group{}, &models.GroupMember{}, &models.Bill{}, &models.BillHistory{})
+	user := models.User{Name: "Asha", Email: "asha@example.com"}
+	other := models.User{Name: "Ravi", Email: "ravi@example.com"}
+	for _, u := range []*models.User{&user, &other} {
+		if err := conn.Create(u).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	group := models.Group{Name: "Trip"}
+	if err := conn.Create(&group).Error; err != nil {
+		t.Fatal(err)
+	}
+	paidAt := time.Date(2024, 3, 5, 18, 30, 0, 0, time.UTC)
+	members := []models.GroupMember{
+		{GroupID: group.ID, UserID: user.ID, SplitAmount: 50, HasPaid: true, PaidAt: &paidAt, Remarks: "cash"},
+		{GroupID: group.ID, UserID: other.ID, SplitAmount: 50},
+	}
+	if err := conn.Create(&members).Error; err != nil {
+		t.Fatal(err)
+	}
+	bill := models.Bill{Name: "Hotel", Amount: 100, GroupID: group.ID}
+	if err := conn.Create(&bill).Error; err != nil {
+		t.Fatal(err)
+	}
+	history := []models.BillHistory{
+		{BillID: bill.ID, Amount: 50, PaidBy: user.Name, PaidByID: user.ID},
+		{BillID: bill.ID, Amount: 50, PaidBy: other.Name, PaidByID: other.ID},
+	}
+	if err := conn.Create(&history).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := collectUserExport(context.Background(), user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Splits) != 1 || len(data.Bills) != 1 {
+		t.Errorf("export has %d splits and %d bills, want the user's split and the group's bill", len(data.Splits), len(data.Bills))
+	}
+	if len(data.BillHistory) != 1 || data.BillHistory[0].PaidByID != user.ID {
+		t.Errorf("bill history = %+v, want only the user's own payment", data.BillHistory)
+	}
+	if len(data.Payments) != 1 || !data.Payments[0].PaidAt.Equal(paidAt) {
+		t.Errorf("payments = %+v, want one paid at %v", data.Payments, paidAt)
+	}
+}
@@ -3,16 +3,19 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi"
-	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/budget"
 	"github.com/mohdjishin/SplitWise/internal/db"
 	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
 	"github.com/mohdjishin/SplitWise/internal/middleware"
 	"github.com/mohdjishin/SplitWise/internal/models"
 	dto "github.com/mohdjishin/SplitWise/internal/models/dto"
 	log "github.com/mohdjishin/SplitWise/logger"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // CreateGroupWithBill handles creating a group with an associated bill
@@ -37,55 +40,10 @@ func CreateGroupWithBill(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	log.Debug("CreateGroupWithBill request", zap.Any("request", input))
-	if err := validate.ValidateStruct(input); err != nil {
-		log.Error("Error validating request body", zap.Any("error", err))
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
-		return
-	}
-
-	userId := middleware.GetCurrentUserId(r)
-
-	group := models.Group{
-		Name:      input.GroupName,
-		CreatedBy: uint(userId),
-	}
-	if err := db.GetDb().Create(&group).Error; err != nil {
-		log.Error("Failed to create group", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-
-		return
-	}
-
-	groupMember := models.GroupMember{
-		GroupID: group.ID,
-		UserID:  uint(userId),
-	}
-	if err := db.GetDb().Create(&groupMember).Error; err != nil {
-		log.Error("Failed to add creator to group", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
-	}
-
-	bill := models.Bill{
-		Name:    input.Bill.Name,
-		Amount:  input.Bill.Amount,
-		GroupID: group.ID,
-	}
-	if err := db.GetDb().Create(&bill).Error; err != nil {
-		log.Error("Failed to create bill", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
-	}
 
-	group.BillID = bill.ID
-	if err := db.GetDb().Save(&group).Error; err != nil {
-		log.Error("Failed to update group with bill", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+	group, bill, err := ledger.CreateGroup(r.Context(), uint(middleware.GetCurrentUserId(r)), input)
+	if err != nil {
+		writeLedgerError(w, err)
 		return
 	}
 
@@ -109,73 +67,168 @@ func CreateGroupWithBill(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} errors.Error "Internal Server Error"
 // @Router /v1/groups/{id} [delete]
 func DeleteGroup(w http.ResponseWriter, r *http.Request) {
-	groupID := chi.URLParam(r, "id")
-	var group models.Group
-	log.Debug("DeleteGroup request", zap.Any("groupID", groupID))
-	if err := db.GetDb().Where("id = ?", groupID).First(&group).Error; err != nil {
+	groupID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
-		log.Error("Group not found", zap.Error(err))
 		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
 		return
 	}
+	log.Debug("DeleteGroup request", zap.Uint64("groupID", groupID))
+	if err := ledger.DeleteGroup(r.Context(), uint(middleware.GetCurrentUserId(r)), uint(groupID)); err != nil {
+		writeLedgerError(w, err)
+		return
+	}
 
-	userId := middleware.GetCurrentUserId(r)
-	if group.CreatedBy != uint(userId) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(dto.DeleteGroupResponse{Message: "Group deleted"})
+}
+
+// GetGroup returns a group with its bills and members
+// @Summary Get a group
+// @Description Returns the group with its bills, budgets and members, including their names, split amounts and whether and when they paid. Any member of the group can fetch it. Emails are only shown for the viewer and for members who share their email with co-members, see PUT /v1/me/privacy.
+// @Tags groups
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Success 200 {object} dto.GroupDetailResponse
+// @Failure 404 {object} errors.Error "Group not found or the user is not a member"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id} [get]
+func GetGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
 		return
 	}
-	log.Debug("DeleteGroup request", zap.Any("groupID and userId", []any{groupID, userId}))
-	if err := db.GetDb().Delete(&group).Error; err != nil {
-		log.Error("Failed to delete group", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+	resp, err := ledger.GroupDetail(uint(middleware.GetCurrentUserId(r)), uint(groupID))
+	if err != nil {
+		writeLedgerError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(dto.DeleteGroupResponse{Message: "Group deleted"})
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
 // ListOwnedGroups handles fetching groups owned by the current user
 // @Summary List groups owned by the user
-// @Description Fetches and returns a list of groups that are owned by the current user, including group members.
+// @Description Fetches a page of the groups created by the current user, including group members. Pages are walked with the nextCursor of the response, which is also linked in the Link header.
 // @Tags groups
+// @Produce json
 // @Param Authorization header string true "Bearer token"
-// @Success 200 {array} dto.ListOwnedGroupsResponse "List of groups owned by the user"
+// @Param limit query int false "Groups per page, 20 by default and at most 100"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, amount or name, with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Created on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Created on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Param minAmount query number false "Smallest total amount"
+// @Param maxAmount query number false "Largest total amount"
+// @Param status query string false "PENDING or DONE"
+// @Param q query string false "Part of the group name, ignoring case"
+// @Success 200 {object} dto.ListOwnedGroupsPage "Page of groups owned by the user"
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
 // @Failure 500 {object} errors.Error "Internal Server Error"
 // @Router /v1/groups/owned [get]
 func ListOwnedGroups(w http.ResponseWriter, r *http.Request) {
 	userId := middleware.GetCurrentUserId(r)
-	var groups []models.Group
 	log.Debug("ListOwnedGroups request", zap.Any("userId", userId))
-	err := db.GetDb().Table("groups").
-		Select("groups.*, group_members.user_id").
-		Joins("LEFT JOIN group_members ON group_members.group_id = groups.id").
-		Where("group_members.user_id = ?", userId).
-		Find(&groups).Error
+	groups, page, ok := listGroups(w, r, db.GetDb().Where("groups.created_by = ?", userId))
+	if !ok {
+		return
+	}
+	members, budgets, ok := groupDetails(w, groups)
+	if !ok {
+		return
+	}
+
+	resp := dto.ListOwnedGroupsPage{Groups: []dto.ListOwnedGroupsResponse{}, Pagination: page}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, dto.ListOwnedGroupsResponse{
+			Group:   group,
+			Members: members[group.ID],
+			Budgets: orEmpty(budgets[group.ID]),
+		})
+	}
 
+	setCursorLinks(w, r, page)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// groupListSpec is how group lists are sorted and filtered.
+var groupListSpec = listSpec{
+	id: "groups.id",
+	sorts: map[string]sortKey{
+		"createdAt": {"groups.created_at", sortTime},
+		"amount":    {"groups.total_amount", sortNumber},
+		"name":      {"groups.name", sortText},
+	},
+	defaultSort:  "-createdAt",
+	created:      "groups.created_at",
+	amount:       "groups.total_amount",
+	name:         "groups.name",
+	status:       "groups.status",
+	statuses:     []string{"PENDING", "DONE"},
+	defaultLimit: 20,
+	maxLimit:     100,
+}
+
+// listGroups fetches the page of groups asked for in the request out of query.
+func listGroups(w http.ResponseWriter, r *http.Request, query *gorm.DB) ([]models.Group, dto.Pagination, bool) {
+	loc, ok := requestTimezone(w, r)
+	if !ok {
+		return nil, dto.Pagination{}, false
+	}
+	lq, err := parseList(r, groupListSpec, loc)
+	if err == nil {
+		query, err = lq.page(query.Model(&models.Group{}))
+	}
 	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return nil, dto.Pagination{}, false
+	}
+
+	var groups []models.Group
+	if err := query.Find(&groups).Error; err != nil {
 		log.Error("Failed to fetch groups", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+		return nil, dto.Pagination{}, false
 	}
+	groups, page := pageOf(lq, groups, func(g models.Group) sortValue {
+		return sortValue{time: g.CreatedAt, number: g.TotalAmount, text: g.Name, id: g.ID}
+	})
+	return groups, page, true
+}
 
-	var groupList []dto.ListOwnedGroupsResponse
-	for _, group := range groups {
-		var members []models.GroupMember
-		err := db.GetDb().Where("group_id = ?", group.ID).Find(&members).Error
-		if err == nil {
-			groupList = append(groupList, dto.ListOwnedGroupsResponse{
-				Group:   group,
-				Members: members,
-			})
-		}
+// groupDetails loads the members and budgets of the groups at once.
+func groupDetails(w http.ResponseWriter, groups []models.Group) (map[uint][]models.GroupMember, map[uint][]dto.BudgetStatus, bool) {
+	groupMemberMap := make(map[uint][]models.GroupMember)
+	if len(groups) == 0 {
+		return groupMemberMap, nil, true
+	}
+	var members []models.GroupMember
+	if err := db.GetDb().Where("group_id IN (?)", getGroupIDs(groups)).Order("id").Find(&members).Error; err != nil {
+		log.Error("Failed to fetch group members", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return nil, nil, false
+	}
+	for _, member := range members {
+		groupMemberMap[member.GroupID] = append(groupMemberMap[member.GroupID], member)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(groupList)
+	budgets, err := budget.Statuses(getGroupIDs(groups))
+	if err != nil {
+		log.Error("Failed to fetch group budgets", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return nil, nil, false
+	}
+	return groupMemberMap, budgets, true
 }
 
 // AddUsersToGroup handles adding users to a specified group
@@ -202,180 +255,69 @@ func AddUsersToGroup(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Debug("AddUsersToGroup request", zap.Any("request", input))
 
-	if err := validate.ValidateStruct(input); err != nil {
-		log.Error("Error validating request body", zap.Error(err))
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
-		return
-	}
-	userId := middleware.GetCurrentUserId(r)
-	log.Debug("AddUsersToGroup request", zap.Any("userId", userId))
-	var group models.Group
-	if err := db.GetDb().Where("id = ? AND created_by = ?", groupID, userId).First(&group).Error; err != nil {
-		log.Error("Group not found", zap.Error(err))
+	id, err := strconv.ParseUint(groupID, 10, 64)
+	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
 		return
 	}
-
-	userList := map[string]uint{}
-	missingUsers := []string{}
-	existingUsers := []string{}
-	for _, email := range input.UserEmailIds {
-		var user models.User
-		if err := db.GetDb().Where("email = ?", email).First(&user).Error; err != nil {
-			missingUsers = append(missingUsers, email)
-			continue
-		}
-		userList[email] = user.ID
-		var groupMember models.GroupMember
-		if err := db.GetDb().Where("group_id = ? AND user_id = ?", groupID, user.ID).First(&groupMember).Error; err == nil {
-			existingUsers = append(existingUsers, email)
-		}
-	}
-
-	if len(missingUsers) > 0 {
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(errors.ErrUsersNotFound(missingUsers))
-		return
-	}
-	if len(existingUsers) > 0 {
-		w.WriteHeader(http.StatusConflict) // HTTP 409 Conflict
-		_ = json.NewEncoder(w).Encode(errors.ErrUsersAlreadyExists(existingUsers))
+	if _, err := ledger.AddMembers(r.Context(), uint(middleware.GetCurrentUserId(r)), uint(id), input.UserEmailIds); err != nil {
+		writeLedgerError(w, err)
 		return
 	}
 
-	for _, userID := range input.UserEmailIds {
-		var groupMember models.GroupMember
-		if err := db.GetDb().Where("group_id = ? AND user_id = ?", groupID, userList[userID]).First(&groupMember).Error; err == nil { // TODO: change this. query once and check if the user is already a member
-			continue
-		}
-
-		newGroupMember := models.GroupMember{
-			GroupID: group.ID,
-			UserID:  userList[userID],
-		}
-		if err := db.GetDb().Create(&newGroupMember).Error; err != nil {
-			log.Error("Failed to add user to group", zap.Error(err))
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-			return
-		}
-	}
-
-	var groupMembers []models.GroupMember
-	if err := db.GetDb().Where("group_id = ?", groupID).Find(&groupMembers).Error; err != nil {
-		log.Error("Failed to fetch group members", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrWhileFetchingMembers)
-		return
-	}
-
-	var bill models.Bill
-	if err := db.GetDb().Where("id = ?", group.BillID).First(&bill).Error; err != nil {
-		log.Error("Failed to fetch bill", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrWhileFetchingBill)
-		return
-	}
-
-	numMembers := len(groupMembers)
-	if numMembers > 0 {
-		perUserSplitAmount := bill.Amount / float64(numMembers)
-		group.TotalAmount = bill.Amount
-		group.PerUserSplitAmount = perUserSplitAmount
-
-		if err := db.GetDb().Save(&group).Error; err != nil {
-			log.Error("Failed to update group with total and per-user split amounts", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalErrorWithMessage("Failed to update total and per-user split amounts"))
-			return
-		}
-
-		for _, member := range groupMembers {
-			member.SplitAmount = perUserSplitAmount
-			if err := db.GetDb().Save(&member).Error; err != nil {
-				log.Error("Failed to update member split amount", zap.Error(err))
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(errors.ErrInternalErrorWithMessage("Failed to update split amount for members"))
-				return
-			}
-		}
-	} else {
-		group.TotalAmount = 0
-		group.PerUserSplitAmount = 0
-	}
-
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(dto.AddUsersToGroupResponse{Message: "Users added to group successfully"})
 }
 
 // ListMemberGroups
 // @Summary List groups the user belongs to
-// @Description Retrieves all groups associated with the authenticated user. Optionally filters the results by group status. If no status is provided, all groups will be returned.
+// @Description Retrieves a page of the groups the authenticated user is a member of. Optionally filters the results by group status, creation date, total amount and name. Pages are walked with the nextCursor of the response, which is also linked in the Link header.
 // @Tags groups
 // @Accept json
 // @Produce json
+// @Param limit query int false "Groups per page, 20 by default and at most 100"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, amount or name, with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Created on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Created on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Param minAmount query number false "Smallest total amount"
+// @Param maxAmount query number false "Largest total amount"
 // @Param status query string false "The status of the groups to filter by. Valid values are 'PENDING' or 'DONE'"
-// @Success 200 {array} dto.ListMemberGroupsResponse "Successful response with the list of groups."
-// @Failure 400 {object} errors.Error "Invalid status parameter provided."
+// @Param q query string false "Part of the group name, ignoring case"
+// @Success 200 {object} dto.ListMemberGroupsPage "Successful response with the page of groups."
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter provided."
 // @Failure 500 {object} errors.Error "Internal server error."
 // @Router /v1/groups/member-groups [get]
 func ListMemberGroups(w http.ResponseWriter, r *http.Request) {
 	userId := middleware.GetCurrentUserId(r)
-	var groups []models.Group
 	log.Debug("ListMemberGroups request", zap.Any("userId", userId))
-
-	status := r.URL.Query().Get("status")
-
-	if status != "PENDING" && status != "DONE" && status != "" {
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(errors.ErrInvalidQueryParameter("Invalid status for query parameter (status)"))
-		return
-	}
 	query := db.GetDb().
-		Joins("JOIN group_members ON groups.id = group_members.group_id").
+		Joins("JOIN group_members ON groups.id = group_members.group_id AND group_members.deleted_at IS NULL").
 		Where("group_members.user_id = ?", userId)
-
-	if status != "" {
-		query = query.Where("groups.status = ?", status)
-	}
-
-	err := query.Find(&groups).Error
-	if err != nil {
-		log.Error("Failed to fetch groups", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+	groups, page, ok := listGroups(w, r, query)
+	if !ok {
 		return
 	}
-	log.Debug("ListMemberGroups request", zap.Any("groups", groups))
 	log.Debug("len(groups)", zap.Any("len(groups)", len(groups)))
+	members, budgets, ok := groupDetails(w, groups)
+	if !ok {
+		return
+	}
 
-	var groupList []dto.ListMemberGroupsResponse
-	if len(groups) > 0 {
-		var members []models.GroupMember
-		err = db.GetDb().Where("group_id IN (?)", getGroupIDs(groups)).Find(&members).Error
-		if err != nil {
-			log.Error("Failed to fetch group members", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-			return
-		}
-
-		groupMemberMap := make(map[uint][]models.GroupMember)
-		for _, member := range members {
-			groupMemberMap[member.GroupID] = append(groupMemberMap[member.GroupID], member)
-		}
-
-		for _, group := range groups {
-			groupList = append(groupList, dto.ListMemberGroupsResponse{
-				Group:   group,
-				Members: groupMemberMap[group.ID],
-			})
-		}
+	resp := dto.ListMemberGroupsPage{Groups: []dto.ListMemberGroupsResponse{}, Pagination: page}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, dto.ListMemberGroupsResponse{
+			Group:   group,
+			Members: members[group.ID],
+			Budgets: orEmpty(budgets[group.ID]),
+		})
 	}
+
+	setCursorLinks(w, r, page)
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(groupList)
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
 func getGroupIDs(groups []models.Group) []uint {
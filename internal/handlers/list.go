@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"gorm.io/gorm"
+)
+
+// Sort key kinds, which decide how cursor values are read back.
+const (
+	sortTime = iota
+	sortNumber
+	sortText
+)
+
+type sortKey struct {
+	column string
+	kind   int
+}
+
+// listSpec describes what a list endpoint can be sorted and filtered on. Empty columns leave the
+// filter out; id is the unique column that breaks ties between rows with the same sort value.
+type listSpec struct {
+	id           string
+	sorts        map[string]sortKey // createdAt, amount and name
+	defaultSort  string             // with a leading - for descending
+	created      string
+	amount       string
+	name         string
+	status       string
+	statuses     []string
+	defaultLimit int
+	maxLimit     int
+}
+
+// listQuery is a parsed list request.
+type listQuery struct {
+	spec  listSpec
+	limit int
+	sort  string
+	desc  bool
+	after *listCursor
+	where []string
+	args  []any
+}
+
+// listCursor points after the last row of a page.
+type listCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// listRequest parses a list request with dates in the user's timezone, answering it when it is
+// invalid.
+func listRequest(w http.ResponseWriter, r *http.Request, spec listSpec) (listQuery, bool) {
+	loc, ok := requestTimezone(w, r)
+	if !ok {
+		return listQuery{}, false
+	}
+	lq, err := parseList(r, spec, loc)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return listQuery{}, false
+	}
+	return lq, true
+}
+
+// parseList reads the limit, cursor, sort and filter query parameters shared by list endpoints.
+// Dates are days in the timezone loc.
+func parseList(r *http.Request, spec listSpec, loc *time.Location) (listQuery, error) {
+	q := r.URL.Query()
+	lq := listQuery{spec: spec, limit: spec.defaultLimit}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > spec.maxLimit {
+			return lq, errors.ErrInvalidQueryParameter(fmt.Sprintf("limit must be between 1 and %d", spec.maxLimit))
+		}
+		lq.limit = limit
+	}
+
+	sort := q.Get("sort")
+	if sort == "" {
+		sort = spec.defaultSort
+	}
+	lq.desc = strings.HasPrefix(sort, "-")
+	lq.sort = strings.TrimPrefix(sort, "-")
+	if _, ok := spec.sorts[lq.sort]; !ok {
+		return lq, errors.ErrInvalidQueryParameter(fmt.Sprintf("sort must be one of %s, with a leading - for descending order", strings.Join(sortNames(spec), ", ")))
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(v)
+		var cursor listCursor
+		if err != nil || json.Unmarshal(raw, &cursor) != nil || cursor.Sort != sort {
+			return lq, errors.ErrInvalidQueryParameter("cursor is invalid or was issued for another sort order")
+		}
+		lq.after = &cursor
+	}
+
+	for _, f := range []struct {
+		param, column string
+		end           bool
+	}{{"from", spec.created, false}, {"to", spec.created, true}} {
+		v := q.Get(f.param)
+		if v == "" || f.column == "" {
+			continue
+		}
+		day, err := time.ParseInLocation("2006-01-02", v, loc)
+		if err != nil {
+			return lq, errors.ErrInvalidQueryParameter(fmt.Sprintf("%s must be a date in the format YYYY-MM-DD", f.param))
+		}
+		if f.end {
+			lq.filter(f.column+" < ?", day.AddDate(0, 0, 1))
+		} else {
+			lq.filter(f.column+" >= ?", day)
+		}
+	}
+	for _, f := range []struct{ param, op string }{{"minAmount", ">="}, {"maxAmount", "<="}} {
+		v := q.Get(f.param)
+		if v == "" || spec.amount == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return lq, errors.ErrInvalidQueryParameter(fmt.Sprintf("%s must be a number", f.param))
+		}
+		lq.filter(spec.amount+" "+f.op+" ?", amount)
+	}
+	if v := q.Get("status"); v != "" && spec.status != "" {
+		v = strings.ToUpper(v)
+		if !slices.Contains(spec.statuses, v) {
+			return lq, errors.ErrInvalidQueryParameter(fmt.Sprintf("status must be one of %s", strings.Join(spec.statuses, ", ")))
+		}
+		lq.filter(spec.status+" = ?", v)
+	}
+	if v := strings.TrimSpace(q.Get("q")); v != "" && spec.name != "" {
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(v)
+		lq.filter(spec.name+" ILIKE ?", "%"+escaped+"%")
+	}
+	return lq, nil
+}
+
+func (lq *listQuery) filter(condition string, arg any) {
+	lq.where = append(lq.where, condition)
+	lq.args = append(lq.args, arg)
+}
+
+// filtered applies the filters only, for totals over every page.
+func (lq listQuery) filtered(query *gorm.DB) *gorm.DB {
+	for i, condition := range lq.where {
+		query = query.Where(condition, lq.args[i])
+	}
+	return query
+}
+
+// page applies the filters, the cursor, the sort order and the limit. One row more than the limit is
+// asked for to learn whether there is a next page.
+func (lq listQuery) page(query *gorm.DB) (*gorm.DB, error) {
+	query = lq.filtered(query)
+	key := lq.spec.sorts[lq.sort]
+	direction, op := "ASC", ">"
+	if lq.desc {
+		direction, op = "DESC", "<"
+	}
+	if lq.after != nil {
+		value, err := key.parse(lq.after.Value)
+		if err != nil {
+			return nil, errors.ErrInvalidQueryParameter("cursor is invalid or was issued for another sort order")
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", key.column, lq.spec.id, op), value, lq.after.ID)
+	}
+	return query.Order(fmt.Sprintf("%s %s, %s %s", key.column, direction, lq.spec.id, direction)).Limit(lq.limit + 1), nil
+}
+
+// pageSlice is page for a list built in memory, whose rows are given in ascending order of their
+// sort value and id. Filters are left to the caller.
+func pageSlice[T any](lq listQuery, rows []T, key func(T) sortValue) ([]T, error) {
+	if lq.desc {
+		rows = slices.Clone(rows)
+		slices.Reverse(rows)
+	}
+	start := 0
+	if lq.after != nil {
+		value, err := lq.spec.sorts[lq.sort].parse(lq.after.Value)
+		if err != nil {
+			return nil, errors.ErrInvalidQueryParameter("cursor is invalid or was issued for another sort order")
+		}
+		after := sortValue{id: lq.after.ID}
+		switch v := value.(type) {
+		case time.Time:
+			after.time = v
+		case float64:
+			after.number = v
+		case string:
+			after.text = v
+		}
+		for start < len(rows) {
+			c := lq.compare(key(rows[start]), after)
+			if (!lq.desc && c > 0) || (lq.desc && c < 0) {
+				break
+			}
+			start++
+		}
+	}
+	return rows[start:min(start+lq.limit+1, len(rows))], nil
+}
+
+func (k sortKey) parse(value string) (any, error) {
+	switch k.kind {
+	case sortTime:
+		return time.Parse(time.RFC3339Nano, value)
+	case sortNumber:
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
+
+// sortValue is the value of a row for the sort, with its id. format writes it for a cursor.
+type sortValue struct {
+	time   time.Time
+	number float64
+	text   string
+	id     uint
+}
+
+func (lq listQuery) format(v sortValue) string {
+	switch lq.spec.sorts[lq.sort].kind {
+	case sortTime:
+		return v.time.UTC().Format(time.RFC3339Nano)
+	case sortNumber:
+		return strconv.FormatFloat(v.number, 'f', -1, 64)
+	}
+	return v.text
+}
+
+// compare orders two sort values by the sort of the list, then by id.
+func (lq listQuery) compare(a, b sortValue) int {
+	var c int
+	switch lq.spec.sorts[lq.sort].kind {
+	case sortTime:
+		c = a.time.Compare(b.time)
+	case sortNumber:
+		c = cmp.Compare(a.number, b.number)
+	default:
+		c = strings.Compare(a.text, b.text)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// pageOf trims the extra row fetched by page and returns the pagination of the rows, whose sort
+// values are given by key.
+func pageOf[T any](lq listQuery, rows []T, key func(T) sortValue) ([]T, dto.Pagination) {
+	sort := lq.sort
+	if lq.desc {
+		sort = "-" + sort
+	}
+	p := dto.Pagination{Limit: lq.limit, Sort: sort}
+	if len(rows) > lq.limit {
+		rows = rows[:lq.limit]
+		last := key(rows[len(rows)-1])
+		raw, _ := json.Marshal(listCursor{Sort: sort, Value: lq.format(last), ID: last.id})
+		p.NextCursor = base64.RawURLEncoding.EncodeToString(raw)
+		p.HasMore = true
+	}
+	return rows, p
+}
+
+// setCursorLinks adds Link headers to the first page and, when there is one, the next page.
+func setCursorLinks(w http.ResponseWriter, r *http.Request, p dto.Pagination) {
+	first := cloneQuery(r.URL.Query())
+	first.Del("cursor")
+	links := []string{pageLink(r, first, "first")}
+	if p.NextCursor != "" {
+		next := cloneQuery(first)
+		next.Set("cursor", p.NextCursor)
+		links = append(links, pageLink(r, next, "next"))
+	}
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
+
+func pageLink(r *http.Request, q url.Values, rel string) string {
+	u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+}
+
+func cloneQuery(q url.Values) url.Values {
+	c := make(url.Values, len(q))
+	for k, v := range q {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+func sortNames(spec listSpec) []string {
+	names := make([]string, 0, len(spec.sorts))
+	for _, name := range []string{"createdAt", "amount", "name"} {
+		if _, ok := spec.sorts[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
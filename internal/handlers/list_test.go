@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	apierrors "github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+)
+
+func TestParseList(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSort := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"amount","v":"10","id":3}`))
+
+	tests := []struct {
+		name    string
+		spec    listSpec
+		query   string
+		limit   int
+		sort    string
+		desc    bool
+		where   []string
+		args    []any
+		wantErr bool
+	}{
+		{name: "defaults", spec: groupListSpec, limit: 20, sort: "createdAt", desc: true},
+		{name: "limit", spec: groupListSpec, query: "limit=100&sort=name", limit: 100, sort: "name"},
+		{name: "descending", spec: groupListSpec, query: "sort=-amount", limit: 20, sort: "amount", desc: true},
+		{name: "limit zero", spec: groupListSpec, query: "limit=0", wantErr: true},
+		{name: "limit too large", spec: groupListSpec, query: "limit=101", wantErr: true},
+		{name: "limit not a number", spec: groupListSpec, query: "limit=ten", wantErr: true},
+		{name: "unknown sort", spec: groupListSpec, query: "sort=status", wantErr: true},
+		{name: "cursor not base64", spec: groupListSpec, query: "cursor=%25%25", wantErr: true},
+		{name: "cursor not json", spec: groupListSpec, query: "cursor=" + base64.RawURLEncoding.EncodeToString([]byte("amount")), wantErr: true},
+		{name: "cursor of another sort", spec: groupListSpec, query: "sort=-amount&cursor=" + otherSort, wantErr: true},
+		{name: "cursor of the sort", spec: groupListSpec, query: "sort=amount&cursor=" + otherSort, limit: 20, sort: "amount"},
+		{
+			name:  "days in the user's timezone",
+			spec:  groupListSpec,
+			query: "from=2024-03-01&to=2024-03-31",
+			limit: 20, sort: "createdAt", desc: true,
+			where: []string{"groups.created_at >= ?", "groups.created_at < ?"},
+			args:  []any{time.Date(2024, 3, 1, 0, 0, 0, 0, kolkata), time.Date(2024, 4, 1, 0, 0, 0, 0, kolkata)},
+		},
+		{name: "bad date", spec: groupListSpec, query: "from=01/03/2024", wantErr: true},
+		{
+			name:  "amounts",
+			spec:  groupListSpec,
+			query: "minAmount=10&maxAmount=99.5",
+			limit: 20, sort: "createdAt", desc: true,
+			where: []string{"groups.total_amount >= ?", "groups.total_amount <= ?"},
+			args:  []any{10.0, 99.5},
+		},
+		{name: "bad amount", spec: groupListSpec, query: "minAmount=lots", wantErr: true},
+		{
+			name:  "status",
+			spec:  groupListSpec,
+			query: "status=done",
+			limit: 20, sort: "createdAt", desc: true,
+			where: []string{"groups.status = ?"},
+			args:  []any{"DONE"},
+		},
+		{name: "unknown status", spec: groupListSpec, query: "status=open", wantErr: true},
+		{
+			name:  "search escapes wildcards",
+			spec:  groupListSpec,
+			query: "q=" + url.QueryEscape(` 50%_off\ `),
+			limit: 20, sort: "createdAt", desc: true,
+			where: []string{"groups.name ILIKE ?"},
+			args:  []any{`%50\%\_off\\%`},
+		},
+		{name: "filter the spec lacks", spec: pendingPaymentSpec, query: "status=bogus", limit: 20, sort: "createdAt", desc: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/v1/groups?"+tt.query, nil)
+			lq, err := parseList(r, tt.spec, kolkata)
+			if tt.wantErr {
+				var apiErr *apierrors.Error
+				if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_QUERY_PARAMETER" {
+					t.Errorf("parseList() error = %v, want an invalid query parameter error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseList() error = %v", err)
+			}
+			if lq.limit != tt.limit || lq.sort != tt.sort || lq.desc != tt.desc {
+				t.Errorf("parseList() = limit %d, sort %q, desc %v, want %d, %q, %v", lq.limit, lq.sort, lq.desc, tt.limit, tt.sort, tt.desc)
+			}
+			if !reflect.DeepEqual(lq.where, tt.where) || !reflect.DeepEqual(lq.args, tt.args) {
+				t.Errorf("filters = %q %v, want %q %v", lq.where, lq.args, tt.where, tt.args)
+			}
+		})
+	}
+}
+
+// listRow is a minimal groups table for paging through.
+type listRow struct {
+	ID          uint
+	CreatedAt   time.Time
+	TotalAmount float64
+	Name        string
+	Status      string
+}
+
+func (listRow) TableName() string { return "groups" }
+
+func (r listRow) sortValue() sortValue {
+	return sortValue{time: r.CreatedAt, number: r.TotalAmount, text: r.Name, id: r.ID}
+}
+
+// TestCursorPages walks every sort order page by page and checks that each row shows up once, in
+// order, also when rows share a sort value.
+func TestCursorPages(t *testing.T) {
+	conn := dbtest.Open(t, &listRow{})
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	rows := []listRow{
+		{CreatedAt: start, TotalAmount: 30, Name: "Trip"},
+		{CreatedAt: start.Add(time.Hour), TotalAmount: 10, Name: "Rent"},
+		{CreatedAt: start.Add(time.Hour), TotalAmount: 30, Name: "Dinner"},
+		{CreatedAt: start.Add(90 * time.Minute), TotalAmount: 12.5, Name: "Trip"},
+		{CreatedAt: start.Add(2*time.Hour + time.Millisecond), TotalAmount: 30, Name: "Bills"},
+		{CreatedAt: start.Add(3 * time.Hour), TotalAmount: 0.1, Name: "Coffee"},
+		{CreatedAt: start.Add(3*time.Hour + 500*time.Microsecond), TotalAmount: 99, Name: "Trip"},
+	}
+	if err := conn.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sortParam := range []string{"createdAt", "-createdAt", "amount", "-amount", "name", "-name"} {
+		for _, limit := range []int{1, 2, 3, 7, 10} {
+			t.Run(fmt.Sprintf("%s by %d", sortParam, limit), func(t *testing.T) {
+				want := expectedOrder(rows, sortParam)
+				var got []uint
+				cursor := ""
+				for pages := 0; ; pages++ {
+					if pages > len(rows) {
+						t.Fatalf("still paging after %d pages, got %v", pages, got)
+					}
+					q := url.Values{"sort": {sortParam}, "limit": {fmt.Sprint(limit)}}
+					if cursor != "" {
+						q.Set("cursor", cursor)
+					}
+					lq, err := parseList(httptest.NewRequest("GET", "/v1/groups?"+q.Encode(), nil), groupListSpec, time.UTC)
+					if err != nil {
+						t.Fatal(err)
+					}
+					query, err := lq.page(conn.Model(&listRow{}))
+					if err != nil {
+						t.Fatal(err)
+					}
+					var page []listRow
+					if err := query.Find(&page).Error; err != nil {
+						t.Fatal(err)
+					}
+					page, p := pageOf(lq, page, listRow.sortValue)
+					if p.Sort != sortParam || p.Limit != limit || p.HasMore != (p.NextCursor != "") {
+						t.Errorf("pagination %+v does not match the request", p)
+					}
+					for _, row := range page {
+						got = append(got, row.ID)
+					}
+					if !p.HasMore {
+						break
+					}
+					cursor = p.NextCursor
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("pages listed %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+// expectedOrder sorts rows as the list query should: by the sort value, then by id, both reversed
+// for a descending sort.
+func expectedOrder(rows []listRow, sortParam string) []uint {
+	sorted := append([]listRow(nil), rows...)
+	desc := sortParam[0] == '-'
+	less := func(a, b listRow) bool {
+		switch sortParam {
+		case "createdAt", "-createdAt":
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case "amount", "-amount":
+			if a.TotalAmount != b.TotalAmount {
+				return a.TotalAmount < b.TotalAmount
+			}
+		default:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if desc {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+	ids := make([]uint, len(sorted))
+	for i, row := range sorted {
+		ids[i] = row.ID
+	}
+	return ids
+}
+
+// TestSlicePages walks a list built in memory page by page in both directions.
+func TestSlicePages(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	rows := []listRow{
+		{ID: 1, CreatedAt: start},
+		{ID: 2, CreatedAt: start.Add(time.Hour)},
+		{ID: 3, CreatedAt: start.Add(time.Hour)},
+		{ID: 4, CreatedAt: start.Add(time.Hour + time.Nanosecond)},
+		{ID: 5, CreatedAt: start.Add(2 * time.Hour)},
+	}
+	spec := listSpec{sorts: map[string]sortKey{"createdAt": {kind: sortTime}}, defaultSort: "createdAt", defaultLimit: 20, maxLimit: 100}
+
+	for _, sortParam := range []string{"createdAt", "-createdAt"} {
+		for _, limit := range []int{1, 2, 5, 6} {
+			t.Run(fmt.Sprintf("%s by %d", sortParam, limit), func(t *testing.T) {
+				want := expectedOrder(rows, sortParam)
+				var got []uint
+				cursor := ""
+				for pages := 0; ; pages++ {
+					if pages > len(rows) {
+						t.Fatalf("still paging after %d pages, got %v", pages, got)
+					}
+					q := url.Values{"sort": {sortParam}, "limit": {fmt.Sprint(limit)}}
+					if cursor != "" {
+						q.Set("cursor", cursor)
+					}
+					lq, err := parseList(httptest.NewRequest("GET", "/v1/groups/1/activity?"+q.Encode(), nil), spec, time.UTC)
+					if err != nil {
+						t.Fatal(err)
+					}
+					page, err := pageSlice(lq, rows, listRow.sortValue)
+					if err != nil {
+						t.Fatal(err)
+					}
+					page, p := pageOf(lq, page, listRow.sortValue)
+					for _, row := range page {
+						got = append(got, row.ID)
+					}
+					if !p.HasMore {
+						break
+					}
+					cursor = p.NextCursor
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("pages listed %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestPageRejectsForgedCursor(t *testing.T) {
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"amount","v":"1; DROP TABLE groups","id":3}`))
+	lq, err := parseList(httptest.NewRequest("GET", "/v1/groups?sort=amount&cursor="+forged, nil), groupListSpec, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lq.page(nil); err == nil {
+		t.Error("page() accepted a cursor whose value is not an amount")
+	}
+}
+
+func TestPageLinks(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v1/groups/member-groups?limit=2&sort=name&cursor=old", nil)
+	w := httptest.NewRecorder()
+	setCursorLinks(w, r, dto.Pagination{NextCursor: "next"})
+	if got, want := w.Header().Get("Link"), `</v1/groups/member-groups?limit=2&sort=name>; rel="first", </v1/groups/member-groups?cursor=next&limit=2&sort=name>; rel="next"`; got != want {
+		t.Errorf("cursor links = %s, want %s", got, want)
+	}
+	w = httptest.NewRecorder()
+	setCursorLinks(w, r, dto.Pagination{})
+	if got, want := w.Header().Get("Link"), `</v1/groups/member-groups?limit=2&sort=name>; rel="first"`; got != want {
+		t.Errorf("links of the last page = %s, want %s", got, want)
+	}
+
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListNotifications lists the current user's notifications
+// @Summary List notifications
+// @Description Returns the current user's notifications, newest first, together with the unread count.
+// @Tags notifications
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Created on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Created on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Success 200 {object} dto.NotificationListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/notifications [get]
+func ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	lq, ok := listRequest(w, r, notificationListSpec)
+	if !ok {
+		return
+	}
+
+	base := db.GetDb().Model(&models.Notification{}).Where("user_id = ?", userId).Session(&gorm.Session{})
+	resp := dto.NotificationListResponse{Notifications: []models.Notification{}}
+	if err := base.Where("read_at IS NULL").Count(&resp.UnreadCount).Error; err != nil {
+		log.Error("Failed to count unread notifications", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	query := base
+	if r.URL.Query().Get("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := lq.filtered(query).Count(&resp.Total).Error; err != nil {
+		log.Error("Failed to count notifications", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	query, err := lq.page(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	if err := query.Find(&resp.Notifications).Error; err != nil {
+		log.Error("Failed to fetch notifications", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	resp.Notifications, resp.Pagination = pageOf(lq, resp.Notifications, func(n models.Notification) sortValue {
+		return sortValue{time: n.CreatedAt, id: n.ID}
+	})
+
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// notificationListSpec is how notifications are sorted and filtered.
+var notificationListSpec = listSpec{
+	id:           "id",
+	sorts:        map[string]sortKey{"createdAt": {"created_at", sortTime}},
+	defaultSort:  "-createdAt",
+	created:      "created_at",
+	defaultLimit: 20,
+	maxLimit:     100,
+}
+
+// MarkNotificationRead marks a notification as read
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Notification ID"
+// @Success 200 {object} map[string]string "Notification marked as read"
+// @Failure 404 {object} errors.Error "Notification not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/notifications/{id}/read [post]
+func MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	var notification models.Notification
+	if err := db.GetDb().Where("id = ? AND user_id = ?", chi.URLParam(r, "id"), userId).First(&notification).Error; err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrNotificationNotFound)
+		return
+	}
+	if notification.ReadAt == nil {
+		if err := db.GetDb().Model(&notification).Update("read_at", time.Now()).Error; err != nil {
+			log.Error("Failed to mark notification as read", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Notification marked as read"})
+}
+
+// MarkAllNotificationsRead marks every notification of the user as read
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} map[string]string "Notifications marked as read"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/notifications/read-all [post]
+func MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	result := db.GetDb().Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userId).Update("read_at", time.Now())
+	if result.Error != nil {
+		log.Error("Failed to mark notifications as read", zap.Error(result.Error))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": fmt.Sprintf("%d notification(s) marked as read", result.RowsAffected)})
+}
+
+// GetNotificationPreferences returns which events the user is notified about
+// @Summary Get notification preferences
+// @Tags notifications
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dto.NotificationPreferences
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/notifications/preferences [get]
+func GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	writeNotificationPreferences(w, middleware.GetCurrentUserId(r))
+}
+
+// UpdateNotificationPreferences changes which events the user is notified about
+// @Summary Update notification preferences
+// @Description Enables or disables notifications per event type. Event types left out keep their current setting.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dto.NotificationPreferences true "Preferences"
+// @Success 200 {object} dto.NotificationPreferences
+// @Failure 400 {object} errors.Error "Unknown event type"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/notifications/preferences [put]
+func UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var input dto.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	if err := validate.ValidateStruct(input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+
+	userId := middleware.GetCurrentUserId(r)
+	prefs := make([]models.NotificationPreference, 0, len(input.Preferences))
+	for eventType, enabled := range input.Preferences {
+		if !slices.Contains(events.Types, eventType) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(fmt.Sprintf("unknown event type '%s'", eventType)))
+			return
+		}
+		prefs = append(prefs, models.NotificationPreference{UserID: uint(userId), EventType: eventType, Enabled: enabled})
+	}
+
+	if len(prefs) > 0 {
+		err := db.GetDb().Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+		}).Create(&prefs).Error
+		if err != nil {
+			log.Error("Failed to save notification preferences", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+			return
+		}
+	}
+	writeNotificationPreferences(w, userId)
+}
+
+func writeNotificationPreferences(w http.ResponseWriter, userId float64) {
+	var stored []models.NotificationPreference
+	if err := db.GetDb().Where("user_id = ?", userId).Find(&stored).Error; err != nil {
+		log.Error("Failed to fetch notification preferences", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	resp := dto.NotificationPreferences{Preferences: make(map[string]bool, len(events.Types))}
+	for _, eventType := range events.Types {
+		resp.Preferences[eventType] = true
+	}
+	for _, pref := range stored {
+		resp.Preferences[pref.EventType] = pref.Enabled
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -2,15 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
-	e "errors"
 	"net/http"
 
-	"github.com/mohdjishin/SplitWise/helper"
 	"github.com/mohdjishin/SplitWise/helper/validate"
 	"github.com/mohdjishin/SplitWise/internal/db"
 	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
 	"github.com/mohdjishin/SplitWise/internal/middleware"
-	"github.com/mohdjishin/SplitWise/internal/models"
 	"github.com/mohdjishin/SplitWise/internal/models/dto"
 	log "github.com/mohdjishin/SplitWise/logger"
 	"go.uber.org/zap"
@@ -44,7 +42,6 @@ func MarkPayment(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Debug("MarkPayment request", zap.Any("request", input))
 
-	userID := middleware.GetCurrentUserId(r)
 	if err := validate.ValidateStruct(input); err != nil {
 
 		log.Error("Error validating request body", zap.Error(err))
@@ -53,147 +50,111 @@ func MarkPayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var groupMember models.GroupMember
-	if err := db.GetDb().Where("group_id = ? AND user_id = ?", input.GroupID, userID).First(&groupMember).Error; err != nil {
-		log.Error("Group member not found", zap.Error(err))
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
+	if _, err := ledger.MarkPayment(r.Context(), uint(middleware.GetCurrentUserId(r)), input.GroupID, input.Remarks); err != nil {
+		writeLedgerError(w, err)
 		return
 	}
 
-	if groupMember.HasPaid {
-		log.Warn("Payment already made by user", zap.Float64("user_id", userID))
-		w.WriteHeader(http.StatusConflict)
-		_ = json.NewEncoder(w).Encode(errors.ErrPaymentAlreadyMade)
-		return
-	}
-
-	groupMember.HasPaid = true
-	groupMember.Remarks = input.Remarks
-	if err := db.GetDb().Save(&groupMember).Error; err != nil {
-		log.Error("Failed to update payment status", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrPaymentFailed)
-		return
-	}
-
-	var group models.Group
-	if err := db.GetDb().Where("id = ?", input.GroupID).First(&group).Error; err != nil {
-		log.Error("Group not found", zap.Error(err))
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
-		return
-	}
-
-	group.PaidAmount += groupMember.SplitAmount
-
-	if group.PaidAmount >= group.TotalAmount {
-		var bill models.Bill
-		if err := db.GetDb().Where("id = ?", group.BillID).First(&bill).Error; err == nil {
-			bill.Completed = true
-
-			if err := db.GetDb().Save(&bill).Error; err != nil {
-				log.Error("Failed to mark bill as completed", zap.Error(err))
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(errors.ErrBillCompletionFailed)
-				return
-			}
-		}
-		group.Status = done
-	}
-
-	if err := db.GetDb().Save(&group).Error; err != nil {
-		log.Error("Failed to update group paid amount", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrGroupUpdateFailed)
-		return
-	}
-	var user models.User
-	if err := db.GetDb().Select("name").Where("id = ?", userID).First(&user).Error; err != nil {
-		if e.Is(err, gorm.ErrRecordNotFound) {
-			log.Warn("User not found", zap.Float64("userID", userID))
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(errors.ErrUserNotFound)
-		} else {
-			log.Error("Error retrieving user", zap.Error(err))
-			json.NewEncoder(w).Encode(errors.ErrInternalError)
-		}
-		return
-	}
-	_ = helper.LogBillHistory(group.BillID, groupMember.SplitAmount, user.Name)
-
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(dto.MarkPaymentResponse{Message: "Payment marked successfully"})
 }
 
-// GetPendingPayments retrieves all pending payments associated with the authenticated user.
+// GetPendingPayments retrieves the pending payments of the authenticated user.
 // @Summary Retrieve Pending Payments
-// @Description Fetches all pending payments for the current user that have not been paid yet, including group ID, group name, bill ID, and amount owed.
+// @Description Fetches a page of the payments the current user has not made yet, including group ID, group name, bill ID and the amount owed. totalAmount is the sum owed over every page matching the filters. Pages are walked with the nextCursor of the response, which is also linked in the Link header.
 // @Tags payments
 // @Accept json
 // @Produce json
-// @Success 200 {object} dto.PendingPaymentsWithTotalResponse "Successful response containing the list of pending payments and total amount."
-// @Failure 404 {object} errors.Error "No pending payments found for the user."
+// @Param limit query int false "Payments per page, 20 by default and at most 100"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, amount or name (of the group), with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Group created on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Group created on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Param minAmount query number false "Smallest amount owed"
+// @Param maxAmount query number false "Largest amount owed"
+// @Param q query string false "Part of the group name, ignoring case"
+// @Success 200 {object} dto.PendingPaymentsWithTotalResponse "Successful response containing the page of pending payments and total amount."
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter provided."
 // @Failure 500 {object} errors.Error "Internal server error occurred while fetching pending payments."
-// @Router /v1/payments/pending-payments [get]
+// @Router /v1/payments/pending [get]
 func GetPendingPayments(w http.ResponseWriter, r *http.Request) {
 	userId := middleware.GetCurrentUserId(r)
-	var groupMembers []models.GroupMember
 	log.Debug("GetPendingPayments request", zap.Any("userId", userId))
-	if err := db.GetDb().Where("user_id = ? AND has_paid = ?", userId, false).Find(&groupMembers).Error; err != nil {
-		if e.Is(err, gorm.ErrRecordNotFound) {
-			log.Warn("No pending payments found", zap.Float64("userId", userId))
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(errors.ErrNoPendingPayments)
-		} else {
-			log.Error("Failed to fetch pending payments", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		}
+	loc, ok := requestTimezone(w, r)
+	if !ok {
 		return
 	}
+	lq, err := parseList(r, pendingPaymentSpec, loc)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+
+	query := db.GetDb().Table("group_members").
+		Joins("JOIN groups ON groups.id = group_members.group_id AND groups.deleted_at IS NULL").
+		Joins("LEFT JOIN bills ON bills.id = groups.bill_id").
+		Where("group_members.user_id = ? AND group_members.has_paid = ? AND group_members.deleted_at IS NULL", userId, false).
+		Session(&gorm.Session{})
 
-	log.Debug("GetPendingPayments request", zap.Any("groupMembers", groupMembers))
-	var pendingPayments []dto.PendingPayments
-	totalAmount := 0.0
-
-	for _, member := range groupMembers {
-		var group models.Group
-		if err := db.GetDb().Where("id = ?", member.GroupID).First(&group).Error; err != nil {
-			log.Error("Failed to fetch group", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-			return
-		}
-
-		var bill models.Bill
-		if err := db.GetDb().Where("id = ?", group.BillID).First(&bill).Error; err != nil {
-			log.Error("Failed to fetch bill", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-			return
-		}
-
-		pendingPayments = append(pendingPayments, dto.PendingPayments{
-			GroupID:   group.ID,
-			GroupName: group.Name,
-			BillID:    bill.ID,
-			Amount:    bill.Amount,
-		})
-		totalAmount += bill.Amount
+	response := dto.PendingPaymentsWithTotalResponse{PendingPayments: []dto.PendingPayments{}}
+	if err := lq.filtered(query).Select("COALESCE(SUM(group_members.split_amount), 0)").Scan(&response.TotalAmount).Error; err != nil {
+		log.Error("Failed to total pending payments", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
 	}
-	response := dto.PendingPaymentsWithTotalResponse{
-		PendingPayments: pendingPayments,
-		TotalAmount:     totalAmount,
-		Message:         "",
+	paged, err := lq.page(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
 	}
-	if len(pendingPayments) == 0 {
+	var rows []pendingPaymentRow
+	if err := paged.Select("group_members.id, groups.id AS group_id, groups.name AS group_name, groups.bill_id, " +
+		"group_members.split_amount AS amount, COALESCE(bills.amount, 0) AS bill_amount, groups.created_at").
+		Scan(&rows).Error; err != nil {
+		log.Error("Failed to fetch pending payments", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	rows, response.Pagination = pageOf(lq, rows, func(p pendingPaymentRow) sortValue {
+		return sortValue{time: p.CreatedAt, number: p.Amount, text: p.GroupName, id: p.ID}
+	})
+	for _, row := range rows {
+		response.PendingPayments = append(response.PendingPayments, row.PendingPayments)
+	}
+	if len(response.PendingPayments) == 0 {
 		response.Message = "No pending payments found"
-		// response.TotalAmount = 0
 	}
 
 	log.Debug("GetPendingPayments request", zap.Any("response", response))
 
+	setCursorLinks(w, r, response.Pagination)
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 }
+
+// pendingPaymentRow is a pending payment with the id of the membership it is owed by.
+type pendingPaymentRow struct {
+	ID uint
+	dto.PendingPayments
+}
+
+// pendingPaymentSpec is how pending payments are sorted and filtered.
+var pendingPaymentSpec = listSpec{
+	id: "group_members.id",
+	sorts: map[string]sortKey{
+		"createdAt": {"groups.created_at", sortTime},
+		"amount":    {"group_members.split_amount", sortNumber},
+		"name":      {"groups.name", sortText},
+	},
+	defaultSort:  "-createdAt",
+	created:      "groups.created_at",
+	amount:       "group_members.split_amount",
+	name:         "groups.name",
+	defaultLimit: 20,
+	maxLimit:     100,
+}
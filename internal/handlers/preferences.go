@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	e "errors"
+	"net/http"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// GetPreferences returns the formatting preferences of the user
+// @Summary Get locale and timezone preferences
+// @Tags account
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dto.PreferencesResponse
+// @Failure 404 {object} errors.Error "User not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/preferences [get]
+func GetPreferences(w http.ResponseWriter, r *http.Request) {
+	var user models.User
+	if err := db.GetDb().Select("id", "locale", "timezone").Where("id = ?", middleware.GetCurrentUserId(r)).First(&user).Error; err != nil {
+		writeUserLookupError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(preferencesResponse(user))
+}
+
+// UpdatePreferences changes the formatting preferences of the user
+// @Summary Update locale and timezone preferences
+// @Description Sets the locale amounts and dates in reports are written in, such as 1.234,56 € for de-DE or ₹1,23,456.00 for en-IN, and the timezone report date ranges are read in and times are shown in. A report template can still set its own locale. The closest supported locale is picked, see supportedLocales in the response.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dto.UpdatePreferencesRequest true "Preferences"
+// @Success 200 {object} dto.PreferencesResponse
+// @Failure 400 {object} errors.Error "Unsupported locale or unknown timezone"
+// @Failure 404 {object} errors.Error "User not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/preferences [put]
+func UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var input dto.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+
+	userId := middleware.GetCurrentUserId(r)
+	var user models.User
+	if err := db.GetDb().Select("id", "locale", "timezone").Where("id = ?", userId).First(&user).Error; err != nil {
+		writeUserLookupError(w, err)
+		return
+	}
+	before := models.User{ID: user.ID, Locale: user.Locale, Timezone: user.Timezone}
+
+	if input.Locale != nil {
+		user.Locale = ""
+		if *input.Locale != "" {
+			_, tag, err := layout.LocaleFor(*input.Locale)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+				return
+			}
+			user.Locale = tag
+		}
+	}
+	if input.Timezone != nil {
+		// time.LoadLocation accepts "Local", which would mean the server's timezone.
+		if _, err := time.LoadLocation(*input.Timezone); err != nil || *input.Timezone == "Local" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("timezone must be an IANA timezone such as Europe/Berlin"))
+			return
+		}
+		user.Timezone = *input.Timezone
+		if user.Timezone == "UTC" {
+			user.Timezone = ""
+		}
+	}
+
+	if err := db.GetDb().Model(&user).Updates(map[string]any{"locale": user.Locale, "timezone": user.Timezone}).Error; err != nil {
+		log.Error("Failed to save preferences", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionPreferencesUpdated, EntityType: audit.EntityAccount, EntityID: user.ID,
+		Before: map[string]string{"locale": before.Locale, "timezone": before.Timezone},
+		After:  map[string]string{"locale": user.Locale, "timezone": user.Timezone}})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(preferencesResponse(user))
+}
+
+func preferencesResponse(user models.User) dto.PreferencesResponse {
+	locale, loc := ledger.PreferredLocale(user)
+	timezone := loc.String()
+	return dto.PreferencesResponse{
+		Locale:    user.Locale,
+		Timezone:  timezone,
+		Example:   locale.Money(1234567.89) + ", " + locale.Stamp(time.Now()),
+		Supported: layout.Locales(),
+	}
+}
+
+func writeUserLookupError(w http.ResponseWriter, err error) {
+	if e.Is(err, gorm.ErrRecordNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrUserNotFound)
+		return
+	}
+	log.Error("Failed to fetch user", zap.Error(err))
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+}
+
+// requestTimezone returns the timezone of the current user, answering the request when it cannot be loaded.
+func requestTimezone(w http.ResponseWriter, r *http.Request) (*time.Location, bool) {
+	_, loc, err := ledger.UserLocale(db.GetDb(), uint(middleware.GetCurrentUserId(r)))
+	if err != nil {
+		writeUserLookupError(w, err)
+		return nil, false
+	}
+	return loc, true
+}
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"github.com/mohdjishin/SplitWise/internal/reminder"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetReminderSchedule returns the reminder schedule of a group
+// @Summary Get the reminder schedule of a group
+// @Description Returns the automatic payment reminder schedule of the group. Groups without a schedule get a disabled default.
+// @Tags reminders
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Success 200 {object} dto.ReminderScheduleResponse
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/reminders/schedule [get]
+func GetReminderSchedule(w http.ResponseWriter, r *http.Request) {
+	member, ok := requireGroupMember(w, chi.URLParam(r, "id"), middleware.GetCurrentUserId(r))
+	if !ok {
+		return
+	}
+	schedule, err := reminder.ScheduleFor(member.GroupID)
+	if err != nil {
+		log.Error("Failed to fetch reminder schedule", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reminderScheduleResponse(schedule))
+}
+
+// UpdateReminderSchedule configures automatic reminders of a group
+// @Summary Configure the reminder schedule of a group
+// @Description Sets when unpaid members are reminded automatically and through which channels (inapp, email, webhook). Only the group owner can change it.
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param request body dto.ReminderScheduleRequest true "Schedule"
+// @Success 200 {object} dto.ReminderScheduleResponse
+// @Failure 400 {object} errors.Error "Invalid schedule"
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/reminders/schedule [put]
+func UpdateReminderSchedule(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+
+	var input dto.ReminderScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	if err := validate.ValidateStruct(input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+	if input.FirstAfterDays < 0 || input.RepeatEveryDays < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("firstAfterDays and repeatEveryDays must not be negative"))
+		return
+	}
+	available := reminder.Channels()
+	for _, name := range input.Channels {
+		if !slices.Contains(available, name) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(fmt.Sprintf("unknown channel '%s', expected one of %v", name, available)))
+			return
+		}
+	}
+
+	before, err := reminder.ScheduleFor(group.ID)
+	if err != nil {
+		log.Error("Failed to fetch reminder schedule", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	schedule := models.ReminderSchedule{
+		GroupID:         group.ID,
+		UpdatedBy:       uint(userId),
+		Enabled:         input.Enabled,
+		FirstAfterDays:  input.FirstAfterDays,
+		RepeatEveryDays: input.RepeatEveryDays,
+		Channels:        input.Channels,
+	}
+	if err := db.GetDb().Clauses(clause.OnConflict{UpdateAll: true}).Create(&schedule).Error; err != nil {
+		log.Error("Failed to save reminder schedule", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionScheduleUpdated, EntityType: audit.EntitySchedule, EntityID: group.ID, GroupID: group.ID, Before: before, After: schedule})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reminderScheduleResponse(schedule))
+}
+
+// NudgeMember sends an immediate reminder to an unpaid member
+// @Summary Nudge an unpaid member
+// @Description Sends a payment reminder to the member right away through the group's reminder channels. Only the group owner can nudge, and each member at most once per cooldown period.
+// @Tags reminders
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param userId path int true "User ID of the member"
+// @Success 201 {array} dto.ReminderResponse
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group or member not found"
+// @Failure 409 {object} errors.Error "Member has already paid"
+// @Failure 429 {object} errors.Error "Member was nudged recently"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/members/{userId}/nudge [post]
+func NudgeMember(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+
+	var member models.GroupMember
+	if err := db.GetDb().Where("group_id = ? AND user_id = ?", group.ID, chi.URLParam(r, "userId")).First(&member).Error; err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrMemberNotFound)
+		return
+	}
+	if member.HasPaid {
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(errors.ErrNothingToRemind)
+		return
+	}
+
+	schedule, err := reminder.ScheduleFor(group.ID)
+	if err != nil {
+		log.Error("Failed to fetch reminder schedule", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	next, err := reminder.ClaimNudge(member.ID)
+	if err != nil {
+		log.Error("Failed to check nudge cooldown", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	if !next.IsZero() {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(next).Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		_ = json.NewEncoder(w).Encode(errors.ErrNudgeCooldown)
+		return
+	}
+
+	reminders, err := reminder.Send(r.Context(), group, member, models.ReminderNudge, uint(userId), schedule.Channels)
+	if err != nil {
+		log.Error("Failed to send nudge", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), uint(userId), audit.Entry{Action: audit.ActionMemberNudged, EntityType: audit.EntityMember, EntityID: member.ID, GroupID: group.ID, After: reminders})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(reminderResponses(reminders))
+}
+
+// ListReminders lists the reminders sent in a group
+// @Summary List reminders of a group
+// @Description Lists scheduled reminders and nudges sent in the group, newest first. The owner sees every reminder, other members only their own.
+// @Tags reminders
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Sent on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Sent on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Success 200 {object} dto.ReminderListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/reminders [get]
+func ListReminders(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	member, ok := requireGroupMember(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+	lq, ok := listRequest(w, r, reminderListSpec)
+	if !ok {
+		return
+	}
+
+	var group models.Group
+	if err := db.GetDb().Select("id", "created_by").Where("id = ?", member.GroupID).First(&group).Error; err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
+		return
+	}
+	query := db.GetDb().Model(&models.Reminder{}).Where("group_id = ?", group.ID)
+	if group.CreatedBy != uint(userId) {
+		query = query.Where("user_id = ?", userId)
+	}
+	query = query.Session(&gorm.Session{})
+
+	resp := dto.ReminderListResponse{}
+	if err := lq.filtered(query).Count(&resp.Total).Error; err != nil {
+		log.Error("Failed to count reminders", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	query, err := lq.page(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	var reminders []models.Reminder
+	if err := query.Find(&reminders).Error; err != nil {
+		log.Error("Failed to fetch reminders", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	reminders, resp.Pagination = pageOf(lq, reminders, func(sent models.Reminder) sortValue {
+		return sortValue{time: sent.CreatedAt, id: sent.ID}
+	})
+	resp.Reminders = reminderResponses(reminders)
+
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func reminderScheduleResponse(schedule models.ReminderSchedule) dto.ReminderScheduleResponse {
+	return dto.ReminderScheduleResponse{
+		GroupID:         schedule.GroupID,
+		Enabled:         schedule.Enabled,
+		FirstAfterDays:  schedule.FirstAfterDays,
+		RepeatEveryDays: schedule.RepeatEveryDays,
+		Channels:        schedule.Channels,
+		UpdatedAt:       schedule.UpdatedAt,
+		UpdatedBy:       schedule.UpdatedBy,
+	}
+}
+
+func reminderResponses(reminders []models.Reminder) []dto.ReminderResponse {
+	resp := make([]dto.ReminderResponse, 0, len(reminders))
+	for _, sent := range reminders {
+		resp = append(resp, dto.ReminderResponse{
+			ID:        sent.ID,
+			CreatedAt: sent.CreatedAt,
+			GroupID:   sent.GroupID,
+			MemberID:  sent.MemberID,
+			UserID:    sent.UserID,
+			Kind:      sent.Kind,
+			SentBy:    sent.SentBy,
+			Channel:   sent.Channel,
+			Amount:    sent.Amount,
+			Status:    sent.Status,
+			Error:     sent.Error,
+		})
+	}
+	return resp
+}
+
+// reminderListSpec is how sent reminders are sorted and filtered.
+var reminderListSpec = listSpec{
+	id:           "id",
+	sorts:        map[string]sortKey{"createdAt": {"created_at", sortTime}},
+	defaultSort:  "-createdAt",
+	created:      "created_at",
+	defaultLimit: 20,
+	maxLimit:     100,
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/report"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"github.com/mohdjishin/SplitWise/internal/reportjob"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CreateReportJob queues a report to be generated in the background
+// @Summary Queue a report
+// @Description Queues the owner groups report, a group report or the personal statement to be generated in the background and returns the job to poll. The file can be downloaded once the job is DONE, until it expires.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dto.CreateReportJobRequest true "Report to generate"
+// @Success 202 {object} dto.ReportJobResponse "Report queued"
+// @Failure 400 {object} errors.Error "Invalid type, format or date range"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 429 {object} errors.Error "Too many reports in progress"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/reports [post]
+func CreateReportJob(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateReportJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	job, err := ledger.QueueReport(uint(middleware.GetCurrentUserId(r)), input)
+	if err != nil {
+		writeLedgerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", reportJobURL(job.ID))
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(reportJobResponse(job))
+}
+
+// ListReportJobs lists the user's report jobs
+// @Summary List report jobs
+// @Tags reports
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param limit query int false "Page size, default 20, max 100"
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param sort query string false "createdAt, with a leading - for descending order; -createdAt by default"
+// @Param from query string false "Requested on or after this day (YYYY-MM-DD) in the user's timezone"
+// @Param to query string false "Requested on or before this day (YYYY-MM-DD) in the user's timezone"
+// @Param status query string false "PENDING, PROCESSING, DONE, FAILED, CANCELLED or EXPIRED"
+// @Success 200 {object} dto.ReportJobListResponse
+// @Header 200 {string} Link "Links to the first and next pages"
+// @Failure 400 {object} errors.Error "Invalid query parameter"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/reports [get]
+func ListReportJobs(w http.ResponseWriter, r *http.Request) {
+	lq, ok := listRequest(w, r, reportJobListSpec)
+	if !ok {
+		return
+	}
+
+	query := db.GetDb().Model(&models.ReportJob{}).Where("user_id = ?", middleware.GetCurrentUserId(r)).Session(&gorm.Session{})
+	resp := dto.ReportJobListResponse{Jobs: []dto.ReportJobResponse{}}
+	if err := lq.filtered(query).Count(&resp.Total).Error; err != nil {
+		log.Error("Failed to count report jobs", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	query, err := lq.page(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	var jobs []models.ReportJob
+	if err := query.Omit("data").Find(&jobs).Error; err != nil {
+		log.Error("Failed to fetch report jobs", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	jobs, resp.Pagination = pageOf(lq, jobs, func(job models.ReportJob) sortValue {
+		return sortValue{time: job.CreatedAt, id: job.ID}
+	})
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, reportJobResponse(job))
+	}
+	setCursorLinks(w, r, resp.Pagination)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// reportJobListSpec is how report jobs are sorted and filtered.
+var reportJobListSpec = listSpec{
+	id:           "id",
+	sorts:        map[string]sortKey{"createdAt": {"created_at", sortTime}},
+	defaultSort:  "-createdAt",
+	created:      "created_at",
+	status:       "status",
+	statuses:     []string{models.JobPending, models.JobProcessing, models.JobDone, models.JobFailed, models.JobCancelled, models.JobExpired},
+	defaultLimit: 20,
+	maxLimit:     100,
+}
+
+// GetReportJob returns the status of a report job
+// @Summary Get report job status
+// @Tags reports
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param jobId path int true "Report job ID"
+// @Success 200 {object} dto.ReportJobResponse
+// @Failure 404 {object} errors.Error "Report job not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/reports/{jobId} [get]
+func GetReportJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := findReportJob(w, r, false)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reportJobResponse(job))
+}
+
+// DownloadReportJob downloads the file of a finished report job
+// @Summary Download a generated report
+// @Tags reports
+// @Produce application/pdf
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce text/html
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param jobId path int true "Report job ID"
+// @Success 200 {file} file "Generated report"
+// @Failure 404 {object} errors.Error "Report job not found"
+// @Failure 409 {object} errors.Error "Report not ready or cancelled"
+// @Failure 410 {object} errors.Error "Report expired"
+// @Failure 500 {object} errors.Error "Report generation failed"
+// @Router /v1/reports/{jobId}/download [get]
+func DownloadReportJob(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := reportJobID(w, r)
+	if !ok {
+		return
+	}
+	job, err := ledger.ReportFile(uint(middleware.GetCurrentUserId(r)), jobID)
+	if err != nil {
+		writeLedgerError(w, err)
+		return
+	}
+	writeReportFile(w, reportFile{FileName: job.FileName, ContentType: job.ContentType, Data: job.Data})
+}
+
+// CancelReportJob cancels a queued or running report job
+// @Summary Cancel a report job
+// @Tags reports
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param jobId path int true "Report job ID"
+// @Success 200 {object} dto.ReportJobResponse
+// @Failure 404 {object} errors.Error "Report job not found"
+// @Failure 409 {object} errors.Error "Report job already finished"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/reports/{jobId}/cancel [post]
+func CancelReportJob(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := reportJobID(w, r)
+	if !ok {
+		return
+	}
+	job, err := ledger.CancelReport(uint(middleware.GetCurrentUserId(r)), jobID)
+	if err != nil {
+		writeLedgerError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reportJobResponse(job))
+}
+
+// RenderReportJob generates the report of a queued job. It is run by the report worker pool.
+func RenderReportJob(ctx context.Context, job models.ReportJob) (reportjob.Result, error) {
+	var file reportFile
+	var err error
+	switch job.Kind {
+	case models.ReportKindGroup:
+		renderer, rerr := report.ForFormat(job.Format)
+		if rerr != nil {
+			return reportjob.Result{}, rerr
+		}
+		if job.Params.NoCharts {
+			renderer = report.WithoutCharts(renderer)
+		}
+		file, err = buildGroupReport(ctx, job.Params.GroupID, job.UserID, job.Params.Comments, renderer)
+	case models.ReportKindGroups, models.ReportKindStatement:
+		_, loc, rerr := ledger.UserLocale(db.GetDb().WithContext(ctx), job.UserID)
+		if rerr != nil {
+			return reportjob.Result{}, rerr
+		}
+		from, to, rerr := ledger.ReportRange(dto.GetGroupReportRequest{From: &job.Params.From, To: &job.Params.To}, loc)
+		if rerr != nil {
+			return reportjob.Result{}, rerr
+		}
+		if job.Kind == models.ReportKindStatement {
+			file, err = buildMemberReport(ctx, job.UserID, from, to, job.Format)
+			break
+		}
+		renderer, rerr := report.ForFormat(job.Format)
+		if rerr != nil {
+			return reportjob.Result{}, rerr
+		}
+		if job.Params.NoCharts {
+			renderer = report.WithoutCharts(renderer)
+		}
+		file, err = buildOwnerReport(ctx, job.UserID, from, to, renderer)
+	default:
+		return reportjob.Result{}, fmt.Errorf("unknown report kind %q", job.Kind)
+	}
+	if err != nil {
+		return reportjob.Result{}, err
+	}
+	return reportjob.Result{FileName: file.FileName, ContentType: file.ContentType, Data: file.Data}, nil
+}
+
+func findReportJob(w http.ResponseWriter, r *http.Request, withData bool) (models.ReportJob, bool) {
+	jobID, ok := reportJobID(w, r)
+	if !ok {
+		return models.ReportJob{}, false
+	}
+	job, err := ledger.ReportJob(uint(middleware.GetCurrentUserId(r)), jobID, withData)
+	if err != nil {
+		writeLedgerError(w, err)
+		return job, false
+	}
+	return job, true
+}
+
+// reportJobID parses the job id of the path, answering 404 when it is not a number.
+func reportJobID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "jobId"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrReportJobNotFound)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func reportJobResponse(job models.ReportJob) dto.ReportJobResponse {
+	resp := dto.ReportJobResponse{
+		JobID:       job.ID,
+		Type:        job.Kind,
+		Format:      job.Format,
+		Params:      job.Params,
+		Status:      job.Status,
+		StatusURL:   reportJobURL(job.ID),
+		FileName:    job.FileName,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		CompletedAt: job.CompletedAt,
+		ExpiresAt:   job.ExpiresAt,
+	}
+	if job.Status == models.ReportJobDone {
+		resp.DownloadURL = reportJobURL(job.ID) + "/download"
+	}
+	return resp
+}
+
+func reportJobURL(jobID uint) string {
+	return fmt.Sprintf("/v1/reports/%d", jobID)
+}
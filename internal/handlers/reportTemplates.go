@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"encoding/json"
+	e "errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxTemplateSize leaves room for a 200 KB logo once base64 encoded.
+const maxTemplateSize = 512 << 10
+
+// GetDefaultReportTemplate returns a bundled report template
+// @Summary Get a bundled report template
+// @Description Returns the template reports of the kind are laid out with when no custom template is set. It is a good starting point for a custom template.
+// @Tags report templates
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param kind path string true "groups or group"
+// @Param format query string false "pdf (default), csv or html"
+// @Success 200 {object} object "Template"
+// @Failure 400 {object} errors.Error "Unknown kind or format"
+// @Router /v1/report-templates/defaults/{kind} [get]
+func GetDefaultReportTemplate(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "kind")
+	if !validTemplateKind(w, kind) {
+		return
+	}
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = "pdf"
+	case "pdf", "csv", "html":
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrInvalidQueryParameter("format must be one of pdf, csv or html"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(layout.Default(kind, format))
+}
+
+// ListReportTemplates returns the report templates of the user
+// @Summary List my report templates
+// @Description Returns the templates the user has set for their groups and group reports. Templates of single groups are listed with GET /v1/groups/{id}/report-template.
+// @Tags report templates
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} dto.ReportTemplateResponse
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/report-templates [get]
+func ListReportTemplates(w http.ResponseWriter, r *http.Request) {
+	var templates []models.ReportTemplate
+	if err := db.GetDb().Where("user_id = ?", middleware.GetCurrentUserId(r)).Order("kind").Find(&templates).Error; err != nil {
+		log.Error("Failed to fetch report templates", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	response := make([]dto.ReportTemplateResponse, len(templates))
+	for i, t := range templates {
+		response[i] = reportTemplateResponse(t)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// GetReportTemplate returns a report template of the user
+// @Summary Get my report template
+// @Description Returns the template the user has set for reports of the kind.
+// @Tags report templates
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param kind path string true "groups or group"
+// @Success 200 {object} dto.ReportTemplateResponse
+// @Failure 400 {object} errors.Error "Unknown kind"
+// @Failure 404 {object} errors.Error "No template set"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/report-templates/{kind} [get]
+func GetReportTemplate(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "kind")
+	if !validTemplateKind(w, kind) {
+		return
+	}
+	t, ok := findReportTemplate(w, models.ReportTemplate{UserID: uint(middleware.GetCurrentUserId(r)), Kind: kind})
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reportTemplateResponse(t))
+}
+
+// SetReportTemplate creates or replaces a report template of the user
+// @Summary Set my report template
+// @Description Lays out every report of the kind the user generates with the template, unless the group has a template of its own. The template is JSON, or YAML when sent as application/yaml. It lists the sections of the report in order: text, fields and table sections show datasets of the report, chart sections draw charts, comments adds the comment threads when asked for and pageBreak starts a new page. Get a bundled template from GET /v1/report-templates/defaults/{kind} to see the datasets, fields and charts available.
+// @Tags report templates
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param kind path string true "groups or group"
+// @Param request body object true "Template"
+// @Success 200 {object} dto.ReportTemplateResponse
+// @Failure 400 {object} errors.Error "Invalid template"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/report-templates/{kind} [put]
+func SetReportTemplate(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "kind")
+	if !validTemplateKind(w, kind) {
+		return
+	}
+	userId := middleware.GetCurrentUserId(r)
+	saveReportTemplate(w, r, uint(userId), models.ReportTemplate{UserID: uint(userId), Kind: kind})
+}
+
+// DeleteReportTemplate removes a report template of the user
+// @Summary Delete my report template
+// @Description Removes the template of the kind, reports go back to the bundled layout.
+// @Tags report templates
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param kind path string true "groups or group"
+// @Success 200 {object} map[string]string "Template deleted"
+// @Failure 400 {object} errors.Error "Unknown kind"
+// @Failure 404 {object} errors.Error "No template set"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/report-templates/{kind} [delete]
+func DeleteReportTemplate(w http.ResponseWriter, r *http.Request) {
+	kind := chi.URLParam(r, "kind")
+	if !validTemplateKind(w, kind) {
+		return
+	}
+	userId := middleware.GetCurrentUserId(r)
+	deleteReportTemplate(w, r, uint(userId), models.ReportTemplate{UserID: uint(userId), Kind: kind})
+}
+
+// GetGroupReportTemplate returns the report template of a group
+// @Summary Get the report template of a group
+// @Description Returns the template the detailed report of the group is laid out with. Only the group owner can see it.
+// @Tags report templates
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Success 200 {object} dto.ReportTemplateResponse
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group not found or no template set"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/report-template [get]
+func GetGroupReportTemplate(w http.ResponseWriter, r *http.Request) {
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), middleware.GetCurrentUserId(r))
+	if !ok {
+		return
+	}
+	t, ok := findReportTemplate(w, models.ReportTemplate{GroupID: group.ID, Kind: layout.KindGroup})
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reportTemplateResponse(t))
+}
+
+// SetGroupReportTemplate creates or replaces the report template of a group
+// @Summary Set the report template of a group
+// @Description Lays out the detailed report of the group with the template, in place of the owner's group template. The template is JSON, or YAML when sent as application/yaml, and has the same form as the templates of PUT /v1/report-templates/group. Only the group owner can set it.
+// @Tags report templates
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Param request body object true "Template"
+// @Success 200 {object} dto.ReportTemplateResponse
+// @Failure 400 {object} errors.Error "Invalid template"
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/report-template [put]
+func SetGroupReportTemplate(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+	saveReportTemplate(w, r, uint(userId), models.ReportTemplate{GroupID: group.ID, Kind: layout.KindGroup})
+}
+
+// DeleteGroupReportTemplate removes the report template of a group
+// @Summary Delete the report template of a group
+// @Description Removes the template of the group, its report goes back to the owner's group template or the bundled layout. Only the group owner can delete it.
+// @Tags report templates
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path int true "Group ID"
+// @Success 200 {object} map[string]string "Template deleted"
+// @Failure 403 {object} errors.Error "Not the group owner"
+// @Failure 404 {object} errors.Error "Group not found or no template set"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/groups/{id}/report-template [delete]
+func DeleteGroupReportTemplate(w http.ResponseWriter, r *http.Request) {
+	userId := middleware.GetCurrentUserId(r)
+	group, ok := requireGroupOwner(w, chi.URLParam(r, "id"), userId)
+	if !ok {
+		return
+	}
+	deleteReportTemplate(w, r, uint(userId), models.ReportTemplate{GroupID: group.ID, Kind: layout.KindGroup})
+}
+
+func validTemplateKind(w http.ResponseWriter, kind string) bool {
+	if kind != layout.KindGroups && kind != layout.KindGroup {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("kind must be groups or group"))
+		return false
+	}
+	return true
+}
+
+// findReportTemplate loads the template of the user or group and kind in key.
+func findReportTemplate(w http.ResponseWriter, key models.ReportTemplate) (models.ReportTemplate, bool) {
+	var t models.ReportTemplate
+	err := db.GetDb().Where("user_id = ? AND group_id = ? AND kind = ?", key.UserID, key.GroupID, key.Kind).First(&t).Error
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(errors.ErrReportTemplateNotFound)
+			return t, false
+		}
+		log.Error("Failed to fetch report template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return t, false
+	}
+	return t, true
+}
+
+// saveReportTemplate validates the template in the request body and stores it under key, replacing
+// any template stored there before.
+func saveReportTemplate(w http.ResponseWriter, r *http.Request, actorID uint, key models.ReportTemplate) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTemplateSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("the template must be at most 512 KB"))
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	asYAML := strings.HasSuffix(mediaType, "yaml")
+
+	template, err := layout.Parse(body, asYAML)
+	if err == nil {
+		err = template.Validate(key.Kind)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed(err.Error()))
+		return
+	}
+	definition, err := json.Marshal(template)
+	if err != nil {
+		log.Error("Failed to encode report template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	var t models.ReportTemplate
+	err = db.GetDb().Where("user_id = ? AND group_id = ? AND kind = ?", key.UserID, key.GroupID, key.Kind).First(&t).Error
+	if err != nil && !e.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to fetch report template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	before := t
+	t.UserID, t.GroupID, t.Kind, t.Definition = key.UserID, key.GroupID, key.Kind, definition
+	if err := db.GetDb().Save(&t).Error; err != nil {
+		log.Error("Failed to save report template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	entry := audit.Entry{Action: audit.ActionTemplateSet, EntityType: audit.EntityTemplate, EntityID: t.ID, GroupID: t.GroupID, After: t}
+	if before.ID != 0 {
+		entry.Before = before
+	}
+	audit.Record(r.Context(), actorID, entry)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reportTemplateResponse(t))
+}
+
+func deleteReportTemplate(w http.ResponseWriter, r *http.Request, actorID uint, key models.ReportTemplate) {
+	t, ok := findReportTemplate(w, key)
+	if !ok {
+		return
+	}
+	if err := db.GetDb().Delete(&t).Error; err != nil {
+		log.Error("Failed to delete report template", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	audit.Record(r.Context(), actorID, audit.Entry{Action: audit.ActionTemplateDeleted, EntityType: audit.EntityTemplate, EntityID: t.ID, GroupID: t.GroupID, Before: t})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Report template deleted"})
+}
+
+func reportTemplateResponse(t models.ReportTemplate) dto.ReportTemplateResponse {
+	return dto.ReportTemplateResponse{Kind: t.Kind, GroupID: t.GroupID, Template: t.Definition, UpdatedAt: t.UpdatedAt}
+}
+
+// reportTemplate returns the stored template a report is laid out with: the group's own template,
+// then the owner's template of the kind. It returns nil when neither is set.
+func reportTemplate(dbc *gorm.DB, userId, groupID uint, kind string) (*layout.Template, error) {
+	var stored []models.ReportTemplate
+	query := dbc.Where("user_id = ? AND group_id = 0 AND kind = ?", userId, kind)
+	if groupID != 0 {
+		query = query.Or("user_id = 0 AND group_id = ? AND kind = ?", groupID, kind)
+	}
+	if err := query.Order("group_id DESC").Limit(1).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		return nil, nil
+	}
+	var t layout.Template
+	if err := json.Unmarshal(stored[0].Definition, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
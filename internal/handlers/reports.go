@@ -2,17 +2,24 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	e "errors"
 
 	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/helper/layout"
 	"github.com/mohdjishin/SplitWise/helper/pdf"
+	"github.com/mohdjishin/SplitWise/helper/report"
+	"github.com/mohdjishin/SplitWise/internal/budget"
 	"github.com/mohdjishin/SplitWise/internal/db"
 	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
 	"github.com/mohdjishin/SplitWise/internal/middleware"
 	"github.com/mohdjishin/SplitWise/internal/models"
 	"github.com/mohdjishin/SplitWise/internal/models/dto"
@@ -22,17 +29,22 @@ import (
 	"gorm.io/gorm"
 )
 
-// GetGroupReport handles downloading a PDF report for a user's groups
-// @Summary Download PDF report of user's groups
-// @Description Generates and downloads a PDF report for the groups created by the user within a specified date range.
+// GetGroupReport handles downloading a report for a user's groups
+// @Summary Download report of user's groups
+// @Description Generates and downloads a report for the groups created by the user within a specified date range. The format is taken from the format query parameter, then from the Accept header, and defaults to PDF. PDF, CSV and HTML reports are laid out with the user's groups template when one is set (see /v1/report-templates). Owners with many groups should queue the report with POST /v1/reports instead.
 // @Tags reports
 // @Accept json
 // @Produce application/pdf
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce text/html
 // @Param Authorization header string true "Bearer token"
 // @Param from query string false "from date in the format YYYY-MM-DD"
 // @Param to query string false "to date in the format YYYY-MM-DD"
+// @Param format query string false "pdf (default), csv, xlsx or html"
+// @Param charts query bool false "Draw the paid versus outstanding, spending over time and category charts (PDF and HTML only, default true)"
 // @Param request body dto.GetGroupReportRequest true "GetGroupReportRequest details"
-// @Success 200 {file} file "PDF report generated and downloaded"
+// @Success 200 {file} file "Report generated and downloaded"
 // @Failure 400 {object} errors.Error "Bad Request"
 // @Failure 404 {object} errors.Error "Not Found"
 // @Failure 500 {object} errors.Error "Internal Server Error"
@@ -40,6 +52,16 @@ import (
 func GetGroupReport(w http.ResponseWriter, r *http.Request) {
 	log.Info("GetGroupReport handler called")
 
+	renderer, err := report.ForRequest(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrInvalidQueryParameter(err.Error()))
+		return
+	}
+	if r.URL.Query().Get("charts") == "false" {
+		renderer = report.WithoutCharts(renderer)
+	}
+
 	var req dto.GetGroupReportRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Error("Invalid request payload", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
@@ -48,55 +70,59 @@ func GetGroupReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	log.Debug("GetGroupReport request", zap.Any("request", req))
-	userId := middleware.GetCurrentUserId(r)
-	var user models.User
-	if err := db.GetDb().Select("name").Where("id = ?", userId).First(&user).Error; err != nil {
-		log.Error("Database query failed", zap.Error(err))
-		if e.Is(err, gorm.ErrRecordNotFound) {
-			log.Info("No user found", zap.Float64("user_id", userId))
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(errors.ErrUserNotFound)
-			return
-		}
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+	loc, ok := requestTimezone(w, r)
+	if !ok {
+		return
+	}
+	fromDate, toDate, err := ledger.ReportRange(req, loc)
+	if err != nil {
+		log.Error("Invalid report date range", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
 		return
 	}
 
-	var (
-		toDate   time.Time
-		fromDate time.Time
-		err      error
-	)
-
-	if req.From != nil {
-		fromDate, err = time.Parse("2006-01-02", *req.From)
-		if err != nil {
-			log.Error("Invalid from date format", zap.Error(err))
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(errors.ErrInvalid("invalid from date format"))
-			return
-		}
-	} else {
-		fromDate = time.Now().AddDate(0, 0, -7)
+	file, err := buildOwnerReport(r.Context(), uint(middleware.GetCurrentUserId(r)), fromDate, toDate, renderer)
+	if err != nil {
+		writeReportError(w, err)
+		return
 	}
+	writeReportFile(w, file)
+	log.Info("Report generated successfully", zap.String("format", renderer.Format()))
+}
 
-	if req.To != nil {
-		toDate, err = time.Parse("2006-01-02", *req.To)
-		if err != nil {
-			log.Error("Invalid to date format", zap.Error(err))
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(errors.ErrInvalid("invalid to date format"))
-			return
+// reportFile is a rendered report, ready to be sent or stored by a report job.
+type reportFile struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// buildOwnerReport renders the groups created by the user in the date range.
+func buildOwnerReport(ctx context.Context, userId uint, fromDate, toDate time.Time, renderer report.Renderer) (reportFile, error) {
+	dbc := db.GetDb().WithContext(ctx)
+	var user models.User
+	if err := dbc.Select("name", "locale", "timezone").Where("id = ?", userId).First(&user).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			log.Info("No user found", zap.Uint("user_id", userId))
+			return reportFile{}, &ledger.Error{Status: http.StatusNotFound, Err: errors.ErrUserNotFound}
 		}
-	} else {
-		toDate = time.Now()
+		return reportFile{}, err
+	}
+	locale, _ := ledger.PreferredLocale(user)
+	renderer = report.WithLocale(renderer, locale)
+	custom, err := reportTemplate(dbc, userId, 0, layout.KindGroups)
+	if err != nil {
+		return reportFile{}, fmt.Errorf("fetch report template: %w", err)
+	}
+	if custom != nil {
+		renderer = report.WithTemplate(renderer, *custom)
 	}
 
-	rows, err := db.GetDb().Table("groups").
+	rows, err := dbc.Table("groups").
 		Select(`
 		groups.id, groups.name, groups.status, groups.total_amount, groups.per_user_split_amount, groups.paid_amount,
-		bills.amount AS bill_amount, bills.completed AS bill_paid, bills.created_at AS bill_date,
+		bills.amount AS bill_amount, bills.completed AS bill_paid, bills.created_at AS bill_date, COALESCE(bills.category, 'uncategorised') AS bill_category,
 		COUNT(group_members.id) AS member_count, groups.total_amount AS total_split, groups.status 
 	`).
 		Joins("LEFT JOIN bills ON bills.group_id = groups.id").
@@ -105,10 +131,7 @@ func GetGroupReport(w http.ResponseWriter, r *http.Request) {
 		Group("groups.id, bills.id").
 		Rows()
 	if err != nil {
-		log.Error("Database query failed", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+		return reportFile{}, err
 	}
 	defer rows.Close()
 
@@ -130,15 +153,13 @@ func GetGroupReport(w http.ResponseWriter, r *http.Request) {
 			&bill.Amount,
 			&bill.Paid,
 			&bill.Date,
+			&bill.Category,
 			&memberCount,
 			&totalSplit,
 			&status,
 		)
 		if err != nil {
-			log.Error("Failed to scan row data", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-			return
+			return reportFile{}, fmt.Errorf("scan row data: %w", err)
 		}
 
 		group.Bills = bill
@@ -147,46 +168,39 @@ func GetGroupReport(w http.ResponseWriter, r *http.Request) {
 		grpInfo = append(grpInfo, group)
 	}
 	if len(grpInfo) == 0 {
-		log.Info("No groups found for user", zap.Float64("user_id", userId))
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
-		return
+		log.Info("No groups found for user", zap.Uint("user_id", userId))
+		return reportFile{}, &ledger.Error{Status: http.StatusNotFound, Err: errors.ErrGroupNotFound}
 	}
-
-	pdfResponse := pdf.GenerateOwnerGroupsReportPDF(grpInfo, fromDate, toDate, user.Name)
-
-	var buf bytes.Buffer
-	if err := pdfResponse.Output(&buf); err != nil {
-		log.Error("Failed to generate PDF", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+	if err := ctx.Err(); err != nil {
+		return reportFile{}, err
 	}
 
-	w.Header().Set("Content-Type", "application/pdf")
-	fileName := fmt.Sprintf("%s_%s_%s_report.pdf", user.Name, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"))
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
-	w.WriteHeader(http.StatusOK)
-	log.Info("PDF generated successfully")
-	_, err = w.Write(buf.Bytes())
-	if err != nil {
-		log.Error("Failed to write PDF to response", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-
+	var buf bytes.Buffer
+	if err := renderer.OwnerGroups(&buf, grpInfo, fromDate, toDate, user.Name); err != nil {
+		return reportFile{}, fmt.Errorf("render %s report: %w", renderer.Format(), err)
 	}
-	log.Info("PDF generated successfully")
+	return reportFile{
+		FileName:    fmt.Sprintf("%s_%s_%s_report%s", user.Name, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"), renderer.Extension()),
+		ContentType: renderer.ContentType(),
+		Data:        buf.Bytes(),
+	}, nil
 }
 
-// GenerateSingleGroupReport generates a PDF report for a specific group.
-// @Summary Generate a PDF report for a specific group
-// @Description Generates a detailed PDF report for the group specified by its ID. The report includes group details, associated bills, and member history.
+// GenerateSingleGroupReport generates a report for a specific group.
+// @Summary Generate a report for a specific group
+// @Description Generates a detailed report for the group specified by its ID. The PDF and HTML reports include group details, budget versus actual, associated bills, member history and the list of attachments; CSV and XLSX contain the summary, members and payment history tables (XLSX as separate sheets). PDF, CSV and HTML reports are laid out with the group's report template, or else the owner's group template, when one is set. The format is taken from the format query parameter, then from the Accept header, and defaults to PDF.
 // @Tags reports
 // @Accept  json
 // @Produce  application/pdf
+// @Produce  text/csv
+// @Produce  application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce  text/html
 // @Param Authorization header string true "Bearer token"
 // @Param id path int true "Group ID"
-// @Success 200 {file} report.pdf "PDF report generated successfully"
+// @Param format query string false "pdf (default), csv, xlsx or html"
+// @Param comments query bool false "Append the group's comment threads as an appendix (PDF and HTML only)"
+// @Param charts query bool false "Draw the paid versus outstanding, member contribution and payments over time charts (PDF and HTML only, default true)"
+// @Success 200 {file} file "Report generated successfully"
 // @Failure 400 {object} errors.Error "Bad request"
 // @Failure 404 {object} errors.Error "Group not found"
 // @Failure 500 {object} errors.Error "Internal server error"
@@ -194,101 +208,343 @@ func GetGroupReport(w http.ResponseWriter, r *http.Request) {
 func GenerateSingleGroupReport(w http.ResponseWriter, r *http.Request) {
 	log.Debug("GenerateSingleGroupReport handler called")
 
-	grpId := chi.URLParam(r, "id")
-	userId := middleware.GetCurrentUserId(r)
+	renderer, err := report.ForRequest(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrInvalidQueryParameter(err.Error()))
+		return
+	}
+	if r.URL.Query().Get("charts") == "false" {
+		renderer = report.WithoutCharts(renderer)
+	}
+
+	file, err := buildGroupReport(r.Context(), chi.URLParam(r, "id"), uint(middleware.GetCurrentUserId(r)), r.URL.Query().Get("comments") == "true", renderer)
+	if err != nil {
+		writeReportError(w, err)
+		return
+	}
+	writeReportFile(w, file)
+	log.Info("Report generated successfully", zap.String("format", renderer.Format()))
+}
+
+// buildGroupReport renders the detailed report of a group created by the user, optionally with its comment threads.
+func buildGroupReport(ctx context.Context, grpId any, userId uint, withComments bool, renderer report.Renderer) (reportFile, error) {
+	dbc := db.GetDb().WithContext(ctx)
 
 	var group models.Group
-	err := db.GetDb().Where("id = ? AND created_by = ?", grpId, userId).First(&group).Error
+	err := dbc.Where("id = ? AND created_by = ?", grpId, userId).First(&group).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Warn("Group not found for user:", zap.Any("group_id", grpId), zap.Any("user_id", userId))
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
-			return
+			return reportFile{}, &ledger.Error{Status: http.StatusNotFound, Err: errors.ErrGroupNotFound}
 		}
-		log.Error("Database error while fetching group:", zap.Any("error", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+		return reportFile{}, fmt.Errorf("fetch group: %w", err)
 	}
 
-	var bill models.Bill
-	err = db.GetDb().Where("group_id = ?", group.ID).First(&bill).Error
+	locale, _, err := ledger.UserLocale(dbc, userId)
 	if err != nil {
-		log.Error("Database error while fetching bill:", zap.Any("error", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+		return reportFile{}, fmt.Errorf("fetch preferences: %w", err)
+	}
+	renderer = report.WithLocale(renderer, locale)
+	custom, err := reportTemplate(dbc, userId, group.ID, layout.KindGroup)
+	if err != nil {
+		return reportFile{}, fmt.Errorf("fetch report template: %w", err)
+	}
+	if custom != nil {
+		renderer = report.WithTemplate(renderer, *custom)
+	}
+
+	var bill models.Bill
+	if err := dbc.Where("group_id = ?", group.ID).First(&bill).Error; err != nil {
+		return reportFile{}, fmt.Errorf("fetch bill: %w", err)
 	}
 
 	var grpMembers []models.GroupMember
-	err = db.GetDb().Where("group_id = ?", group.ID).Find(&grpMembers).Error
-	if err != nil && err != gorm.ErrRecordNotFound {
-		log.Error("Database error while fetching group members:", zap.Any("error", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+	if err := dbc.Where("group_id = ?", group.ID).Find(&grpMembers).Error; err != nil {
+		return reportFile{}, fmt.Errorf("fetch group members: %w", err)
 	}
 	if len(grpMembers) == 0 {
 		log.Warn("No group members found for group:", zap.Any("group_id", group.ID))
 	}
 
 	var billHistory []models.BillHistory
-	err = db.GetDb().Where("bill_id = ?", bill.ID).Find(&billHistory).Error
-	if err != nil && err != gorm.ErrRecordNotFound {
-		log.Error("Database error while fetching bill history:", zap.Any("error", err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-		return
+	if err := dbc.Where("bill_id = ?", bill.ID).Find(&billHistory).Error; err != nil {
+		return reportFile{}, fmt.Errorf("fetch bill history: %w", err)
 	}
-	userMap := make(map[uint]string, len(grpMembers))
 	if len(billHistory) == 0 {
 		log.Warn("No bill history found for bill:", zap.Any("bill_id", bill.ID))
-	} else {
-		var memberIDs []int
-		for _, member := range grpMembers {
-			memberIDs = append(memberIDs, int(member.UserID))
+	}
+
+	var attachments []models.Attachment
+	if err := dbc.Where("group_id = ?", group.ID).Order("created_at").Find(&attachments).Error; err != nil {
+		return reportFile{}, fmt.Errorf("fetch attachments: %w", err)
+	}
+
+	userIDs := make([]uint, 0, len(grpMembers)+len(attachments))
+	for _, member := range grpMembers {
+		userIDs = append(userIDs, member.UserID)
+	}
+	for _, attachment := range attachments {
+		userIDs = append(userIDs, attachment.UploadedBy)
+	}
+	userMap, err := userNames(userIDs)
+	if err != nil {
+		return reportFile{}, fmt.Errorf("fetch user details: %w", err)
+	}
+
+	var comments []dto.CommentResponse
+	if withComments {
+		var groupComments []models.Comment
+		if err := dbc.Where("group_id = ?", group.ID).Order("created_at, id").Find(&groupComments).Error; err != nil {
+			return reportFile{}, fmt.Errorf("fetch comments: %w", err)
 		}
-		var users []models.User
-		err = db.GetDb().Select("id,name").Where("id IN ?", memberIDs).Find(&users).Error
+		authors, err := userNames(commentAuthorIDs(groupComments))
 		if err != nil {
-			log.Error("Database error while fetching user details:", zap.Any("error", err))
-			w.WriteHeader(http.StatusInternalServerError)
-			_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
-			return
-		}
-
-		for _, user := range users {
-			userMap[user.ID] = user.Name
+			return reportFile{}, fmt.Errorf("fetch comment authors: %w", err)
 		}
+		comments = buildCommentTree(groupComments, authors)
+	}
 
+	budgets, err := budget.Statuses([]uint{group.ID})
+	if err != nil {
+		return reportFile{}, fmt.Errorf("fetch budgets: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return reportFile{}, err
 	}
 
 	log.Debug("[+]--->Group and associated data fetched successfully", zap.Any("group", group), zap.Any("bill", bill), zap.Any("members", grpMembers), zap.Any("history", billHistory))
 	req := dto.GroupReportRequest{Group: group,
-		Bill:     bill,
-		Members:  grpMembers,
-		History:  billHistory,
-		UserInfo: userMap}
-	pdfResponse := pdf.GenerateGroupDetailedReportPDF(req)
-
+		Bill:        bill,
+		Members:     grpMembers,
+		History:     billHistory,
+		UserInfo:    userMap,
+		Comments:    comments,
+		Attachments: attachments,
+		Budgets:     budgets[group.ID]}
 	var buf bytes.Buffer
-	if err := pdfResponse.Output(&buf); err != nil {
-		log.Error("Failed to generate PDF", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+	if err := renderer.GroupDetail(&buf, req); err != nil {
+		return reportFile{}, fmt.Errorf("render %s report: %w", renderer.Format(), err)
+	}
+	return reportFile{
+		FileName:    fmt.Sprintf("%s_%s_report%s", userMap[userId], group.Name, renderer.Extension()),
+		ContentType: renderer.ContentType(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// writeReportError answers a failed report build with the status the builder gave its error, or
+// as an internal error when it gave none.
+func writeReportError(w http.ResponseWriter, err error) {
+	var failure *ledger.Error
+	if e.As(err, &failure) {
+		w.WriteHeader(failure.Status)
+		_ = json.NewEncoder(w).Encode(failure.Err)
 		return
 	}
+	log.Error("Failed to generate report", zap.Error(err))
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+}
 
-	fileName := fmt.Sprintf("%s_%s_report.pdf", userMap[uint(userId)], group.Name)
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+func writeReportFile(w http.ResponseWriter, file reportFile) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", file.FileName))
 	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(file.Data); err != nil {
+		log.Error("Failed to write report to response", zap.Error(err))
+	}
+}
+
+// GetMemberReport returns the current user's statement across every group they belong to
+// @Summary Download personal statement
+// @Description Generates a statement for the current user covering every group they are a member of that was created in the date range: their share of each bill, payments made with dates, outstanding amounts and totals. Returned as PDF (default) or JSON, chosen by the format query parameter or the Accept header.
+// @Tags reports
+// @Accept json
+// @Produce application/pdf
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param format query string false "pdf (default) or json"
+// @Param request body dto.GetGroupReportRequest true "Date range, defaults to the last seven days"
+// @Success 200 {object} dto.MemberReport "Statement as JSON, or the PDF file"
+// @Failure 400 {object} errors.Error "Bad Request"
+// @Failure 404 {object} errors.Error "User not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/report/me [post]
+func GetMemberReport(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" && strings.Contains(r.Header.Get("Accept"), "application/json") {
+		format = "json"
+	}
+	if format != "" && format != "pdf" && format != "json" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrInvalidQueryParameter("format must be pdf or json"))
+		return
+	}
+
+	var req dto.GetGroupReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		log.Error("Invalid request payload", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrInvalidInput)
+		return
+	}
+	loc, ok := requestTimezone(w, r)
+	if !ok {
+		return
+	}
+	fromDate, toDate, err := ledger.ReportRange(req, loc)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+
+	file, err := buildMemberReport(r.Context(), uint(middleware.GetCurrentUserId(r)), fromDate, toDate, format)
+	if err != nil {
+		writeReportError(w, err)
+		return
+	}
+	if format == "json" {
+		w.Header().Set("Content-Type", file.ContentType)
+		_, _ = w.Write(file.Data)
+		return
+	}
+	writeReportFile(w, file)
+}
+
+// buildMemberReport renders the user's statement as PDF, or as JSON when format is json.
+func buildMemberReport(ctx context.Context, userId uint, fromDate, toDate time.Time, format string) (reportFile, error) {
+	statement, err := memberReport(ctx, userId, fromDate, toDate)
+	if err != nil {
+		return reportFile{}, fmt.Errorf("build member report: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return reportFile{}, err
+	}
+
+	name := fmt.Sprintf("%s_%s_%s_statement", statement.Name, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"))
+	var buf bytes.Buffer
+	if format == "json" {
+		if err := json.NewEncoder(&buf).Encode(statement); err != nil {
+			return reportFile{}, err
+		}
+		return reportFile{FileName: name + ".json", ContentType: "application/json", Data: buf.Bytes()}, nil
+	}
+	locale, _, err := ledger.UserLocale(db.GetDb().WithContext(ctx), userId)
+	if err != nil {
+		return reportFile{}, fmt.Errorf("fetch preferences: %w", err)
+	}
+	if err := pdf.GenerateMemberReportPDF(statement, locale).Output(&buf); err != nil {
+		return reportFile{}, fmt.Errorf("generate PDF: %w", err)
+	}
+	return reportFile{FileName: name + ".pdf", ContentType: "application/pdf", Data: buf.Bytes()}, nil
+}
+
+// memberReport collects the user's share and payment of every group they joined that was created in the range.
+func memberReport(ctx context.Context, userId uint, from, to time.Time) (dto.MemberReport, error) {
+	statement := dto.MemberReport{UserID: userId, From: from, To: to, Entries: []dto.MemberReportEntry{}}
+	dbc := db.GetDb().WithContext(ctx)
+
+	var user models.User
+	if err := dbc.Select("name").Where("id = ?", userId).First(&user).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return statement, &ledger.Error{Status: http.StatusNotFound, Err: errors.ErrUserNotFound}
+		}
+		return statement, err
+	}
+	statement.Name = user.Name
+
+	var memberships []models.GroupMember
+	err := dbc.
+		Joins("JOIN groups ON groups.id = group_members.group_id AND groups.deleted_at IS NULL").
+		Where("group_members.user_id = ? AND groups.created_at BETWEEN ? AND ?", userId, from, to).
+		Order("groups.created_at").
+		Find(&memberships).Error
+	if err != nil || len(memberships) == 0 {
+		return statement, err
+	}
+
+	groupIDs := make([]uint, len(memberships))
+	for i, m := range memberships {
+		groupIDs[i] = m.GroupID
+	}
+	var groups []models.Group
+	if err := dbc.Where("id IN ?", groupIDs).Find(&groups).Error; err != nil {
+		return statement, err
+	}
+	groupByID := make(map[uint]models.Group, len(groups))
+	ownerIDs := make([]uint, 0, len(groups))
+	billIDs := make([]uint, 0, len(groups))
+	for _, g := range groups {
+		groupByID[g.ID] = g
+		ownerIDs = append(ownerIDs, g.CreatedBy)
+		billIDs = append(billIDs, g.BillID)
+	}
+	owners, err := userNames(ownerIDs)
+	if err != nil {
+		return statement, err
+	}
+
+	var bills []models.Bill
+	if err := dbc.Where("id IN ?", billIDs).Find(&bills).Error; err != nil {
+		return statement, err
+	}
+	billByID := make(map[uint]models.Bill, len(bills))
+	for _, b := range bills {
+		billByID[b.ID] = b
+	}
+	var payments []models.BillHistory
+	if err := dbc.Where("bill_id IN ? AND paid_by_id = ?", billIDs, userId).Order("paid_at").Find(&payments).Error; err != nil {
+		return statement, err
+	}
+	paymentsByBill := make(map[uint][]models.BillHistory, len(payments))
+	for _, p := range payments {
+		paymentsByBill[p.BillID] = append(paymentsByBill[p.BillID], p)
+	}
+
+	for _, m := range memberships {
+		group := groupByID[m.GroupID]
+		bill := billByID[group.BillID]
+		entry := dto.MemberReportEntry{
+			GroupID:    group.ID,
+			GroupName:  group.Name,
+			Owner:      owners[group.CreatedBy],
+			Status:     group.Status,
+			BillID:     bill.ID,
+			BillName:   bill.Name,
+			Category:   bill.Category,
+			BillAmount: bill.Amount,
+			BillDate:   bill.CreatedAt,
+			Share:      m.SplitAmount,
+			HasPaid:    m.HasPaid,
+			PaidAt:     m.PaidAt,
+			Remarks:    m.Remarks,
+		}
+		for _, p := range paymentsByBill[bill.ID] {
+			entry.PaidAmount += p.Amount
+			if entry.PaidAt == nil {
+				paidAt := p.PaidAt
+				entry.PaidAt = &paidAt
+			}
+		}
+		if m.HasPaid {
+			if entry.PaidAmount == 0 {
+				entry.PaidAmount = m.SplitAmount // paid before payments were linked to users
+			}
+			if entry.PaidAt == nil {
+				paidAt := m.UpdatedAt
+				entry.PaidAt = &paidAt
+			}
+		} else {
+			entry.Outstanding = m.SplitAmount
+		}
 
-	if _, err := w.Write(buf.Bytes()); err != nil {
-		log.Error("Failed to write PDF to response", zap.Error(err))
-		w.WriteHeader(http.StatusInternalServerError)
-		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		statement.Entries = append(statement.Entries, entry)
+		statement.Totals.Groups++
+		statement.Totals.Share += entry.Share
+		statement.Totals.Paid += entry.PaidAmount
+		statement.Totals.Outstanding += entry.Outstanding
 	}
-	log.Info("PDF generated successfully")
+	return statement, nil
 }
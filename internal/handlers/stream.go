@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/broker"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHeartbeat = 15 * time.Second
+	// resetEvent tells the client that updates were missed and it should reload the group.
+	resetEvent = "reset"
+)
+
+// StreamGroupEvents streams updates of a group as Server-Sent Events
+// @Summary Stream group updates
+// @Description Streams member, bill, payment and status changes of the group as Server-Sent Events. Each event carries the domain event and the group's current totals. A comment line is sent as heartbeat every few seconds. Reconnecting clients send the Last-Event-ID header to receive the updates they missed; if those are no longer available a "reset" event asks the client to reload the group.
+// @Tags groups
+// @Produce text/event-stream
+// @Param Authorization header string true "Bearer token"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param id path int true "Group ID"
+// @Success 200 {string} string "Event stream"
+// @Failure 404 {object} errors.Error "Group not found"
+// @Failure 500 {object} errors.Error "Streaming not supported"
+// @Router /v1/groups/{id}/events [get]
+func StreamGroupEvents(w http.ResponseWriter, r *http.Request) {
+	member, ok := requireGroupMember(w, chi.URLParam(r, "id"), middleware.GetCurrentUserId(r))
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Error("Response writer does not support streaming")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+
+	var lastEventID uint64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		lastEventID, _ = strconv.ParseUint(v, 10, 64)
+	}
+	sub, replay, complete, err := broker.GetBroker().Subscribe(member.GroupID, lastEventID)
+	if err != nil {
+		log.Error("Failed to subscribe to group updates", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errors.ErrInternalError)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if !complete {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", resetEvent)
+	}
+	for _, msg := range replay {
+		writeSSE(w, msg)
+	}
+	flusher.Flush()
+
+	heartbeat := config.GetConfig().Events.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, open := <-sub.Messages():
+			if !open {
+				return // dropped by the broker, the client reconnects with Last-Event-ID
+			}
+			writeSSE(w, msg)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, msg broker.Message) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, msg.Data)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// GetProfile returns the account of the current user
+// @Summary Get the current user
+// @Tags account
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} dto.UserResponse
+// @Failure 404 {object} errors.Error "User not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me [get]
+func GetProfile(w http.ResponseWriter, r *http.Request) {
+	var user models.User
+	if err := db.GetDb().Where("id = ?", middleware.GetCurrentUserId(r)).First(&user).Error; err != nil {
+		writeUserLookupError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(userResponse(user))
+}
+
+// UpdatePrivacy changes who can see the email of the current user
+// @Summary Update privacy settings
+// @Description With emailVisibility members, members of the user's groups see the user's email in group details; with private, the default, only the user does.
+// @Tags account
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param request body dto.UpdatePrivacyRequest true "Privacy settings"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} errors.Error "Bad Request"
+// @Failure 404 {object} errors.Error "User not found"
+// @Failure 500 {object} errors.Error "Internal Server Error"
+// @Router /v1/me/privacy [put]
+func UpdatePrivacy(w http.ResponseWriter, r *http.Request) {
+	var input dto.UpdatePrivacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		log.Error("Error decoding request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrBadRequest)
+		return
+	}
+	user, err := ledger.SetEmailVisibility(r.Context(), uint(middleware.GetCurrentUserId(r)), input.EmailVisibility)
+	if err != nil {
+		writeLedgerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(userResponse(user))
+}
+
+// userResponse is the account of a user as shown to the user themselves.
+func userResponse(user models.User) dto.UserResponse {
+	visibility := user.EmailVisibility
+	if visibility == "" {
+		visibility = models.EmailPrivate
+	}
+	return dto.UserResponse{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Role:            user.Role,
+		Locale:          user.Locale,
+		Timezone:        user.Timezone,
+		EmailVisibility: visibility,
+		CreatedAt:       user.CreatedAt,
+	}
+}
@@ -0,0 +1,276 @@
+// Package jobqueue runs background jobs stored in the database. A worker leases a job while running
+// it, a job left by a stopped worker is picked up again once its lease runs out, and results are
+// dropped after their TTL. Report and export jobs are both built on it.
+package jobqueue
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxAttempts     = 3 // a job interrupted this many times by stopped workers is failed
+	leaseGrace      = 30 * time.Second
+	cleanupInterval = time.Minute
+)
+
+// Job is a row of a job table. Its table needs the status, attempts, lease_until, started_at,
+// completed_at, expires_at, error and data columns.
+type Job interface {
+	JobID() uint
+	JobAttempts() int
+}
+
+// Handler runs a job and returns the result columns to store with it. It must give up when ctx is
+// done. An *errors.Error is shown to the user, other errors are logged.
+type Handler[J Job] func(ctx context.Context, job J) (map[string]any, error)
+
+// Options configure how a queue is worked.
+type Options struct {
+	Workers      int           // jobs run at the same time per instance
+	PollInterval time.Duration // how often idle workers check the queue
+	JobTimeout   time.Duration // a job running longer is cancelled and failed
+	ResultTTL    time.Duration // results are kept this long
+}
+
+// Queue is the jobs of one table.
+type Queue[J Job] struct {
+	kind string // names the job in messages, e.g. "report"
+	wake chan struct{}
+
+	mu      sync.Mutex
+	running map[uint]context.CancelFunc
+}
+
+func New[J Job](kind string) *Queue[J] {
+	return &Queue[J]{kind: kind, wake: make(chan struct{}, 1), running: map[uint]context.CancelFunc{}}
+}
+
+// Wake makes an idle worker of this instance look at the queue now instead of at its next poll.
+func (q *Queue[J]) Wake() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Cancel cancels a pending or running job and reports whether it was still unfinished. A job running
+// on this instance stops at once, workers of other instances notice at their next poll.
+func (q *Queue[J]) Cancel(jobID uint) (bool, error) {
+	res := db.GetDb().Model(new(J)).
+		Where("id = ? AND status IN ?", jobID, []string{models.JobPending, models.JobProcessing}).
+		Updates(map[string]any{"status": models.JobCancelled, "completed_at": time.Now(), "lease_until": nil})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+
+	q.mu.Lock()
+	if cancel, ok := q.running[jobID]; ok {
+		cancel()
+	}
+	q.mu.Unlock()
+	return true, nil
+}
+
+// Run starts the workers and expires old results until ctx is cancelled, then waits for the workers
+// to return.
+func (q *Queue[J]) Run(ctx context.Context, opts Options, handle Handler[J]) {
+	w := &worker[J]{q: q, opts: opts, handle: handle}
+	var wg sync.WaitGroup
+	for i := 0; i < max(opts.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+	for {
+		q.expire()
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type worker[J Job] struct {
+	q      *Queue[J]
+	opts   Options
+	handle Handler[J]
+}
+
+func (w *worker[J]) work(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := w.claim()
+		if err != nil {
+			log.Error("Failed to claim job", zap.String("kind", w.q.kind), zap.Error(err))
+		} else if job != nil {
+			w.process(ctx, *job)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim takes the oldest pending job, or a running one whose worker stopped without finishing it,
+// and leases it for the job timeout.
+func (w *worker[J]) claim() (*J, error) {
+	var jobs []J
+	now := time.Now()
+	err := db.GetDb().Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND lease_until < ?)", models.JobPending, models.JobProcessing, now).
+			Order("id").Limit(1).Find(&jobs).Error
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+		id := jobs[0].JobID()
+		err = tx.Model(new(J)).Where("id = ?", id).Updates(map[string]any{
+			"status":      models.JobProcessing,
+			"started_at":  now,
+			"lease_until": now.Add(w.opts.JobTimeout + leaseGrace),
+			"attempts":    jobs[0].JobAttempts() + 1,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).First(&jobs[0]).Error
+	})
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return &jobs[0], nil
+}
+
+func (w *worker[J]) process(parent context.Context, job J) {
+	id := job.JobID()
+	if job.JobAttempts() > maxAttempts {
+		w.q.finish(id, failed(fmt.Sprintf("the %s could not be generated, please request it again", w.q.kind)))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(parent, w.opts.JobTimeout)
+	defer cancel()
+	w.q.mu.Lock()
+	w.q.running[id] = cancel
+	w.q.mu.Unlock()
+	defer func() {
+		w.q.mu.Lock()
+		delete(w.q.running, id)
+		w.q.mu.Unlock()
+	}()
+	go w.watch(ctx, id, cancel)
+
+	result, err := w.run(ctx, job)
+	switch {
+	case err == nil:
+		now := time.Now()
+		updates := map[string]any{
+			"status":       models.JobDone,
+			"completed_at": now,
+			"expires_at":   now.Add(w.opts.ResultTTL),
+			"lease_until":  nil,
+		}
+		for column, v := range result {
+			updates[column] = v
+		}
+		w.q.finish(id, updates)
+	case parent.Err() != nil:
+		// Shutting down, the job is picked up again once its lease runs out.
+	case e.Is(ctx.Err(), context.DeadlineExceeded):
+		log.Warn("Job timed out", zap.String("kind", w.q.kind), zap.Uint("jobId", id), zap.Duration("timeout", w.opts.JobTimeout))
+		w.q.finish(id, failed(fmt.Sprintf("the %s took longer than %s to generate", w.q.kind, w.opts.JobTimeout)))
+	case ctx.Err() != nil:
+		// Cancelled, Cancel already recorded it.
+	default:
+		var apiErr *errors.Error
+		if e.As(err, &apiErr) {
+			w.q.finish(id, failed(apiErr.Message))
+			return
+		}
+		log.Error("Job failed", zap.String("kind", w.q.kind), zap.Uint("jobId", id), zap.Error(err))
+		w.q.finish(id, failed(fmt.Sprintf("the %s could not be generated", w.q.kind)))
+	}
+}
+
+// run calls the handler, turning a panic into an error so it cannot take the worker down.
+func (w *worker[J]) run(ctx context.Context, job J) (result map[string]any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s handler panicked: %v", w.q.kind, r)
+		}
+	}()
+	return w.handle(ctx, job)
+}
+
+// watch stops the job when it gets cancelled through another instance.
+func (w *worker[J]) watch(ctx context.Context, jobID uint, cancel context.CancelFunc) {
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		var status string
+		if err := db.GetDb().Model(new(J)).Where("id = ?", jobID).Pluck("status", &status).Error; err != nil {
+			continue
+		}
+		if status != models.JobProcessing {
+			cancel()
+			return
+		}
+	}
+}
+
+func failed(msg string) map[string]any {
+	return map[string]any{"status": models.JobFailed, "error": msg, "completed_at": time.Now(), "lease_until": nil}
+}
+
+// finish records the outcome unless the job was cancelled in the meantime.
+func (q *Queue[J]) finish(jobID uint, updates map[string]any) {
+	err := db.GetDb().Model(new(J)).
+		Where("id = ? AND status = ?", jobID, models.JobProcessing).
+		Updates(updates).Error
+	if err != nil {
+		log.Error("Failed to save job result", zap.String("kind", q.kind), zap.Uint("jobId", jobID), zap.Error(err))
+	}
+}
+
+// expire drops the results of jobs past their TTL, keeping the job for status queries.
+func (q *Queue[J]) expire() {
+	res := db.GetDb().Model(new(J)).
+		Where("status = ? AND expires_at < ?", models.JobDone, time.Now()).
+		Updates(map[string]any{"status": models.JobExpired, "data": nil})
+	if res.Error != nil {
+		log.Error("Failed to expire jobs", zap.String("kind", q.kind), zap.Error(res.Error))
+		return
+	}
+	if res.RowsAffected > 0 {
+		log.Info("Expired jobs", zap.String("kind", q.kind), zap.Int64("count", res.RowsAffected))
+	}
+}
@@ -0,0 +1,352 @@
+package jobqueue
+
+import (
+	"context"
+	e "errors"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"gorm.io/gorm"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// newWorker works a queue of report jobs.
+func newWorker(opts Options, handle Handler[models.ReportJob]) (*Queue[models.ReportJob], *worker[models.ReportJob]) {
+	if opts.PollInterval == 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.JobTimeout == 0 {
+		opts.JobTimeout = time.Minute
+	}
+	q := New[models.ReportJob]("report")
+	return q, &worker[models.ReportJob]{q: q, opts: opts, handle: handle}
+}
+
+func create(t *testing.T, conn *gorm.DB, job models.ReportJob) models.ReportJob {
+	t.Helper()
+	if job.Status == "" {
+		job.Status = models.ReportJobPending
+	}
+	if err := conn.Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func reload(t *testing.T, conn *gorm.DB, id uint) models.ReportJob {
+	t.Helper()
+	var job models.ReportJob
+	if err := conn.First(&job, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func TestClaim(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		job     models.ReportJob
+		claimed bool
+	}{
+		{"pending", models.ReportJob{}, true},
+		{"running", models.ReportJob{Status: models.ReportJobProcessing, Attempts: 1, LeaseUntil: ptr(now.Add(time.Minute))}, false},
+		{"lease run out", models.ReportJob{Status: models.ReportJobProcessing, Attempts: 1, LeaseUntil: ptr(now.Add(-time.Second))}, true},
+		{"done", models.ReportJob{Status: models.ReportJobDone, Attempts: 1}, false},
+		{"failed", models.ReportJob{Status: models.ReportJobFailed, Attempts: 1}, false},
+		{"cancelled", models.ReportJob{Status: models.ReportJobCancelled}, false},
+		{"expired", models.ReportJob{Status: models.ReportJobExpired, Attempts: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbtest.Open(t, &models.ReportJob{})
+			_, p := newWorker(Options{}, nil)
+			created := create(t, conn, tt.job)
+
+			before := time.Now()
+			job, err := p.claim()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.claimed {
+				if job != nil {
+					t.Errorf("claimed job %d, want none", job.ID)
+				}
+				if got := reload(t, conn, created.ID); got.Status != created.Status || got.Attempts != created.Attempts {
+					t.Errorf("job changed to %s with %d attempts, want it left alone", got.Status, got.Attempts)
+				}
+				return
+			}
+
+			if job == nil || job.ID != created.ID {
+				t.Fatalf("claim() = %v, want job %d", job, created.ID)
+			}
+			got := reload(t, conn, created.ID)
+			if got.Status != models.ReportJobProcessing || got.Attempts != created.Attempts+1 {
+				t.Errorf("claimed job is %s with %d attempts, want PROCESSING with %d", got.Status, got.Attempts, created.Attempts+1)
+			}
+			lease := time.Minute + leaseGrace
+			if got.LeaseUntil == nil || got.LeaseUntil.Before(before.Add(lease)) || got.LeaseUntil.After(time.Now().Add(lease)) {
+				t.Errorf("lease until %v, want %v from now", got.LeaseUntil, lease)
+			}
+			if again, err := p.claim(); err != nil || again != nil {
+				t.Errorf("second claim() = %v, %v, want nothing while the lease runs", again, err)
+			}
+		})
+	}
+}
+
+func TestClaimOldestFirst(t *testing.T) {
+	conn := dbtest.Open(t, &models.ReportJob{})
+	_, p := newWorker(Options{}, nil)
+	first := create(t, conn, models.ReportJob{})
+	second := create(t, conn, models.ReportJob{})
+
+	for _, want := range []uint{first.ID, second.ID} {
+		job, err := p.claim()
+		if err != nil || job == nil || job.ID != want {
+			t.Fatalf("claim() = %v, %v, want job %d", job, err, want)
+		}
+	}
+}
+
+// TestLeaseRunsOut follows a job whose worker keeps stopping: it is taken over each time its lease
+// runs out, and failed once it has been interrupted maxAttempts times.
+func TestLeaseRunsOut(t *testing.T) {
+	conn := dbtest.Open(t, &models.ReportJob{})
+	calls := 0
+	_, p := newWorker(Options{}, func(context.Context, models.ReportJob) (map[string]any, error) {
+		calls++
+		return nil, nil
+	})
+	created := create(t, conn, models.ReportJob{})
+
+	for attempt := 1; attempt <= maxAttempts+1; attempt++ {
+		job, err := p.claim()
+		if err != nil || job == nil {
+			t.Fatalf("claim %d = %v, %v, want the job", attempt, job, err)
+		}
+		if job.Attempts != attempt {
+			t.Fatalf("claim %d has %d attempts", attempt, job.Attempts)
+		}
+		if attempt <= maxAttempts {
+			// The worker stops without finishing; let the lease run out.
+			if err := conn.Model(&models.ReportJob{}).Where("id = ?", created.ID).Update("lease_until", time.Now().Add(-time.Second)).Error; err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		p.process(context.Background(), *job)
+	}
+
+	got := reload(t, conn, created.ID)
+	if got.Status != models.ReportJobFailed || got.LeaseUntil != nil || got.Error == "" {
+		t.Errorf("job after %d interrupted attempts: %s, lease %v, error %q, want FAILED", maxAttempts, got.Status, got.LeaseUntil, got.Error)
+	}
+	if calls != 0 {
+		t.Errorf("handler called %d times, want the job failed without running it", calls)
+	}
+	if job, err := p.claim(); err != nil || job != nil {
+		t.Errorf("claim() after failure = %v, %v, want nothing", job, err)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeout   time.Duration
+		handle    Handler[models.ReportJob]
+		status    string
+		wantError string
+	}{
+		{
+			name: "done",
+			handle: func(context.Context, models.ReportJob) (map[string]any, error) {
+				return map[string]any{"file_name": "report.csv", "content_type": "text/csv", "data": []byte("a,b\n")}, nil
+			},
+			status: models.ReportJobDone,
+		},
+		{
+			name: "user error",
+			handle: func(context.Context, models.ReportJob) (map[string]any, error) {
+				return nil, &errors.Error{Code: "GROUP_NOT_FOUND", Message: "group not found"}
+			},
+			status:    models.ReportJobFailed,
+			wantError: "group not found",
+		},
+		{
+			name: "internal error",
+			handle: func(context.Context, models.ReportJob) (map[string]any, error) {
+				return nil, e.New("connection reset")
+			},
+			status:    models.ReportJobFailed,
+			wantError: "the report could not be generated",
+		},
+		{
+			name: "panic",
+			handle: func(context.Context, models.ReportJob) (map[string]any, error) {
+				panic("nil map")
+			},
+			status:    models.ReportJobFailed,
+			wantError: "the report could not be generated",
+		},
+		{
+			name:    "timeout",
+			timeout: 10 * time.Millisecond,
+			handle: func(ctx context.Context, _ models.ReportJob) (map[string]any, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			status:    models.ReportJobFailed,
+			wantError: "the report took longer than 10ms to generate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbtest.Open(t, &models.ReportJob{})
+			_, p := newWorker(Options{JobTimeout: tt.timeout, ResultTTL: time.Hour}, tt.handle)
+			created := create(t, conn, models.ReportJob{})
+			job, err := p.claim()
+			if err != nil || job == nil {
+				t.Fatalf("claim() = %v, %v", job, err)
+			}
+
+			before := time.Now()
+			p.process(context.Background(), *job)
+			got := reload(t, conn, created.ID)
+			if got.Status != tt.status || got.Error != tt.wantError || got.LeaseUntil != nil || got.CompletedAt == nil {
+				t.Errorf("job is %s with error %q, lease %v, completed %v, want %s with error %q and no lease",
+					got.Status, got.Error, got.LeaseUntil, got.CompletedAt, tt.status, tt.wantError)
+			}
+			if tt.status != models.ReportJobDone {
+				return
+			}
+			if got.FileName != "report.csv" || got.ContentType != "text/csv" || string(got.Data) != "a,b\n" {
+				t.Errorf("result %q %q %q, want the generated file", got.FileName, got.ContentType, got.Data)
+			}
+			if got.ExpiresAt == nil || got.ExpiresAt.Before(before.Add(time.Hour)) || got.ExpiresAt.After(time.Now().Add(time.Hour)) {
+				t.Errorf("expires at %v, want the result TTL from now", got.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestProcessCancelled(t *testing.T) {
+	conn := dbtest.Open(t, &models.ReportJob{})
+	started := make(chan struct{})
+	q, p := newWorker(Options{}, func(ctx context.Context, _ models.ReportJob) (map[string]any, error) {
+		close(started)
+		<-ctx.Done()
+		return map[string]any{"data": []byte("late")}, nil
+	})
+	created := create(t, conn, models.ReportJob{})
+	job, err := p.claim()
+	if err != nil || job == nil {
+		t.Fatalf("claim() = %v, %v", job, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.process(context.Background(), *job)
+		close(done)
+	}()
+	<-started
+	if ok, err := q.Cancel(created.ID); err != nil || !ok {
+		t.Fatalf("Cancel() = %v, %v, want true", ok, err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job still running after Cancel")
+	}
+	if got := reload(t, conn, created.ID); got.Status != models.ReportJobCancelled || got.Data != nil {
+		t.Errorf("cancelled job is %s with %d bytes, want CANCELLED without a result", got.Status, len(got.Data))
+	}
+	if ok, err := q.Cancel(created.ID); err != nil || ok {
+		t.Errorf("second Cancel() = %v, %v, want false", ok, err)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		job     models.ReportJob
+		expired bool
+	}{
+		{"past TTL", models.ReportJob{Status: models.ReportJobDone, ExpiresAt: ptr(now.Add(-time.Minute))}, true},
+		{"within TTL", models.ReportJob{Status: models.ReportJobDone, ExpiresAt: ptr(now.Add(time.Minute))}, false},
+		{"failed", models.ReportJob{Status: models.ReportJobFailed, ExpiresAt: ptr(now.Add(-time.Minute))}, false},
+		{"running", models.ReportJob{Status: models.ReportJobProcessing}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dbtest.Open(t, &models.ReportJob{})
+			tt.job.FileName, tt.job.Data = "report.pdf", []byte("%PDF")
+			created := create(t, conn, tt.job)
+
+			New[models.ReportJob]("report").expire()
+			got := reload(t, conn, created.ID)
+			if !tt.expired {
+				if got.Status != created.Status || string(got.Data) != "%PDF" {
+					t.Errorf("job changed to %s with %d bytes, want it left alone", got.Status, len(got.Data))
+				}
+				return
+			}
+			if got.Status != models.ReportJobExpired || got.Data != nil {
+				t.Errorf("job is %s with %d bytes, want EXPIRED without its file", got.Status, len(got.Data))
+			}
+			if got.FileName != "report.pdf" {
+				t.Errorf("file name %q, want it kept for status queries", got.FileName)
+			}
+		})
+	}
+}
+
+// TestOtherTable runs an export job through the same queue as report jobs.
+func TestOtherTable(t *testing.T) {
+	conn := dbtest.Open(t, &models.ExportJob{})
+	q := New[models.ExportJob]("export")
+	w := &worker[models.ExportJob]{q: q, opts: Options{PollInterval: time.Second, JobTimeout: time.Minute, ResultTTL: time.Hour},
+		handle: func(context.Context, models.ExportJob) (map[string]any, error) {
+			return map[string]any{"file_name": "export.zip", "data": []byte("PK")}, nil
+		}}
+	created := models.ExportJob{UserID: 7, Status: models.ExportStatusPending}
+	if err := conn.Create(&created).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := w.claim()
+	if err != nil || job == nil || job.ID != created.ID || job.Attempts != 1 || job.StartedAt == nil {
+		t.Fatalf("claim() = %+v, %v, want the export leased", job, err)
+	}
+	w.process(context.Background(), *job)
+	var got models.ExportJob
+	if err := conn.First(&got, created.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.ExportStatusDone || got.FileName != "export.zip" || string(got.Data) != "PK" || got.ExpiresAt == nil {
+		t.Errorf("export is %s with %q, %d bytes, expiry %v, want DONE with its archive", got.Status, got.FileName, len(got.Data), got.ExpiresAt)
+	}
+}
+
+func TestRunStops(t *testing.T) {
+	dbtest.Open(t, &models.ReportJob{})
+	q := New[models.ReportJob]("report")
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		q.Run(ctx, Options{Workers: 2, PollInterval: time.Second, JobTimeout: time.Minute}, nil)
+		close(stopped)
+	}()
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() still running after its context was cancelled")
+	}
+}
@@ -0,0 +1,238 @@
+package ledger
+
+import (
+	"context"
+	e "errors"
+	"net/http"
+	"strings"
+
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/budget"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PublicUserColumns are the columns needed to show a user to someone else.
+var PublicUserColumns = []string{"id", "name", "email", "email_visibility"}
+
+// PublicUser is a user as shown to the viewer, with the email only when the viewer is the user or
+// the user shares it with co-members. Callers make sure the two share a group.
+func PublicUser(user models.User, viewer uint) dto.PublicUser {
+	p := dto.PublicUser{ID: user.ID, Name: user.Name}
+	if user.EmailVisibleTo(viewer) {
+		p.Email = user.Email
+	}
+	return p
+}
+
+// GroupDetail returns a group the user is a member of with its bills, budgets and members.
+func GroupDetail(userId, groupID uint) (dto.GroupDetailResponse, error) {
+	var resp dto.GroupDetailResponse
+	dbc := db.GetDb()
+	var group models.Group
+	if err := dbc.Joins("JOIN group_members ON groups.id = group_members.group_id AND group_members.deleted_at IS NULL").
+		Where("groups.id = ? AND group_members.user_id = ?", groupID, userId).First(&group).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return resp, fail(http.StatusNotFound, errors.ErrGroupNotFound)
+		}
+		log.Error("Failed to fetch group membership", zap.Error(err))
+		return resp, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	var bills []models.Bill
+	if err := dbc.Preload("History", func(db *gorm.DB) *gorm.DB { return db.Order("paid_at") }).
+		Where("group_id = ? OR id = ?", group.ID, group.BillID).Order("id").Find(&bills).Error; err != nil {
+		log.Error("Failed to fetch bills", zap.Error(err))
+		return resp, fail(http.StatusInternalServerError, errors.ErrWhileFetchingBill)
+	}
+	var members []models.GroupMember
+	if err := dbc.Where("group_id = ?", group.ID).Order("id").Find(&members).Error; err != nil {
+		log.Error("Failed to fetch group members", zap.Error(err))
+		return resp, fail(http.StatusInternalServerError, errors.ErrWhileFetchingMembers)
+	}
+	userIDs := make([]uint, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+	}
+	var users []models.User
+	if err := dbc.Select(PublicUserColumns).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		log.Error("Failed to fetch member profiles", zap.Error(err))
+		return resp, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	budgets, err := budget.Statuses([]uint{group.ID})
+	if err != nil {
+		log.Error("Failed to fetch group budgets", zap.Error(err))
+		return resp, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+
+	resp = dto.GroupDetailResponse{
+		ID:                 group.ID,
+		Name:               group.Name,
+		Status:             group.Status,
+		CreatedBy:          group.CreatedBy,
+		CreatedAt:          group.CreatedAt,
+		TotalAmount:        group.TotalAmount,
+		PerUserSplitAmount: group.PerUserSplitAmount,
+		PaidAmount:         group.PaidAmount,
+		Bills:              []dto.GroupBill{},
+		Members:            groupMemberDetails(group, members, users, userId),
+		Budgets:            budgets[group.ID],
+	}
+	if resp.Budgets == nil {
+		resp.Budgets = []dto.BudgetStatus{}
+	}
+	for _, bill := range bills {
+		history := bill.History
+		if history == nil {
+			history = []models.BillHistory{}
+		}
+		resp.Bills = append(resp.Bills, dto.GroupBill{
+			ID:        bill.ID,
+			Name:      bill.Name,
+			Category:  bill.Category,
+			Amount:    bill.Amount,
+			Completed: bill.Completed,
+			CreatedAt: bill.CreatedAt,
+			History:   history,
+		})
+	}
+	return resp, nil
+}
+
+// groupMemberDetails resolves the profiles of the members as seen by the viewer.
+func groupMemberDetails(group models.Group, members []models.GroupMember, users []models.User, viewer uint) []dto.GroupMemberDetail {
+	profiles := make(map[uint]models.User, len(users))
+	for _, user := range users {
+		profiles[user.ID] = user
+	}
+	details := make([]dto.GroupMemberDetail, 0, len(members))
+	for _, m := range members {
+		user := PublicUser(profiles[m.UserID], viewer)
+		details = append(details, dto.GroupMemberDetail{
+			UserID:      m.UserID,
+			Name:        user.Name,
+			Email:       user.Email,
+			IsOwner:     m.UserID == group.CreatedBy,
+			SplitAmount: m.SplitAmount,
+			HasPaid:     m.HasPaid,
+			PaidAt:      m.PaidAt,
+			Remarks:     m.Remarks,
+			JoinedAt:    m.CreatedAt,
+		})
+	}
+	return details
+}
+
+// GroupFilter selects a page of the groups of a user, newest first.
+type GroupFilter struct {
+	Owned  bool   // only the groups the user created, instead of every group they are a member of
+	Status string // PENDING or DONE, any when empty
+	After  uint   // id of the last group of the previous page
+	Limit  int
+}
+
+// Groups returns a page of the groups of the user.
+func Groups(userId uint, filter GroupFilter) ([]models.Group, error) {
+	query := db.GetDb()
+	if filter.Owned {
+		query = query.Where("groups.created_by = ?", userId)
+	} else {
+		query = query.Joins("JOIN group_members ON groups.id = group_members.group_id AND group_members.deleted_at IS NULL").
+			Where("group_members.user_id = ?", userId)
+	}
+	if filter.Status != "" {
+		query = query.Where("groups.status = ?", filter.Status)
+	}
+	if filter.After != 0 {
+		query = query.Where("groups.id < ?", filter.After)
+	}
+	var groups []models.Group
+	if err := query.Order("groups.id DESC").Limit(filter.Limit).Find(&groups).Error; err != nil {
+		log.Error("Failed to fetch groups", zap.Error(err))
+		return nil, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	return groups, nil
+}
+
+// DeleteGroup deletes a group created by the user. Groups of other users are reported as not found.
+func DeleteGroup(ctx context.Context, userId, groupID uint) error {
+	var group models.Group
+	if err := db.GetDb().Where("id = ?", groupID).First(&group).Error; err != nil {
+		log.Error("Group not found", zap.Error(err))
+		return fail(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+	if group.CreatedBy != userId {
+		return fail(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+	if err := db.GetDb().Delete(&group).Error; err != nil {
+		log.Error("Failed to delete group", zap.Error(err))
+		return fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionGroupDeleted, EntityType: audit.EntityGroup, EntityID: group.ID, GroupID: group.ID, Before: group})
+	return nil
+}
+
+// UpdateBill changes the name or category of a bill of a group created by the user.
+func UpdateBill(ctx context.Context, userId, groupID, billID uint, input dto.UpdateBillRequest) (models.Bill, error) {
+	var bill models.Bill
+	var group models.Group
+	if err := db.GetDb().Where("id = ?", groupID).First(&group).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return bill, fail(http.StatusNotFound, errors.ErrGroupNotFound)
+		}
+		log.Error("Failed to fetch group", zap.Error(err))
+		return bill, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	if group.CreatedBy != userId {
+		return bill, fail(http.StatusForbidden, errors.ErrForbidden)
+	}
+	if err := db.GetDb().Where("id = ? AND group_id = ?", billID, group.ID).First(&bill).Error; err != nil {
+		return bill, fail(http.StatusNotFound, errors.ErrBillNotFound)
+	}
+	if err := validate.ValidateStruct(input); err != nil {
+		return bill, fail(http.StatusBadRequest, errors.ErrValidationFailed(err.Error()))
+	}
+
+	before := bill
+	if input.Name != nil && strings.TrimSpace(*input.Name) != "" {
+		bill.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Category != nil {
+		category, err := ResolveCategory(userId, *input.Category)
+		if err != nil {
+			return bill, CategoryError(err)
+		}
+		bill.Category = category
+	}
+
+	if err := db.GetDb().Save(&bill).Error; err != nil {
+		log.Error("Failed to update bill", zap.Error(err))
+		return bill, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionBillUpdated, EntityType: audit.EntityBill, EntityID: bill.ID, GroupID: group.ID, Before: before, After: bill})
+	if bill.Name != before.Name || bill.Category != before.Category {
+		events.Publish(events.Event{
+			Type:      events.BillUpdated,
+			GroupID:   group.ID,
+			GroupName: group.Name,
+			ActorID:   userId,
+			Data: map[string]any{
+				"billId":           bill.ID,
+				"billName":         bill.Name,
+				"category":         bill.Category,
+				"previousName":     before.Name,
+				"previousCategory": before.Category,
+			},
+		})
+	}
+	if bill.Category != before.Category {
+		budget.Check(group, userId)
+	}
+	return bill, nil
+}
@@ -0,0 +1,358 @@
+// Package ledger holds the operations on groups, members, payments and accounts shared by the REST,
+// GraphQL and gRPC APIs, along with their authorisation rules.
+package ledger
+
+import (
+	"context"
+	e "errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/helper"
+	"github.com/mohdjishin/SplitWise/helper/audit"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const done = "DONE"
+
+// Error is a failed operation with the HTTP status it answers with.
+type Error struct {
+	Status int
+	Err    error
+}
+
+func (f *Error) Error() string { return f.Err.Error() }
+
+func (f *Error) Unwrap() error { return f.Err }
+
+func fail(status int, err error) *Error {
+	return &Error{Status: status, Err: err}
+}
+
+// ErrUnknownCategory is returned for a category that is neither predefined nor one of the user's.
+var ErrUnknownCategory = e.New("unknown category")
+
+// NormaliseCategory is the stored form of a category name.
+func NormaliseCategory(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ResolveCategory returns the stored form of a category name if it is predefined or one of the user's
+// custom categories. An empty name resolves to uncategorised.
+func ResolveCategory(userId uint, name string) (string, error) {
+	name = NormaliseCategory(name)
+	if name == "" {
+		return models.Uncategorised, nil
+	}
+	if slices.Contains(models.PredefinedCategories, name) {
+		return name, nil
+	}
+	var count int64
+	if err := db.GetDb().Model(&models.CustomCategory{}).Where("user_id = ? AND name = ?", userId, name).Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "", ErrUnknownCategory
+	}
+	return name, nil
+}
+
+// CategoryError is the failure for an error of ResolveCategory.
+func CategoryError(err error) *Error {
+	if e.Is(err, ErrUnknownCategory) {
+		return fail(http.StatusBadRequest, errors.ErrValidationFailed("unknown category, create it first or use one of the predefined categories"))
+	}
+	log.Error("Failed to resolve category", zap.Error(err))
+	return fail(http.StatusInternalServerError, errors.ErrInternalError)
+}
+
+// CreateGroup creates a group with its bill and adds the user to it.
+func CreateGroup(ctx context.Context, userId uint, input dto.CreateGroupWithBillRequest) (models.Group, models.Bill, error) {
+	var group models.Group
+	var bill models.Bill
+	if err := validate.ValidateStruct(input); err != nil {
+		return group, bill, fail(http.StatusBadRequest, errors.ErrValidationFailed(err.Error()))
+	}
+	category, err := ResolveCategory(userId, input.Bill.Category)
+	if err != nil {
+		return group, bill, CategoryError(err)
+	}
+
+	dbc := db.GetDb()
+	group = models.Group{
+		Name:      input.GroupName,
+		CreatedBy: userId,
+	}
+	if err := dbc.Create(&group).Error; err != nil {
+		log.Error("Failed to create group", zap.Error(err))
+		return group, bill, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+
+	groupMember := models.GroupMember{
+		GroupID: group.ID,
+		UserID:  userId,
+	}
+	if err := dbc.Create(&groupMember).Error; err != nil {
+		log.Error("Failed to add creator to group", zap.Error(err))
+		return group, bill, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+
+	bill = models.Bill{
+		Name:     input.Bill.Name,
+		Amount:   input.Bill.Amount,
+		Category: category,
+		GroupID:  group.ID,
+	}
+	if err := dbc.Create(&bill).Error; err != nil {
+		log.Error("Failed to create bill", zap.Error(err))
+		return group, bill, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+
+	group.BillID = bill.ID
+	if err := dbc.Save(&group).Error; err != nil {
+		log.Error("Failed to update group with bill", zap.Error(err))
+		return group, bill, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionGroupCreated, EntityType: audit.EntityGroup, EntityID: group.ID, GroupID: group.ID, After: group})
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionMemberAdded, EntityType: audit.EntityMember, EntityID: groupMember.ID, GroupID: group.ID, After: groupMember})
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionBillCreated, EntityType: audit.EntityBill, EntityID: bill.ID, GroupID: group.ID, After: bill})
+	events.Publish(events.Event{
+		Type:      events.BillCreated,
+		GroupID:   group.ID,
+		GroupName: group.Name,
+		ActorID:   userId,
+		Data:      map[string]any{"billId": bill.ID, "billName": bill.Name, "amount": bill.Amount},
+	})
+	return group, bill, nil
+}
+
+// AddMembers adds the users with the emails to a group created by the user and splits the bill
+// again between all members.
+func AddMembers(ctx context.Context, userId uint, groupID uint, emails []string) (models.Group, error) {
+	var group models.Group
+	if err := validate.ValidateStruct(dto.AddUsersToGroupRequest{UserEmailIds: emails}); err != nil {
+		return group, fail(http.StatusBadRequest, errors.ErrValidationFailed(err.Error()))
+	}
+	dbc := db.GetDb()
+	if err := dbc.Where("id = ? AND created_by = ?", groupID, userId).First(&group).Error; err != nil {
+		log.Error("Group not found", zap.Error(err))
+		return group, fail(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+
+	var users []models.User
+	if err := dbc.Select("id", "email").Where("email IN ?", emails).Find(&users).Error; err != nil {
+		log.Error("Failed to fetch users", zap.Error(err))
+		return group, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	userList := make(map[string]uint, len(users))
+	for _, user := range users {
+		userList[user.Email] = user.ID
+	}
+	var memberIDs []uint
+	if err := dbc.Model(&models.GroupMember{}).Where("group_id = ?", group.ID).Pluck("user_id", &memberIDs).Error; err != nil {
+		log.Error("Failed to fetch group members", zap.Error(err))
+		return group, fail(http.StatusInternalServerError, errors.ErrWhileFetchingMembers)
+	}
+
+	missingUsers := []string{}
+	existingUsers := []string{}
+	for _, email := range emails {
+		id, ok := userList[email]
+		if !ok {
+			missingUsers = append(missingUsers, email)
+		} else if slices.Contains(memberIDs, id) {
+			existingUsers = append(existingUsers, email)
+		}
+	}
+	if len(missingUsers) > 0 {
+		return group, fail(http.StatusNotFound, errors.ErrUsersNotFound(missingUsers))
+	}
+	if len(existingUsers) > 0 {
+		return group, fail(http.StatusConflict, errors.ErrUsersAlreadyExists(existingUsers))
+	}
+
+	addedUserIDs := []uint{}
+	for _, email := range emails {
+		if slices.Contains(addedUserIDs, userList[email]) {
+			continue
+		}
+		newGroupMember := models.GroupMember{
+			GroupID: group.ID,
+			UserID:  userList[email],
+		}
+		if err := dbc.Create(&newGroupMember).Error; err != nil {
+			log.Error("Failed to add user to group", zap.Error(err))
+			return group, fail(http.StatusInternalServerError, errors.ErrInternalError)
+		}
+		audit.Record(ctx, userId, audit.Entry{Action: audit.ActionMemberAdded, EntityType: audit.EntityMember, EntityID: newGroupMember.ID, GroupID: group.ID, After: newGroupMember})
+		addedUserIDs = append(addedUserIDs, newGroupMember.UserID)
+	}
+
+	var groupMembers []models.GroupMember
+	if err := dbc.Where("group_id = ?", group.ID).Find(&groupMembers).Error; err != nil {
+		log.Error("Failed to fetch group members", zap.Error(err))
+		return group, fail(http.StatusInternalServerError, errors.ErrWhileFetchingMembers)
+	}
+
+	var bill models.Bill
+	if err := dbc.Where("id = ?", group.BillID).First(&bill).Error; err != nil {
+		log.Error("Failed to fetch bill", zap.Error(err))
+		return group, fail(http.StatusInternalServerError, errors.ErrWhileFetchingBill)
+	}
+
+	if numMembers := len(groupMembers); numMembers > 0 {
+		perUserSplitAmount := bill.Amount / float64(numMembers)
+		groupBefore := group
+		group.TotalAmount = bill.Amount
+		group.PerUserSplitAmount = perUserSplitAmount
+
+		if err := dbc.Save(&group).Error; err != nil {
+			log.Error("Failed to update group with total and per-user split amounts", zap.Error(err))
+			return group, fail(http.StatusInternalServerError, errors.ErrInternalErrorWithMessage("Failed to update total and per-user split amounts"))
+		}
+		audit.Record(ctx, userId, audit.Entry{Action: audit.ActionGroupUpdated, EntityType: audit.EntityGroup, EntityID: group.ID, GroupID: group.ID, Before: groupBefore, After: group})
+
+		for _, member := range groupMembers {
+			memberBefore := member
+			member.SplitAmount = perUserSplitAmount
+			if err := dbc.Save(&member).Error; err != nil {
+				log.Error("Failed to update member split amount", zap.Error(err))
+				return group, fail(http.StatusInternalServerError, errors.ErrInternalErrorWithMessage("Failed to update split amount for members"))
+			}
+			if memberBefore.SplitAmount != member.SplitAmount {
+				audit.Record(ctx, userId, audit.Entry{Action: audit.ActionMemberUpdated, EntityType: audit.EntityMember, EntityID: member.ID, GroupID: group.ID, Before: memberBefore, After: member})
+			}
+		}
+	}
+
+	if len(addedUserIDs) > 0 {
+		events.Publish(events.Event{
+			Type:      events.MemberAdded,
+			GroupID:   group.ID,
+			GroupName: group.Name,
+			ActorID:   userId,
+			UserIDs:   addedUserIDs,
+		})
+	}
+	return group, nil
+}
+
+// MarkPayment records that the user paid their share of a group they belong to, completing the
+// bill and the group once everyone has paid.
+func MarkPayment(ctx context.Context, userId uint, groupID uint, remarks string) (models.GroupMember, error) {
+	dbc := db.GetDb()
+	var groupMember models.GroupMember
+	if err := dbc.Where("group_id = ? AND user_id = ?", groupID, userId).First(&groupMember).Error; err != nil {
+		log.Error("Group member not found", zap.Error(err))
+		return groupMember, fail(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+	if groupMember.HasPaid {
+		log.Warn("Payment already made by user", zap.Uint("user_id", userId))
+		return groupMember, fail(http.StatusConflict, errors.ErrPaymentAlreadyMade)
+	}
+
+	memberBefore := groupMember
+	paidAt := time.Now()
+	groupMember.HasPaid = true
+	groupMember.PaidAt = &paidAt
+	groupMember.Remarks = remarks
+	if err := dbc.Save(&groupMember).Error; err != nil {
+		log.Error("Failed to update payment status", zap.Error(err))
+		return groupMember, fail(http.StatusInternalServerError, errors.ErrPaymentFailed)
+	}
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionPaymentMarked, EntityType: audit.EntityPayment, EntityID: groupMember.ID, GroupID: groupMember.GroupID, Before: memberBefore, After: groupMember})
+
+	var group models.Group
+	if err := dbc.Where("id = ?", groupID).First(&group).Error; err != nil {
+		log.Error("Group not found", zap.Error(err))
+		return groupMember, fail(http.StatusNotFound, errors.ErrGroupNotFound)
+	}
+
+	groupBefore := group
+	group.PaidAmount += groupMember.SplitAmount
+	if group.PaidAmount >= group.TotalAmount {
+		var bill models.Bill
+		if err := dbc.Where("id = ?", group.BillID).First(&bill).Error; err == nil {
+			billBefore := bill
+			bill.Completed = true
+			if err := dbc.Save(&bill).Error; err != nil {
+				log.Error("Failed to mark bill as completed", zap.Error(err))
+				return groupMember, fail(http.StatusInternalServerError, errors.ErrBillCompletionFailed)
+			}
+			audit.Record(ctx, userId, audit.Entry{Action: audit.ActionBillUpdated, EntityType: audit.EntityBill, EntityID: bill.ID, GroupID: group.ID, Before: billBefore, After: bill})
+		}
+		group.Status = done
+	}
+
+	if err := dbc.Save(&group).Error; err != nil {
+		log.Error("Failed to update group paid amount", zap.Error(err))
+		return groupMember, fail(http.StatusInternalServerError, errors.ErrGroupUpdateFailed)
+	}
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionGroupUpdated, EntityType: audit.EntityGroup, EntityID: group.ID, GroupID: group.ID, Before: groupBefore, After: group})
+
+	var user models.User
+	if err := dbc.Select("name").Where("id = ?", userId).First(&user).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("User not found", zap.Uint("userID", userId))
+			return groupMember, fail(http.StatusNotFound, errors.ErrUserNotFound)
+		}
+		log.Error("Error retrieving user", zap.Error(err))
+		return groupMember, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	_ = helper.LogBillHistory(group.BillID, groupMember.SplitAmount, user.Name, userId)
+
+	events.Publish(events.Event{
+		Type:      events.PaymentMarked,
+		GroupID:   group.ID,
+		GroupName: group.Name,
+		ActorID:   userId,
+		Data:      map[string]any{"amount": groupMember.SplitAmount, "remarks": groupMember.Remarks},
+	})
+	if groupBefore.Status != done && group.Status == done {
+		events.Publish(events.Event{
+			Type:      events.GroupCompleted,
+			GroupID:   group.ID,
+			GroupName: group.Name,
+			ActorID:   userId,
+			Data:      map[string]any{"totalAmount": group.TotalAmount},
+		})
+	}
+	return groupMember, nil
+}
+
+// SetEmailVisibility sets who can see the user's email, models.EmailPrivate or models.EmailMembers.
+func SetEmailVisibility(ctx context.Context, userId uint, visibility string) (models.User, error) {
+	var user models.User
+	if err := validate.ValidateStruct(dto.UpdatePrivacyRequest{EmailVisibility: visibility}); err != nil {
+		return user, fail(http.StatusBadRequest, errors.ErrValidationFailed(err.Error()))
+	}
+	dbc := db.GetDb()
+	if err := dbc.Where("id = ?", userId).First(&user).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return user, fail(http.StatusNotFound, errors.ErrUserNotFound)
+		}
+		log.Error("Failed to fetch user", zap.Error(err))
+		return user, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	before := user.EmailVisibility
+	if err := dbc.Model(&user).Update("email_visibility", visibility).Error; err != nil {
+		log.Error("Failed to save privacy settings", zap.Error(err))
+		return user, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	user.EmailVisibility = visibility
+	audit.Record(ctx, userId, audit.Entry{Action: audit.ActionPrivacyUpdated, EntityType: audit.EntityAccount, EntityID: user.ID,
+		Before: map[string]string{"emailVisibility": before},
+		After:  map[string]string{"emailVisibility": user.EmailVisibility}})
+	return user, nil
+}
@@ -0,0 +1,32 @@
+package ledger
+
+import (
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// PendingPayments returns every share the user has not paid yet, newest group first, and their total.
+func PendingPayments(userId uint) ([]dto.PendingPayments, float64, error) {
+	payments := []dto.PendingPayments{}
+	if err := db.GetDb().Table("group_members").
+		Joins("JOIN groups ON groups.id = group_members.group_id AND groups.deleted_at IS NULL").
+		Joins("LEFT JOIN bills ON bills.id = groups.bill_id").
+		Where("group_members.user_id = ? AND group_members.has_paid = ? AND group_members.deleted_at IS NULL", userId, false).
+		Select("groups.id AS group_id, groups.name AS group_name, groups.bill_id, " +
+			"group_members.split_amount AS amount, COALESCE(bills.amount, 0) AS bill_amount, groups.created_at").
+		Order("groups.created_at DESC, group_members.id DESC").
+		Scan(&payments).Error; err != nil {
+		log.Error("Failed to fetch pending payments", zap.Error(err))
+		return nil, 0, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	var total float64
+	for _, p := range payments {
+		total += p.Amount
+	}
+	return payments, total, nil
+}
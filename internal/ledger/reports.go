@@ -0,0 +1,233 @@
+package ledger
+
+import (
+	e "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/helper/layout"
+	"github.com/mohdjishin/SplitWise/helper/report"
+	"github.com/mohdjishin/SplitWise/helper/validate"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"github.com/mohdjishin/SplitWise/internal/reportjob"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultMaxPendingReportJobs = 5
+
+// QueueReport queues a report of the user to be generated in the background by the report job pool.
+// Dates are days in the user's timezone.
+func QueueReport(userId uint, input dto.CreateReportJobRequest) (models.ReportJob, error) {
+	var job models.ReportJob
+	if err := validate.ValidateStruct(input); err != nil {
+		return job, fail(http.StatusBadRequest, errors.ErrValidationFailed(err.Error()))
+	}
+	format, err := reportFormat(input.Type, input.Format)
+	if err != nil {
+		return job, fail(http.StatusBadRequest, err)
+	}
+	dbc := db.GetDb()
+	_, loc, err := UserLocale(dbc, userId)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return job, fail(http.StatusNotFound, errors.ErrUserNotFound)
+		}
+		log.Error("Failed to fetch user", zap.Error(err))
+		return job, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	from, to, err := ReportRange(dto.GetGroupReportRequest{From: input.From, To: input.To}, loc)
+	if err != nil {
+		return job, fail(http.StatusBadRequest, err)
+	}
+
+	params := models.ReportJobParams{From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}
+	if input.Type == models.ReportKindGroup {
+		if input.GroupID == 0 {
+			return job, fail(http.StatusBadRequest, errors.ErrValidationFailed("field 'groupId' is required for group reports"))
+		}
+		var group models.Group
+		if err := dbc.Where("id = ?", input.GroupID).First(&group).Error; err != nil {
+			if e.Is(err, gorm.ErrRecordNotFound) {
+				return job, fail(http.StatusNotFound, errors.ErrGroupNotFound)
+			}
+			log.Error("Failed to fetch group", zap.Error(err))
+			return job, fail(http.StatusInternalServerError, errors.ErrInternalError)
+		}
+		if group.CreatedBy != userId {
+			return job, fail(http.StatusForbidden, errors.ErrForbidden)
+		}
+		params = models.ReportJobParams{GroupID: input.GroupID, Comments: input.Comments}
+	}
+	params.NoCharts = input.Charts != nil && !*input.Charts
+
+	maxPending := config.GetConfig().ReportJobs.MaxPendingPerUser
+	if maxPending <= 0 {
+		maxPending = defaultMaxPendingReportJobs
+	}
+	// The user row is locked while counting so that concurrent requests cannot all pass the limit.
+	job = models.ReportJob{UserID: userId, Kind: input.Type, Format: format, Params: params, Status: models.ReportJobPending}
+	err = dbc.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", userId).First(&models.User{}).Error; err != nil {
+			return err
+		}
+		var pending int64
+		if err := tx.Model(&models.ReportJob{}).
+			Where("user_id = ? AND status IN ?", userId, []string{models.ReportJobPending, models.ReportJobProcessing}).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending >= int64(maxPending) {
+			return fail(http.StatusTooManyRequests, errors.ErrTooManyReportJobs)
+		}
+		return tx.Create(&job).Error
+	})
+	if err != nil {
+		var failure *Error
+		if e.As(err, &failure) {
+			return job, failure
+		}
+		log.Error("Failed to queue report job", zap.Error(err))
+		return job, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	reportjob.Wake()
+	return job, nil
+}
+
+// ReportJob returns a report job of the user, with the generated file when withData is set.
+func ReportJob(userId, jobID uint, withData bool) (models.ReportJob, error) {
+	query := db.GetDb().Where("id = ? AND user_id = ?", jobID, userId)
+	if !withData {
+		query = query.Omit("data")
+	}
+	var job models.ReportJob
+	if err := query.First(&job).Error; err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return job, fail(http.StatusNotFound, errors.ErrReportJobNotFound)
+		}
+		log.Error("Failed to fetch report job", zap.Error(err))
+		return job, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	return job, nil
+}
+
+// ReportFile returns a finished report job of the user with its file, failing when the file cannot
+// be downloaded yet or any more.
+func ReportFile(userId, jobID uint) (models.ReportJob, error) {
+	job, err := ReportJob(userId, jobID, true)
+	if err != nil {
+		return job, err
+	}
+	switch job.Status {
+	case models.ReportJobDone:
+		return job, nil
+	case models.ReportJobExpired:
+		return job, fail(http.StatusGone, errors.ErrReportExpired)
+	case models.ReportJobFailed:
+		return job, fail(http.StatusInternalServerError, errors.ErrReportFailed)
+	case models.ReportJobCancelled:
+		return job, fail(http.StatusConflict, errors.ErrReportCancelled)
+	default:
+		return job, fail(http.StatusConflict, errors.ErrReportNotReady)
+	}
+}
+
+// CancelReport cancels a queued or running report job of the user.
+func CancelReport(userId, jobID uint) (models.ReportJob, error) {
+	job, err := ReportJob(userId, jobID, false)
+	if err != nil {
+		return job, err
+	}
+	cancelled, err := reportjob.Cancel(job.ID)
+	if err != nil {
+		log.Error("Failed to cancel report job", zap.Uint("jobId", job.ID), zap.Error(err))
+		return job, fail(http.StatusInternalServerError, errors.ErrInternalError)
+	}
+	if !cancelled {
+		return job, fail(http.StatusConflict, errors.ErrReportJobFinished)
+	}
+	return ReportJob(userId, jobID, false)
+}
+
+// PreferredLocale returns the report locale and the timezone of a user's preferences. Users without
+// preferences get the bundled formatting and UTC.
+func PreferredLocale(user models.User) (layout.Locale, *time.Location) {
+	var locale layout.Locale
+	if user.Locale != "" {
+		locale, _, _ = layout.LocaleFor(user.Locale)
+	}
+	loc := time.UTC
+	if user.Timezone != "" {
+		if l, err := time.LoadLocation(user.Timezone); err == nil {
+			loc = l
+			locale.Timezone = user.Timezone
+		}
+	}
+	return locale, loc
+}
+
+// UserLocale loads the preferences of a user and returns their report locale and timezone.
+func UserLocale(dbc *gorm.DB, userId uint) (layout.Locale, *time.Location, error) {
+	var user models.User
+	if err := dbc.Select("id", "locale", "timezone").Where("id = ?", userId).First(&user).Error; err != nil {
+		return layout.Locale{}, time.UTC, err
+	}
+	locale, loc := PreferredLocale(user)
+	return locale, loc, nil
+}
+
+// ReportRange validates the requested date range and fills in the defaults, the last seven days
+// up to now. Dates are days in the timezone loc and both are inclusive, so the range ends at the end
+// of the to date.
+func ReportRange(req dto.GetGroupReportRequest, loc *time.Location) (from, to time.Time, err error) {
+	if err := validate.ValidateStruct(req); err != nil {
+		return from, to, errors.ErrValidationFailed(err.Error())
+	}
+
+	from = time.Now().In(loc).AddDate(0, 0, -7)
+	if req.From != nil {
+		if from, err = time.ParseInLocation("2006-01-02", *req.From, loc); err != nil {
+			return from, to, errors.ErrInvalid("invalid from date format")
+		}
+	}
+	to = time.Now().In(loc)
+	if req.To != nil {
+		if to, err = time.ParseInLocation("2006-01-02", *req.To, loc); err != nil {
+			return from, to, errors.ErrInvalid("invalid to date format")
+		}
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	if to.Before(from) {
+		return from, to, errors.ErrInvalid("from date must not be after to date")
+	}
+	return from, to, nil
+}
+
+// reportFormat validates the requested format for the report type and returns it with the default filled in.
+func reportFormat(kind, format string) (string, error) {
+	switch kind {
+	case models.ReportKindStatement:
+		if format == "" {
+			return "pdf", nil
+		}
+		if format != "pdf" && format != "json" {
+			return "", errors.ErrValidationFailed("statements can be generated as pdf or json")
+		}
+		return format, nil
+	case models.ReportKindGroups, models.ReportKindGroup:
+		renderer, err := report.ForFormat(format)
+		if err != nil {
+			return "", errors.ErrValidationFailed(err.Error())
+		}
+		return renderer.Format(), nil
+	default:
+		return "", errors.ErrValidationFailed(fmt.Sprintf("type must be one of %s, %s or %s", models.ReportKindGroups, models.ReportKindGroup, models.ReportKindStatement))
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// AdminOnly rejects requests from users without the ADMIN role. It must run after AuthMiddleware.
+func AdminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAdmin(r) {
+			log.Warn("Admin route accessed by non admin", zap.Float64("userId", GetCurrentUserId(r)))
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(errors.ErrForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// IsAdmin reports whether the current user has the ADMIN role.
+func IsAdmin(r *http.Request) bool {
+	var user models.User
+	if err := db.GetDb().Select("role").Where("id = ?", GetCurrentUserId(r)).First(&user).Error; err != nil {
+		log.Error("Failed to fetch user role", zap.Error(err))
+		return false
+	}
+	return user.Role == models.RoleAdmin
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/helper"
+)
+
+const contextClientIPKey = contextKey("clientIP")
+
+// ClientIP adds the address of the client to the request context, where the audit log reads it.
+func ClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), helper.ClientIP(r))))
+	})
+}
+
+// WithClientIP returns a context carrying the address of the client, as read by GetClientIP.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextClientIPKey, ip)
+}
+
+// GetClientIP returns the address of the client, or "" outside of a request.
+func GetClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(contextClientIPKey).(string)
+	return ip
+}
@@ -24,37 +24,56 @@ const authorization = "Authorization"
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Debug("Auth middleware", zap.Any("request-url", r.URL))
-		authHeader := r.Header.Get(authorization)
-		if authHeader == "" {
-			log.Error("Authorization header not found")
+		userId, err := Authenticate(r.Header.Get(authorization))
+		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
-			_ = json.NewEncoder(w).Encode(errors.ErrUnauthorizationHeaderNotFound)
+			_ = json.NewEncoder(w).Encode(err)
 			return
 		}
-		parts := strings.Split(authHeader, "Bearer ")
-		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
-			log.Error("Invalid token")
-			w.WriteHeader(http.StatusUnauthorized)
-			_ = json.NewEncoder(w).Encode(errors.ErrInvalidAuthHeader)
-			return
-		}
-		tokenString := strings.TrimSpace(parts[1])
-		claims := &jwt.MapClaims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(config.GetConfig().JwtString), nil
-		})
-
-		if err != nil || !token.Valid {
-			log.Error("Invalid token", zap.Any("error", err))
-			w.WriteHeader(http.StatusUnauthorized)
-			_ = json.NewEncoder(w).Encode(errors.ErrInvalidToken)
-			return
-		}
-		ctx := context.WithValue(r.Context(), ContextuserIdKey, (*claims)["id"].(float64))
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(WithUserId(r.Context(), userId)))
+	})
+}
+
+// Authenticate returns the id of the user an Authorization header value of the form "Bearer <token>"
+// was issued to. The error is the API error to answer with.
+func Authenticate(authHeader string) (float64, *errors.Error) {
+	if authHeader == "" {
+		log.Error("Authorization header not found")
+		return 0, errors.ErrUnauthorizationHeaderNotFound
+	}
+	parts := strings.Split(authHeader, "Bearer ")
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		log.Error("Invalid token")
+		return 0, errors.ErrInvalidAuthHeader
+	}
+	tokenString := strings.TrimSpace(parts[1])
+	claims := &jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.GetConfig().JwtString), nil
 	})
+
+	if err != nil || !token.Valid {
+		log.Error("Invalid token", zap.Any("error", err))
+		return 0, errors.ErrInvalidToken
+	}
+	userId, ok := (*claims)["id"].(float64)
+	if !ok {
+		log.Error("Token without user id")
+		return 0, errors.ErrInvalidToken
+	}
+	return userId, nil
+}
+
+// WithUserId returns a context carrying the id of the authenticated user, as read by GetCurrentUserId.
+func WithUserId(ctx context.Context, userId float64) context.Context {
+	return context.WithValue(ctx, ContextuserIdKey, userId)
 }
 
 func GetCurrentUserId(r *http.Request) float64 {
-	return r.Context().Value(ContextuserIdKey).(float64)
+	return UserIdFrom(r.Context())
+}
+
+// UserIdFrom returns the id of the authenticated user of a context made by WithUserId.
+func UserIdFrom(ctx context.Context) float64 {
+	return ctx.Value(ContextuserIdKey).(float64)
 }
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"golang.org/x/text/language"
+)
+
+var languageMatcher = language.NewMatcher(errors.Languages)
+
+// Localize translates the message of error responses into the language asked for in the
+// Accept-Language header. Every error response varies on the header, also when it stays in English,
+// so caches do not hand an English error to a German client or the other way round. Error bodies are
+// held back until the handler returns; other responses, including streams, are written through.
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepted, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		_, i, confidence := languageMatcher.Match(accepted...)
+		lang := errors.Languages[0]
+		if confidence != language.No {
+			lang = errors.Languages[i]
+		}
+
+		lw := &localizedWriter{ResponseWriter: w, lang: lang}
+		next.ServeHTTP(lw, r)
+		lw.finish()
+	})
+}
+
+// localizedWriter buffers responses with an error status so their message can be translated.
+type localizedWriter struct {
+	http.ResponseWriter
+	lang   language.Tag
+	status int
+	body   *bytes.Buffer
+}
+
+func (w *localizedWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	if status >= http.StatusBadRequest {
+		w.body = &bytes.Buffer{}
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *localizedWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		return w.body.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush passes flushes through for streaming responses.
+func (w *localizedWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && w.body == nil {
+		f.Flush()
+	}
+}
+
+func (w *localizedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish writes the held back error response, translated when it is an API error.
+func (w *localizedWriter) finish() {
+	if w.body == nil {
+		return
+	}
+	body := w.body.Bytes()
+	w.Header().Add("Vary", "Accept-Language")
+	var apiErr errors.Error
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Code != "" {
+		if message := errors.Translate(apiErr.Message, w.lang); message != apiErr.Message {
+			apiErr.Message = message
+			body = append(apiErr.JSON(), '\n')
+			w.Header().Set("Content-Language", w.lang.String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
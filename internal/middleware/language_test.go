@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mohdjishin/SplitWise/internal/errors"
+)
+
+func TestLocalize(t *testing.T) {
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(errors.ErrGroupNotFound)
+	})
+	detailed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(errors.ErrValidationFailed("field 'name' is required"))
+	})
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "The specified group could not be found"})
+	})
+
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		acceptLanguage string
+		wantStatus     int
+		wantMessage    string
+		wantLanguage   string
+	}{
+		{"no header", notFound, "", http.StatusNotFound, "The specified group could not be found", ""},
+		{"english", notFound, "en-US,en;q=0.9", http.StatusNotFound, "The specified group could not be found", ""},
+		{"german", notFound, "de-DE", http.StatusNotFound, "Die angegebene Gruppe wurde nicht gefunden", "de"},
+		{"french region", notFound, "fr-CA", http.StatusNotFound, "Le groupe indiqué est introuvable", "fr"},
+		{"preference order", notFound, "ja, es;q=0.8, de;q=0.5", http.StatusNotFound, "No se encontró el grupo indicado", "es"},
+		{"unsupported", notFound, "ja-JP", http.StatusNotFound, "The specified group could not be found", ""},
+		{"untranslated message", detailed, "de", http.StatusBadRequest, "field 'name' is required", ""},
+		{"success left alone", ok, "de", http.StatusOK, "The specified group could not be found", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/groups/1", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			Localize(tt.handler).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body struct{ Message string }
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q: %v", rec.Body.String(), err)
+			}
+			if body.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", body.Message, tt.wantMessage)
+			}
+			if got := rec.Header().Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLanguage)
+			}
+			wantVary := ""
+			if tt.wantStatus >= http.StatusBadRequest {
+				wantVary = "Accept-Language"
+			}
+			if got := rec.Header().Get("Vary"); got != wantVary {
+				t.Errorf("Vary = %q, want %q", got, wantVary)
+			}
+		})
+	}
+}
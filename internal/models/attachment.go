@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Attachment is a receipt (photo or PDF) attached to a bill or to a member's payment as proof.
+type Attachment struct {
+	ID          uint           `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	GroupID     uint           `gorm:"index" json:"groupId"`
+	BillID      *uint          `gorm:"index" json:"billId,omitempty"`    // set for bill receipts
+	PaymentID   *uint          `gorm:"index" json:"paymentId,omitempty"` // GroupMember id, set for payment proofs
+	UploadedBy  uint           `json:"uploadedBy"`
+	FileName    string         `json:"fileName"`
+	ContentType string         `json:"contentType"`
+	Size        int64          `json:"size"`
+	StorageKey  string         `json:"-"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	e "errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrAuditLogImmutable = e.New("audit log entries cannot be changed")
+
+// AuditLog is an append-only record of a state-changing action.
+type AuditLog struct {
+	ID         uint            `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time       `gorm:"index" json:"createdAt"`
+	ActorID    uint            `gorm:"index" json:"actorId"`    // User who performed the action, 0 for anonymous
+	Action     string          `gorm:"index" json:"action"`     // e.g. group.created, payment.marked
+	EntityType string          `gorm:"index" json:"entityType"` // group, member, bill, payment or account
+	EntityID   uint            `json:"entityId"`
+	GroupID    *uint           `gorm:"index" json:"groupId,omitempty"`
+	Before     json.RawMessage `gorm:"type:jsonb" json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `gorm:"type:jsonb" json:"after,omitempty" swaggertype:"object"`
+	RequestID  string          `json:"requestId"`
+	IP         string          `json:"ip"`
+}
+
+func (a *AuditLog) BeforeUpdate(*gorm.DB) error {
+	return ErrAuditLogImmutable
+}
+
+func (a *AuditLog) BeforeDelete(*gorm.DB) error {
+	return ErrAuditLogImmutable
+}
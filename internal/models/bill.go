@@ -10,6 +10,7 @@ import (
 type Bill struct {
 	gorm.Model `json:"-"`
 	Name       string        `json:"name"`
+	Category   string        `json:"category" gorm:"not null;default:uncategorised;index"`
 	Amount     float64       `json:"amount"`    // Total amount
 	GroupID    uint          `json:"groupId"`   // Reference to the associated group
 	Completed  bool          `json:"completed"` // Overall bill payment status
@@ -20,6 +21,7 @@ type BillHistory struct {
 	BillID    uint      `json:"billId"`    // Automatically inferred foreign key
 	Amount    float64   `json:"amount"`    // Amount related to this history entry
 	PaidBy    string    `json:"paidBy"`    // User who made the payment
+	PaidByID  uint      `json:"paidById"`  // Id of the user who made the payment
 	PaidAt    time.Time `json:"paidAt"`    // Time of payment
 	CreatedAt time.Time `json:"createdAt"` // Auto-create timestamp
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Budget caps the bill totals of a group, or of one category of it when Category is set.
+// LastAlerted is the highest threshold (in percent) already announced, so each crossing alerts once.
+type Budget struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	GroupID     uint      `gorm:"uniqueIndex:idx_budget_group_category" json:"groupId"`
+	Category    string    `gorm:"uniqueIndex:idx_budget_group_category" json:"category,omitempty"` // empty for the whole group
+	Amount      float64   `json:"amount"`
+	Thresholds  []int     `gorm:"serializer:json" json:"thresholds"`
+	LastAlerted int       `json:"lastAlerted"`
+	CreatedBy   uint      `json:"createdBy"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Uncategorised is the category of bills created without one.
+const Uncategorised = "uncategorised"
+
+// PredefinedCategories are available to every user.
+var PredefinedCategories = []string{
+	"food",
+	"groceries",
+	"travel",
+	"transport",
+	"rent",
+	"utilities",
+	"entertainment",
+	"shopping",
+	"health",
+	"other",
+	Uncategorised,
+}
+
+// CustomCategory is a bill category defined by a user in addition to the predefined ones.
+type CustomCategory struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UserID    uint      `gorm:"uniqueIndex:idx_custom_category_user_name" json:"-"`
+	Name      string    `gorm:"uniqueIndex:idx_custom_category_user_name" json:"name"`
+}
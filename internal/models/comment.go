@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comment is a message posted on a group or on one of its bills. Replies point to their
+// parent and to the top-level comment of their thread.
+type Comment struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	GroupID   uint           `gorm:"index" json:"groupId"`
+	BillID    *uint          `gorm:"index" json:"billId,omitempty"`   // nil for comments on the group itself
+	ParentID  *uint          `gorm:"index" json:"parentId,omitempty"` // nil for top-level comments
+	RootID    *uint          `gorm:"index" json:"rootId,omitempty"`   // top-level comment of the thread, nil for top-level comments
+	AuthorID  uint           `gorm:"index" json:"authorId"`
+	Body      string         `json:"body"`
+	EditedAt  *time.Time     `json:"editedAt,omitempty"`
+	Removed   bool           `json:"removed"` // deleted comment kept as a placeholder because it has replies
+}
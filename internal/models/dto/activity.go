@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// ActivityEntry is one item of a group's activity feed.
+// @Description Activity feed entry. Types are group.created, member.joined, bill.created, bill.updated, bill.completed, payment.marked and group.completed.
+// @Name ActivityEntry
+type ActivityEntry struct {
+	Type        string    `json:"type"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	ActorID     uint      `json:"actorId,omitempty"`
+	ActorName   string    `json:"actorName,omitempty"`
+	MemberID    uint      `json:"memberId,omitempty"` // user the entry is about, e.g. who joined
+	MemberName  string    `json:"memberName,omitempty"`
+	BillID      uint      `json:"billId,omitempty"`
+	BillName    string    `json:"billName,omitempty"`
+	Amount      float64   `json:"amount,omitempty"`
+	Remarks     string    `json:"remarks,omitempty"`
+	Description string    `json:"description"`
+}
+
+// ActivityFeedResponse represents a page of a group's activity feed.
+// @Description Response model for the activity feed of a group, oldest first unless sorted by -createdAt.
+// @Name ActivityFeedResponse
+type ActivityFeedResponse struct {
+	Entries    []ActivityEntry `json:"entries"`
+	Total      int             `json:"total"`
+	Pagination Pagination      `json:"pagination"`
+}
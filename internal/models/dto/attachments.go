@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// AttachmentURLResponse represents a signed, time-limited download url for an attachment.
+// @Description Signed download url for an attachment. The url can be used without an Authorization header until it expires.
+// @Name AttachmentURLResponse
+type AttachmentURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AttachmentResponse represents a receipt or payment proof. The file itself is fetched through a
+// signed url.
+// @Description Response model for an attachment. billId is set for bill receipts, paymentId for payment proofs.
+// @Name AttachmentResponse
+type AttachmentResponse struct {
+	ID          uint      `json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	GroupID     uint      `json:"groupId"`
+	BillID      *uint     `json:"billId,omitempty"`
+	PaymentID   *uint     `json:"paymentId,omitempty"` // group member id
+	UploadedBy  uint      `json:"uploadedBy"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/mohdjishin/SplitWise/internal/models"
+
+// AuditLogListResponse represents a page of audit log entries.
+// @Description Response model for listing audit log entries, newest first.
+// @Name AuditLogListResponse
+// @Property entries []AuditLog "Audit log entries"
+// @Property total integer "Total number of entries matching the filters"
+type AuditLogListResponse struct {
+	Entries    []models.AuditLog `json:"entries"`
+	Total      int64             `json:"total"`
+	Pagination Pagination        `json:"pagination"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// Budget states
+const (
+	BudgetOK       = "OK"
+	BudgetWarning  = "WARNING"
+	BudgetExceeded = "EXCEEDED"
+)
+
+// SetBudgetRequest represents the request body for setting a group or category budget.
+// @Description Request model for a budget. Without a category the budget covers the whole group. Thresholds are percentages of the amount that raise an alert when crossed, 80 and 100 by default.
+// @Name SetBudgetRequest
+type SetBudgetRequest struct {
+	Category   string  `json:"category,omitempty"`
+	Amount     float64 `json:"amount" validate:"required"`
+	Thresholds []int   `json:"thresholds,omitempty"`
+}
+
+// BudgetStatus compares a budget with the bills of its group.
+// @Description Budget versus actual spending. Reached is the highest threshold crossed, 0 when none.
+// @Name BudgetStatus
+type BudgetStatus struct {
+	ID          uint    `json:"id"`
+	Category    string  `json:"category,omitempty"`
+	Amount      float64 `json:"amount"`
+	Spent       float64 `json:"spent"`
+	Remaining   float64 `json:"remaining"`
+	PercentUsed float64 `json:"percentUsed"`
+	Thresholds  []int   `json:"thresholds"`
+	Reached     int     `json:"reached"`
+	Status      string  `json:"status"` // OK, WARNING or EXCEEDED
+}
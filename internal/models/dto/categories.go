@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+// CategoryListResponse lists the bill categories available to the user.
+// @Description Predefined categories and the user's custom categories.
+// @Name CategoryListResponse
+type CategoryListResponse struct {
+	Predefined []string                `json:"predefined"`
+	Custom     []models.CustomCategory `json:"custom"`
+}
+
+// CreateCategoryRequest represents the request body for creating a custom category.
+// @Description Request model for a custom bill category. Names are stored in lower case.
+// @Name CreateCategoryRequest
+type CreateCategoryRequest struct {
+	Name string `json:"name" validate:"required,max=40"`
+}
+
+// UpdateBillRequest represents the request body for editing a bill. Omitted fields are left unchanged.
+// @Description Request model for editing the name or category of a bill.
+// @Name UpdateBillRequest
+type UpdateBillRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,max=200"`
+	Category *string `json:"category,omitempty"`
+}
+
+// BillResponse represents a bill of a group.
+// @Description Response model for a bill, without its payments.
+// @Name BillResponse
+type BillResponse struct {
+	ID        uint      `json:"id"`
+	GroupID   uint      `json:"groupId"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Amount    float64   `json:"amount"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CategoryAmount is the amount spent in one category.
+// @Description Amount spent in a category.
+// @Name CategoryAmount
+type CategoryAmount struct {
+	Category string  `json:"category"`
+	Amount   float64 `json:"amount"`
+}
+
+// MonthlySpending is the amount spent in one month, split by category.
+// @Description Amount spent in a month (YYYY-MM), split by category.
+// @Name MonthlySpending
+type MonthlySpending struct {
+	Month      string           `json:"month"`
+	Amount     float64          `json:"amount"`
+	Categories []CategoryAmount `json:"categories"`
+}
+
+// SpendingAnalyticsResponse aggregates spending per category and per month.
+// @Description Spending per category and per month. For a user it is their share of each bill, for a group the bill totals.
+// @Name SpendingAnalyticsResponse
+type SpendingAnalyticsResponse struct {
+	Scope      string            `json:"scope"` // user or group
+	GroupID    uint              `json:"groupId,omitempty"`
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Total      float64           `json:"total"`
+	ByCategory []CategoryAmount  `json:"byCategory"`
+	ByMonth    []MonthlySpending `json:"byMonth"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// CreateCommentRequest represents the request body for posting a comment.
+// @Description Request model for posting a comment on a group or a bill, optionally as a reply.
+// @Name CreateCommentRequest
+// @Property body string true "Comment text"
+// @Property parentId integer false "Id of the comment being replied to"
+type CreateCommentRequest struct {
+	Body     string `json:"body" validate:"required,max=2000" example:"Why is my share so high?"`
+	ParentID *uint  `json:"parentId,omitempty"`
+}
+
+// UpdateCommentRequest represents the request body for editing a comment.
+// @Description Request model for editing the text of a comment.
+// @Name UpdateCommentRequest
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=2000" example:"Never mind, it includes the taxi."`
+}
+
+// CommentResponse represents a comment together with its replies.
+// @Description A comment with author attribution and nested replies.
+// @Name CommentResponse
+type CommentResponse struct {
+	ID         uint              `json:"id"`
+	GroupID    uint              `json:"groupId"`
+	BillID     *uint             `json:"billId,omitempty"`
+	ParentID   *uint             `json:"parentId,omitempty"`
+	AuthorID   uint              `json:"authorId"`
+	AuthorName string            `json:"authorName"`
+	Body       string            `json:"body"`
+	Removed    bool              `json:"removed"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	EditedAt   *time.Time        `json:"editedAt,omitempty"`
+	Replies    []CommentResponse `json:"replies"`
+}
+
+// CommentListResponse represents a page of top-level comments.
+// @Description Response model for listing comments. Pagination applies to top-level comments; each carries all its replies.
+// @Name CommentListResponse
+type CommentListResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	Total      int64             `json:"total"`
+	Pagination Pagination        `json:"pagination"`
+}
@@ -3,7 +3,8 @@ package dto
 type CreateGroupWithBillRequest struct {
 	GroupName string `json:"groupName" validate:"required"`
 	Bill      struct {
-		Name   string  `json:"name" validate:"required"`
-		Amount float64 `json:"amount" validate:"required"`
+		Name     string  `json:"name" validate:"required"`
+		Amount   float64 `json:"amount" validate:"required"`
+		Category string  `json:"category,omitempty"` // predefined or custom category, uncategorised when empty
 	} `json:"bill" validate:"required"`
 }
@@ -4,9 +4,12 @@ import "github.com/mohdjishin/SplitWise/internal/models"
 
 // ONLY USER FOR INTERNAL USE
 type GroupReportRequest struct {
-	Bill     models.Bill          `json:"bill"`
-	Group    models.Group         `json:"group"`
-	History  []models.BillHistory `json:"history"`
-	Members  []models.GroupMember `json:"members"`
-	UserInfo map[uint]string      `json:"-"`
+	Bill        models.Bill          `json:"bill"`
+	Group       models.Group         `json:"group"`
+	History     []models.BillHistory `json:"history"`
+	Members     []models.GroupMember `json:"members"`
+	UserInfo    map[uint]string      `json:"-"`
+	Comments    []CommentResponse    `json:"comments,omitempty"`    // Optional appendix, threads on the group and its bills
+	Attachments []models.Attachment  `json:"attachments,omitempty"` // Receipts and payment proofs attached in the group
+	Budgets     []BudgetStatus       `json:"budgets,omitempty"`
 }
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+// GroupDetailResponse represents a group with its bills and members.
+// @Description A group as seen by one of its members, with its bills and the members' names and payment status.
+// @Name GroupDetailResponse
+type GroupDetailResponse struct {
+	ID                 uint                `json:"id"`
+	Name               string              `json:"name"`
+	Status             string              `json:"status"`
+	CreatedBy          uint                `json:"createdBy"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	TotalAmount        float64             `json:"totalAmount"`
+	PerUserSplitAmount float64             `json:"perUserSplitAmount"`
+	PaidAmount         float64             `json:"paidAmount"`
+	Bills              []GroupBill         `json:"bills"`
+	Members            []GroupMemberDetail `json:"members"`
+	Budgets            []BudgetStatus      `json:"budgets"`
+}
+
+// GroupBill is a bill of a group with its payments.
+type GroupBill struct {
+	ID        uint                 `json:"id"`
+	Name      string               `json:"name"`
+	Category  string               `json:"category"`
+	Amount    float64              `json:"amount"`
+	Completed bool                 `json:"completed"`
+	CreatedAt time.Time            `json:"createdAt"`
+	History   []models.BillHistory `json:"history"`
+}
+
+// GroupMemberDetail is a member of a group with their display name. Email is only set for the
+// viewer and for members who share their email with co-members.
+type GroupMemberDetail struct {
+	UserID      uint       `json:"userId"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email,omitempty"`
+	IsOwner     bool       `json:"isOwner"`
+	SplitAmount float64    `json:"splitAmount"`
+	HasPaid     bool       `json:"hasPaid"`
+	PaidAt      *time.Time `json:"paidAt,omitempty"`
+	Remarks     string     `json:"remarks,omitempty"`
+	JoinedAt    time.Time  `json:"joinedAt"`
+}
@@ -10,4 +10,11 @@ import "github.com/mohdjishin/SplitWise/internal/models"
 type ListOwnedGroupsResponse struct {
 	Group   models.Group         `json:"group"`
 	Members []models.GroupMember `json:"members"`
+	Budgets []BudgetStatus       `json:"budgets"`
+}
+
+// ListOwnedGroupsPage is a page of the groups owned by the user.
+type ListOwnedGroupsPage struct {
+	Groups     []ListOwnedGroupsResponse `json:"groups"`
+	Pagination Pagination                `json:"pagination"`
 }
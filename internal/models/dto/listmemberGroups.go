@@ -8,4 +8,11 @@ import "github.com/mohdjishin/SplitWise/internal/models"
 type ListMemberGroupsResponse struct {
 	Group   models.Group         `json:"group"`
 	Members []models.GroupMember `json:"members"`
+	Budgets []BudgetStatus       `json:"budgets"`
+}
+
+// ListMemberGroupsPage is a page of the groups the user belongs to.
+type ListMemberGroupsPage struct {
+	Groups     []ListMemberGroupsResponse `json:"groups"`
+	Pagination Pagination                 `json:"pagination"`
 }
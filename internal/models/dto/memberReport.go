@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// MemberReport is a personal statement of a member across all their groups.
+// @Description Statement of what the user owes and paid in every group they belong to, for groups created in the date range.
+// @Name MemberReport
+type MemberReport struct {
+	UserID  uint                `json:"userId"`
+	Name    string              `json:"name"`
+	From    time.Time           `json:"from"`
+	To      time.Time           `json:"to"`
+	Entries []MemberReportEntry `json:"entries"`
+	Totals  MemberReportTotals  `json:"totals"`
+}
+
+// MemberReportEntry is the member's share of one group's bill.
+// @Description The member's share of a bill and their payment of it.
+// @Name MemberReportEntry
+type MemberReportEntry struct {
+	GroupID     uint       `json:"groupId"`
+	GroupName   string     `json:"groupName"`
+	Owner       string     `json:"owner"`
+	Status      string     `json:"status"`
+	BillID      uint       `json:"billId"`
+	BillName    string     `json:"billName"`
+	Category    string     `json:"category"`
+	BillAmount  float64    `json:"billAmount"`
+	BillDate    time.Time  `json:"billDate"`
+	Share       float64    `json:"share"`
+	HasPaid     bool       `json:"hasPaid"`
+	PaidAmount  float64    `json:"paidAmount"`
+	PaidAt      *time.Time `json:"paidAt,omitempty"`
+	Remarks     string     `json:"remarks,omitempty"`
+	Outstanding float64    `json:"outstanding"`
+}
+
+// MemberReportTotals sums up a member report.
+// @Description Totals of a member report.
+// @Name MemberReportTotals
+type MemberReportTotals struct {
+	Groups      int     `json:"groups"`
+	Share       float64 `json:"share"`
+	Paid        float64 `json:"paid"`
+	Outstanding float64 `json:"outstanding"`
+}
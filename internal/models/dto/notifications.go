@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/mohdjishin/SplitWise/internal/models"
+
+// NotificationListResponse represents a page of the user's notifications.
+// @Description Response model for the notification inbox, newest first, with the number of unread notifications.
+// @Name NotificationListResponse
+type NotificationListResponse struct {
+	Notifications []models.Notification `json:"notifications"`
+	UnreadCount   int64                 `json:"unreadCount"`
+	Total         int64                 `json:"total"`
+	Pagination    Pagination            `json:"pagination"`
+}
+
+// NotificationPreferences represents which event types the user wants to be notified about.
+// @Description Map of event type (member.added, bill.created, bill.updated, payment.marked, group.completed, reminder.due, budget.threshold) to enabled flag.
+// @Name NotificationPreferences
+type NotificationPreferences struct {
+	Preferences map[string]bool `json:"preferences" validate:"required"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+// Pagination describes a page of a cursor paginated list. The next page is fetched by passing
+// nextCursor as the cursor query parameter with the same sort and filters.
+// @Description Pagination of a list, with the cursor of the next page when there is one.
+type Pagination struct {
+	Limit      int    `json:"limit" example:"20"`
+	Sort       string `json:"sort" example:"-createdAt"`
+	HasMore    bool   `json:"hasMore"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -3,9 +3,10 @@ package dto
 import "time"
 
 type Bill struct {
-	Amount float64
-	Paid   bool
-	Date   time.Time
+	Amount   float64
+	Paid     bool
+	Date     time.Time
+	Category string
 }
 
 type Group struct {
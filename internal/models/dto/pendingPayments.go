@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // PendingPaymentsWithTotalResponse  represents the response for listing pending payments.
 // @Description Response model for listing pending payments with total amount.
 // @Name PendingPaymentsWithTotalResponse
@@ -8,13 +10,16 @@ package dto
 // @Property message string false "any message"
 type PendingPaymentsWithTotalResponse struct {
 	PendingPayments []PendingPayments `json:"pendingPayments,omitempty"`
-	TotalAmount     float64           `json:"totalAmount,omitempty"`
+	TotalAmount     float64           `json:"totalAmount,omitempty"` // over every page matching the filters
 	Message         string            `json:"message,omitempty"`
+	Pagination      Pagination        `json:"pagination"`
 }
 
 type PendingPayments struct {
-	GroupID   uint    `json:"groupId"`
-	GroupName string  `json:"groupName"`
-	BillID    uint    `json:"billId"`
-	Amount    float64 `json:"amount"`
+	GroupID    uint      `json:"groupId"`
+	GroupName  string    `json:"groupName"`
+	BillID     uint      `json:"billId"`
+	Amount     float64   `json:"amount"`     // the user's share
+	BillAmount float64   `json:"billAmount"` // the whole bill
+	CreatedAt  time.Time `json:"createdAt"`
 }
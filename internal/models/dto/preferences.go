@@ -0,0 +1,19 @@
+package dto
+
+// UpdatePreferencesRequest represents the request body for changing the user's formatting preferences.
+// @Description Request model for the locale and timezone of the user. Fields left out keep their current value, an empty string resets one to the default.
+// @Name UpdatePreferencesRequest
+type UpdatePreferencesRequest struct {
+	Locale   *string `json:"locale,omitempty"`   // BCP 47 tag such as en-US, de-DE or en-IN
+	Timezone *string `json:"timezone,omitempty"` // IANA name such as Europe/Berlin
+}
+
+// PreferencesResponse represents the formatting preferences of the user.
+// @Description Locale and timezone reports are formatted with and report date ranges are read in. Locale is the supported locale closest to the one asked for, empty for the default formatting; timezone is UTC unless set. Example shows how amounts and dates look.
+// @Name PreferencesResponse
+type PreferencesResponse struct {
+	Locale    string   `json:"locale"`
+	Timezone  string   `json:"timezone"`
+	Example   string   `json:"example"`
+	Supported []string `json:"supportedLocales"`
+}
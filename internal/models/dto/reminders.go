@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// ReminderScheduleRequest represents the request body for configuring a group's reminder schedule.
+// @Description Request model for a reminder schedule. The first reminder is sent firstAfterDays after a member joined, then every repeatEveryDays until they pay (0 sends a single reminder).
+// @Name ReminderScheduleRequest
+type ReminderScheduleRequest struct {
+	Enabled         bool     `json:"enabled"`
+	FirstAfterDays  int      `json:"firstAfterDays"`
+	RepeatEveryDays int      `json:"repeatEveryDays"`
+	Channels        []string `json:"channels" validate:"required"`
+}
+
+// ReminderScheduleResponse represents the reminder schedule of a group.
+// @Description Response model for a reminder schedule.
+// @Name ReminderScheduleResponse
+type ReminderScheduleResponse struct {
+	GroupID         uint      `json:"groupId"`
+	Enabled         bool      `json:"enabled"`
+	FirstAfterDays  int       `json:"firstAfterDays"`
+	RepeatEveryDays int       `json:"repeatEveryDays"`
+	Channels        []string  `json:"channels"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	UpdatedBy       uint      `json:"updatedBy"`
+}
+
+// ReminderResponse represents a reminder sent to an unpaid member through one channel.
+// @Description Response model for a sent reminder. kind is SCHEDULED or NUDGE, status SENT or FAILED.
+// @Name ReminderResponse
+type ReminderResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	GroupID   uint      `json:"groupId"`
+	MemberID  uint      `json:"memberId"` // group member id
+	UserID    uint      `json:"userId"`
+	Kind      string    `json:"kind"`
+	SentBy    uint      `json:"sentBy"` // owner who nudged, 0 for scheduled reminders
+	Channel   string    `json:"channel"`
+	Amount    float64   `json:"amount"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ReminderListResponse represents a page of sent reminders.
+// @Description Response model for listing reminders, newest first.
+// @Name ReminderListResponse
+type ReminderListResponse struct {
+	Reminders  []ReminderResponse `json:"reminders"`
+	Total      int64              `json:"total"`
+	Pagination Pagination         `json:"pagination"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+// CreateReportJobRequest represents the request body for queueing a report.
+// @Description Request model for a background report. type is groups (groups owned by the user in the date range), group (detailed report of groupId) or statement (personal statement). Format is pdf (default), csv, xlsx or html, and pdf or json for statements. Dates default to the last seven days.
+// @Name CreateReportJobRequest
+type CreateReportJobRequest struct {
+	Type     string  `json:"type" validate:"required"`
+	Format   string  `json:"format,omitempty"`
+	From     *string `json:"from,omitempty" validate:"omitempty,dateFormat"`
+	To       *string `json:"to,omitempty" validate:"omitempty,dateFormat"`
+	GroupID  uint    `json:"groupId,omitempty"`
+	Comments bool    `json:"comments,omitempty"` // group reports only, append the comment threads
+	Charts   *bool   `json:"charts,omitempty"`   // PDF and HTML groups and group reports, false leaves out the charts
+}
+
+// ReportJobResponse represents the status of a report job.
+// @Description Status of a background report. The file can be downloaded from downloadUrl while the job is DONE, until expiresAt.
+// @Name ReportJobResponse
+// @Property status string "PENDING, PROCESSING, DONE, FAILED, CANCELLED or EXPIRED"
+type ReportJobResponse struct {
+	JobID       uint                   `json:"jobId"`
+	Type        string                 `json:"type"`
+	Format      string                 `json:"format"`
+	Params      models.ReportJobParams `json:"params"`
+	Status      string                 `json:"status"`
+	StatusURL   string                 `json:"statusUrl"`
+	DownloadURL string                 `json:"downloadUrl,omitempty"`
+	FileName    string                 `json:"fileName,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	StartedAt   *time.Time             `json:"startedAt,omitempty"`
+	CompletedAt *time.Time             `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time             `json:"expiresAt,omitempty"`
+}
+
+// ReportJobListResponse represents a page of the user's report jobs.
+// @Description Response model for listing report jobs, newest first.
+// @Name ReportJobListResponse
+type ReportJobListResponse struct {
+	Jobs       []ReportJobResponse `json:"jobs"`
+	Total      int64               `json:"total"`
+	Pagination Pagination          `json:"pagination"`
+}
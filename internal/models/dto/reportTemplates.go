@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReportTemplateResponse represents a stored report template.
+// @Description A report template of the user, or of a group when groupId is set. The template holds the title, branding, locale and sections of the report.
+// @Name ReportTemplateResponse
+// @Property kind string "groups or group"
+type ReportTemplateResponse struct {
+	Kind      string          `json:"kind"`
+	GroupID   uint            `json:"groupId,omitempty"`
+	Template  json.RawMessage `json:"template" swaggertype:"object"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
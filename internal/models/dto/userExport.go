@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+// ONLY USER FOR INTERNAL USE
+type UserExport struct {
+	Profile     ExportProfile
+	Groups      []models.Group
+	Bills       []models.Bill
+	Splits      []models.GroupMember
+	Payments    []ExportPayment
+	BillHistory []models.BillHistory
+	GeneratedAt time.Time
+}
+
+type ExportProfile struct {
+	ID              uint      `json:"id"`
+	Email           string    `json:"email"`
+	Name            string    `json:"name"`
+	EmailVisibility string    `json:"emailVisibility"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+type ExportPayment struct {
+	GroupID   uint      `json:"groupId"`
+	GroupName string    `json:"groupName"`
+	Amount    float64   `json:"amount"`
+	Remarks   string    `json:"remarks"`
+	PaidAt    time.Time `json:"paidAt"`
+}
+
+// ExportJobResponse represents the response returned when a data export is queued or polled.
+// @Description Status of an asynchronous personal data export.
+// @Name ExportJobResponse
+// @Property jobId integer "Id of the export job"
+// @Property status string "PENDING, PROCESSING, DONE, FAILED or EXPIRED"
+// @Property downloadUrl string "Url to download the archive while the job is DONE, until expiresAt"
+type ExportJobResponse struct {
+	JobID       uint       `json:"jobId"`
+	Status      string     `json:"status"`
+	StatusURL   string     `json:"statusUrl"`
+	DownloadURL string     `json:"downloadUrl,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+// UserResponse is the account of the current user.
+// @Description The current user's account. Credentials are never included.
+// @Name UserResponse
+type UserResponse struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	Role            string    `json:"role"`
+	Locale          string    `json:"locale,omitempty"`
+	Timezone        string    `json:"timezone,omitempty"`
+	EmailVisibility string    `json:"emailVisibility" enums:"private,members"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// PublicUser is another user as seen by a member of a shared group. Email is only set when the user
+// lets co-members see it.
+// @Description A user as seen by other members of their groups.
+// @Name PublicUser
+type PublicUser struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// RegisterResponse is returned when an account is created.
+type RegisterResponse struct {
+	Message string       `json:"message" example:"User registered"`
+	User    UserResponse `json:"user"`
+}
+
+// UpdatePrivacyRequest sets who can see the user's email.
+// @Description private keeps the email to the user; members shows it to members of the user's groups.
+// @Name UpdatePrivacyRequest
+type UpdatePrivacyRequest struct {
+	EmailVisibility string `json:"emailVisibility" validate:"required,oneof=private members" enums:"private,members"`
+}
+
+// LoginEventResponse represents a successful or failed login attempt on the user's account.
+// @Description Response model for a login attempt. reason is set for failed attempts.
+// @Name LoginEventResponse
+type LoginEventResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"userAgent"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// LoginEventListResponse represents a page of the login attempts on the user's account.
+// @Description Response model for the recent login attempts of the current user, newest first.
+// @Name LoginEventListResponse
+type LoginEventListResponse struct {
+	Events     []LoginEventResponse `json:"events"`
+	Pagination Pagination           `json:"pagination"`
+}
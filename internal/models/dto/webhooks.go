@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateWebhookRequest represents the request body for registering a webhook.
+// @Description Request model for registering a webhook. Leave groupId out for a system-wide webhook (admins only).
+// @Name CreateWebhookRequest
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"eventTypes" validate:"required"`
+	GroupID    *uint    `json:"groupId,omitempty"`
+}
+
+// UpdateWebhookRequest represents the request body for changing a webhook. Omitted fields are left unchanged.
+// @Description Request model for updating a webhook. Set rotateSecret to get a new signing secret.
+// @Name UpdateWebhookRequest
+type UpdateWebhookRequest struct {
+	URL          *string  `json:"url,omitempty" validate:"omitempty,url"`
+	EventTypes   []string `json:"eventTypes,omitempty"`
+	Active       *bool    `json:"active,omitempty"`
+	RotateSecret bool     `json:"rotateSecret,omitempty"`
+}
+
+// WebhookResponse represents a webhook. The signing secret is only returned when it is created or rotated.
+// @Description Response model for a webhook.
+// @Name WebhookResponse
+type WebhookResponse struct {
+	ID         uint      `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	GroupID    *uint     `json:"groupId,omitempty"` // nil for system-wide webhooks
+	CreatedBy  uint      `json:"createdBy"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	Secret     string    `json:"secret,omitempty"`
+}
+
+// WebhookDeliveryResponse represents a delivery of one event to a webhook.
+// @Description Response model for a webhook delivery, with the attempts made and the last response.
+// @Name WebhookDeliveryResponse
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	WebhookID      uint            `json:"webhookId"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time      `json:"lastAttemptAt,omitempty"`
+	ResponseStatus int             `json:"responseStatus,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	RedeliveryOf   *uint           `json:"redeliveryOf,omitempty"` // delivery this one was manually re-sent from
+}
+
+// WebhookListResponse represents a page of webhooks.
+// @Description Response model for listing webhooks.
+// @Name WebhookListResponse
+type WebhookListResponse struct {
+	Webhooks   []WebhookResponse `json:"webhooks"`
+	Total      int64             `json:"total"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// WebhookDeliveryListResponse represents a page of the delivery log of a webhook.
+// @Description Response model for the delivery log of a webhook, newest first.
+// @Name WebhookDeliveryListResponse
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Total      int64                     `json:"total"`
+	Pagination Pagination                `json:"pagination"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	ExportStatusPending    = JobPending
+	ExportStatusProcessing = JobProcessing
+	ExportStatusDone       = JobDone
+	ExportStatusFailed     = JobFailed
+	ExportStatusExpired    = JobExpired
+)
+
+// ExportJob tracks an asynchronous personal data export requested by a user. Like report jobs, a
+// worker of the job queue leases the job until LeaseUntil and the archive is dropped at ExpiresAt.
+type ExportJob struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	UserID      uint       `json:"userId" gorm:"index"`
+	Status      string     `json:"status" gorm:"index;default:PENDING"`
+	Attempts    int        `json:"attempts"`
+	LeaseUntil  *time.Time `json:"-"`
+	FileName    string     `json:"fileName"`
+	Data        []byte     `json:"-"` // Generated ZIP archive, cleared when it expires
+	Error       string     `json:"error,omitempty"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" gorm:"index"`
+}
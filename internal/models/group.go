@@ -29,6 +29,7 @@ type GroupMember struct {
 	GroupID     uint           `json:"groupId"`
 	UserID      uint           `json:"userId"`
 	HasPaid     bool           `json:"hasPaid"` // Tracks if the member has paid
+	PaidAt      *time.Time     `json:"paidAt,omitempty"`
 	SplitAmount float64        `json:"splitAmount"`
 	Remarks     string         `json:"remarks"`
 }
@@ -0,0 +1,19 @@
+package models
+
+// Statuses of background jobs, shared by report and export jobs
+const (
+	JobPending    = "PENDING"
+	JobProcessing = "PROCESSING"
+	JobDone       = "DONE"
+	JobFailed     = "FAILED"
+	JobCancelled  = "CANCELLED"
+	JobExpired    = "EXPIRED"
+)
+
+// JobID and JobAttempts let the job queue lease report and export jobs alike.
+
+func (j ReportJob) JobID() uint      { return j.ID }
+func (j ReportJob) JobAttempts() int { return j.Attempts }
+
+func (j ExportJob) JobID() uint      { return j.ID }
+func (j ExportJob) JobAttempts() int { return j.Attempts }
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// LoginThrottle stores failed login attempts for a throttling key (an account or an IP address).
+type LoginThrottle struct {
+	ThrottleKey string    `gorm:"primaryKey"`
+	Failures    int       `gorm:"not null;default:0"`
+	LastFailure time.Time `gorm:"index"`
+}
+
+// LoginEvent is a successful or failed login attempt recorded for the user to review.
+type LoginEvent struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UserID    uint      `gorm:"index" json:"-"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"userAgent"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Notification is an entry in a user's in-app inbox.
+type Notification struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time  `gorm:"index" json:"createdAt"`
+	UserID    uint       `gorm:"index" json:"-"`
+	Type      string     `json:"type"`
+	GroupID   uint       `json:"groupId,omitempty"`
+	ActorID   uint       `json:"actorId,omitempty"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `gorm:"index" json:"readAt,omitempty"`
+}
+
+// NotificationPreference stores whether a user wants notifications of an event type.
+// A missing row means the event type is enabled.
+type NotificationPreference struct {
+	UserID    uint   `gorm:"primaryKey"`
+	EventType string `gorm:"primaryKey"`
+	Enabled   bool
+}
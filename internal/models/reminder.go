@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Reminder kinds
+const (
+	ReminderScheduled = "SCHEDULED"
+	ReminderNudge     = "NUDGE"
+)
+
+// Reminder statuses
+const (
+	ReminderSent   = "SENT"
+	ReminderFailed = "FAILED"
+)
+
+// ReminderSchedule configures automatic payment reminders of a group. The first reminder goes out
+// FirstAfterDays after a member joined, then every RepeatEveryDays until they pay (0 sends only one).
+type ReminderSchedule struct {
+	GroupID         uint      `gorm:"primaryKey" json:"groupId"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	UpdatedBy       uint      `json:"updatedBy"`
+	Enabled         bool      `json:"enabled"`
+	FirstAfterDays  int       `json:"firstAfterDays"`
+	RepeatEveryDays int       `json:"repeatEveryDays"`
+	Channels        []string  `gorm:"serializer:json" json:"channels"`
+}
+
+// Reminder records a reminder sent to an unpaid member through one channel.
+type Reminder struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+	GroupID   uint      `gorm:"index" json:"groupId"`
+	MemberID  uint      `gorm:"index" json:"memberId"` // GroupMember id
+	UserID    uint      `gorm:"index" json:"userId"`
+	Kind      string    `json:"kind"`   // SCHEDULED or NUDGE
+	SentBy    uint      `json:"sentBy"` // owner who nudged, 0 for scheduled reminders
+	Channel   string    `json:"channel"`
+	Amount    float64   `json:"amount"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ReminderClaim is taken before a reminder goes out, so that when instances check the same member
+// at once only one sends it. PreviousID is the member's last reminder of the kind, 0 before the
+// first: the next reminder can be claimed once that one is recorded, or once the claim is stale.
+type ReminderClaim struct {
+	MemberID   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Kind       string `gorm:"primaryKey"`
+	PreviousID uint   `gorm:"primaryKey;autoIncrement:false"`
+	ClaimedAt  time.Time
+}
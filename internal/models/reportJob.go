@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Report job kinds
+const (
+	ReportKindGroups    = "groups"    // groups owned by the user in a date range
+	ReportKindGroup     = "group"     // detailed report of one group
+	ReportKindStatement = "statement" // personal statement of the user
+)
+
+// Report job statuses
+const (
+	ReportJobPending    = JobPending
+	ReportJobProcessing = JobProcessing
+	ReportJobDone       = JobDone
+	ReportJobFailed     = JobFailed
+	ReportJobCancelled  = JobCancelled
+	ReportJobExpired    = JobExpired
+)
+
+// ReportJobParams are the options a report job was requested with.
+type ReportJobParams struct {
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	GroupID  uint   `json:"groupId,omitempty"`
+	Comments bool   `json:"comments,omitempty"`
+	NoCharts bool   `json:"noCharts,omitempty"`
+}
+
+// ReportJob is a report generated in the background. Workers claim pending jobs and hold them until
+// LeaseUntil, after which a job left in PROCESSING by a stopped instance is picked up again.
+type ReportJob struct {
+	ID          uint            `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	UserID      uint            `gorm:"index" json:"userId"`
+	Kind        string          `json:"kind"`
+	Format      string          `json:"format"`
+	Params      ReportJobParams `gorm:"serializer:json" json:"params"`
+	Status      string          `gorm:"index;default:PENDING" json:"status"`
+	Attempts    int             `json:"attempts"`
+	LeaseUntil  *time.Time      `json:"-"`
+	FileName    string          `json:"fileName,omitempty"`
+	ContentType string          `json:"-"`
+	Data        []byte          `json:"-"` // Generated report, cleared when it expires
+	Error       string          `json:"error,omitempty"`
+	StartedAt   *time.Time      `json:"startedAt,omitempty"`
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time      `gorm:"index" json:"expiresAt,omitempty"`
+}
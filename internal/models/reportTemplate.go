@@ -0,0 +1,19 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReportTemplate is a stored report layout. User templates have a UserID and apply to every report of
+// their kind the user generates; group templates have a GroupID, are always of the group kind and take
+// precedence over the owner's own template. Definition holds the layout.Template as JSON.
+type ReportTemplate struct {
+	ID         uint            `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	UpdatedAt  time.Time       `json:"updatedAt"`
+	UserID     uint            `gorm:"uniqueIndex:idx_report_template_owner" json:"userId,omitempty"`  // 0 for group templates
+	GroupID    uint            `gorm:"uniqueIndex:idx_report_template_owner" json:"groupId,omitempty"` // 0 for user templates
+	Kind       string          `gorm:"uniqueIndex:idx_report_template_owner" json:"kind"`
+	Definition json.RawMessage `gorm:"type:jsonb" json:"definition" swaggertype:"object"`
+}
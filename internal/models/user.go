@@ -2,17 +2,38 @@ package models
 
 import "time"
 
-// User represents a user in the system.
+const (
+	RoleUser  = "USER"
+	RoleAdmin = "ADMIN"
+)
+
+// Who can see a user's email
+const (
+	EmailPrivate = "private" // only the user
+	EmailMembers = "members" // members of the user's groups
+)
+
+// User represents a user in the system. The password hash is never serialised; responses use
+// dto.UserResponse or dto.PublicUser.
 // @Description User model for registration and login.
 // @Name User
 // @Property email string true "Email" format(email)
-// @Property password string true "Password"
 // @Property name string true "Name"
 type User struct {
 	ID        uint      `json:"id" example:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Email     string    `json:"email" gorm:"unique" example:"user@example.com"`
-	Password  string    `json:"password" example:"password123"`
+	Password  string    `json:"-"`
 	Name      string    `json:"name" example:"John Doe"`
+	Role      string    `json:"role" gorm:"default:USER"` // USER or ADMIN, admins are promoted directly in the database
+	Locale    string    `json:"locale,omitempty"`         // BCP 47 tag of the formatting preset, such as de-DE
+	Timezone  string    `json:"timezone,omitempty"`       // IANA name, UTC when empty
+
+	EmailVisibility string `json:"emailVisibility,omitempty" gorm:"not null;default:private"` // EmailPrivate or EmailMembers
+}
+
+// EmailVisibleTo tells whether the viewer, who shares a group with the user, may see the user's email.
+func (u User) EmailVisibleTo(viewer uint) bool {
+	return u.ID == viewer || u.EmailVisibility == EmailMembers
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Delivery statuses
+const (
+	DeliveryPending   = "PENDING"
+	DeliverySucceeded = "SUCCEEDED"
+	DeliveryFailed    = "FAILED" // gave up after the maximum number of attempts
+)
+
+// Webhook is an endpoint that receives events of a group, or of every group when GroupID is nil.
+type Webhook struct {
+	ID         uint           `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	GroupID    *uint          `gorm:"index" json:"groupId,omitempty"` // nil for system-wide webhooks (admins only)
+	CreatedBy  uint           `json:"createdBy"`
+	URL        string         `json:"url"`
+	EventTypes []string       `gorm:"serializer:json" json:"eventTypes"`
+	Secret     string         `json:"-"`
+	Active     bool           `json:"active"`
+}
+
+// WebhookDelivery is a queued or attempted delivery of one event to one webhook.
+type WebhookDelivery struct {
+	ID             uint            `gorm:"primarykey" json:"id"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	WebhookID      uint            `gorm:"index" json:"webhookId"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `gorm:"type:jsonb" json:"payload" swaggertype:"object"`
+	Status         string          `gorm:"index;default:PENDING" json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `gorm:"index" json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time      `json:"lastAttemptAt,omitempty"`
+	ResponseStatus int             `json:"responseStatus,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	RedeliveryOf   *uint           `json:"redeliveryOf,omitempty"` // delivery this one was manually re-sent from
+}
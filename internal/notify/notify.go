@@ -0,0 +1,159 @@
+package notify
+
+import (
+	e "errors"
+	"fmt"
+
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Register subscribes the in-app inbox to domain events.
+func Register() {
+	events.Subscribe(handle)
+}
+
+func handle(ev events.Event) {
+	recipients, err := recipients(ev)
+	if err != nil {
+		log.Error("Failed to resolve notification recipients", zap.String("type", ev.Type), zap.Error(err))
+		return
+	}
+	for _, userID := range recipients {
+		if err := Deliver(userID, ev); err != nil {
+			log.Error("Failed to deliver notification", zap.String("type", ev.Type), zap.Uint("userId", userID), zap.Error(err))
+		}
+	}
+}
+
+// Deliver stores a notification about the event for the user unless they turned the event type off.
+func Deliver(userID uint, ev events.Event) error {
+	enabled, err := Enabled(userID, ev.Type)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	title, body := render(ev, userID)
+	notification := models.Notification{
+		UserID:  userID,
+		Type:    ev.Type,
+		GroupID: ev.GroupID,
+		ActorID: ev.ActorID,
+		Title:   title,
+		Body:    body,
+	}
+	return db.GetDb().Create(&notification).Error
+}
+
+// Enabled reports whether the user wants notifications of the event type.
+func Enabled(userID uint, eventType string) (bool, error) {
+	var pref models.NotificationPreference
+	err := db.GetDb().Where("user_id = ? AND event_type = ?", userID, eventType).First(&pref).Error
+	if e.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return pref.Enabled, nil
+}
+
+// recipients returns the members who should hear about the event. The actor is skipped
+// except when the whole group completes or a budget threshold is crossed.
+func recipients(ev events.Event) ([]uint, error) {
+	if ev.Type == events.ReminderDue {
+		return ev.UserIDs, nil
+	}
+	if ev.GroupID == 0 {
+		return nil, nil
+	}
+
+	var userIDs []uint
+	if err := db.GetDb().Model(&models.GroupMember{}).Where("group_id = ?", ev.GroupID).Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	if ev.Type == events.GroupCompleted || ev.Type == events.BudgetAlert {
+		return userIDs, nil
+	}
+
+	filtered := userIDs[:0]
+	for _, id := range userIDs {
+		if id != ev.ActorID {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered, nil
+}
+
+func render(ev events.Event, userID uint) (title, body string) {
+	actor := actorName(ev.ActorID)
+	group := ev.GroupName
+
+	switch ev.Type {
+	case events.MemberAdded:
+		for _, id := range ev.UserIDs {
+			if id == userID {
+				return fmt.Sprintf("Added to %s", group), fmt.Sprintf("%s added you to %s.", actor, group)
+			}
+		}
+		return fmt.Sprintf("New members in %s", group), fmt.Sprintf("%s added %d member(s) to %s.", actor, len(ev.UserIDs), group)
+	case events.BillCreated:
+		return fmt.Sprintf("New bill in %s", group), fmt.Sprintf("%s added the bill %s of %.2f.", actor, str(ev.Data["billName"]), num(ev.Data["amount"]))
+	case events.BillUpdated:
+		if previous := str(ev.Data["previousName"]); previous != str(ev.Data["billName"]) {
+			body = fmt.Sprintf("%s renamed the bill %s to %s", actor, previous, str(ev.Data["billName"]))
+		} else {
+			body = fmt.Sprintf("%s changed the bill %s", actor, str(ev.Data["billName"]))
+		}
+		if category := str(ev.Data["category"]); category != str(ev.Data["previousCategory"]) {
+			body += fmt.Sprintf(", now filed under %s", category)
+		}
+		return fmt.Sprintf("Bill changed in %s", group), body + "."
+	case events.PaymentMarked:
+		body = fmt.Sprintf("%s marked their share of %.2f as paid.", actor, num(ev.Data["amount"]))
+		if remarks := str(ev.Data["remarks"]); remarks != "" {
+			body += fmt.Sprintf(" Remarks: %s", remarks)
+		}
+		return fmt.Sprintf("Payment in %s", group), body
+	case events.GroupCompleted:
+		return fmt.Sprintf("%s is settled", group), fmt.Sprintf("Everyone has paid, %s is now DONE.", group)
+	case events.ReminderDue:
+		return fmt.Sprintf("Payment reminder for %s", group), fmt.Sprintf("You still owe %.2f in %s.", num(ev.Data["amount"]), group)
+	case events.BudgetAlert:
+		budget := "The budget"
+		if category := str(ev.Data["category"]); category != "" {
+			budget = fmt.Sprintf("The %s budget", category)
+		}
+		return fmt.Sprintf("Budget alert for %s", group), fmt.Sprintf("%s of %s reached %d%%: %.2f of %.2f spent.", budget, group, int(num(ev.Data["threshold"])), num(ev.Data["spent"]), num(ev.Data["amount"]))
+	default:
+		return ev.Type, group
+	}
+}
+
+func actorName(id uint) string {
+	if id == 0 {
+		return "SplitWise"
+	}
+	var user models.User
+	if err := db.GetDb().Select("name").Where("id = ?", id).First(&user).Error; err != nil {
+		return "Someone"
+	}
+	return user.Name
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func num(v any) float64 {
+	f, _ := v.(float64)
+	return f
+}
@@ -0,0 +1,131 @@
+// Package reminder sends payment reminders to members who have not paid yet.
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/notify"
+	"github.com/mohdjishin/SplitWise/internal/webhook/dispatch"
+)
+
+// Message is a reminder for one unpaid member.
+type Message struct {
+	UserID    uint
+	UserName  string
+	Email     string
+	GroupID   uint
+	GroupName string
+	Amount    float64
+	Kind      string // models.ReminderScheduled or models.ReminderNudge
+	SentBy    uint
+}
+
+// Channel delivers reminders through one medium.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+var (
+	mu       sync.RWMutex
+	channels = map[string]Channel{}
+)
+
+// RegisterChannel makes a channel available to schedules and nudges, replacing one with the same name.
+func RegisterChannel(ch Channel) {
+	mu.Lock()
+	defer mu.Unlock()
+	channels[ch.Name()] = ch
+}
+
+// Channels returns the names of the registered channels.
+func Channels() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func channel(name string) (Channel, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	ch, ok := channels[name]
+	return ch, ok
+}
+
+func init() {
+	RegisterChannel(inAppChannel{})
+	RegisterChannel(webhookChannel{})
+	RegisterChannel(emailChannel{})
+}
+
+func reminderEvent(msg Message) events.Event {
+	return events.Event{
+		ID:        events.NewID(),
+		Type:      events.ReminderDue,
+		GroupID:   msg.GroupID,
+		GroupName: msg.GroupName,
+		ActorID:   msg.SentBy,
+		UserIDs:   []uint{msg.UserID},
+		Data:      map[string]any{"amount": msg.Amount, "kind": msg.Kind},
+	}
+}
+
+// inAppChannel puts the reminder in the member's notification inbox.
+type inAppChannel struct{}
+
+func (inAppChannel) Name() string { return "inapp" }
+
+func (inAppChannel) Send(_ context.Context, msg Message) error {
+	return notify.Deliver(msg.UserID, reminderEvent(msg))
+}
+
+// webhookChannel sends a reminder.due event to the webhooks subscribed to it.
+type webhookChannel struct{}
+
+func (webhookChannel) Name() string { return "webhook" }
+
+func (webhookChannel) Send(_ context.Context, msg Message) error {
+	return dispatch.Enqueue(reminderEvent(msg))
+}
+
+// emailChannel mails the reminder through the SMTP server in reminders.smtp.
+type emailChannel struct{}
+
+func (emailChannel) Name() string { return "email" }
+
+func (emailChannel) Send(_ context.Context, msg Message) error {
+	cfg := config.GetConfig().Reminders.SMTP
+	if cfg.Host == "" {
+		return fmt.Errorf("email reminders are not configured")
+	}
+	if msg.Email == "" {
+		return fmt.Errorf("user %d has no email address", msg.UserID)
+	}
+
+	// Group names are user input: line breaks would start new headers, and the encoded word keeps
+	// non-ASCII names intact.
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(fmt.Sprintf("Payment reminder for %s", msg.GroupName))
+	body := fmt.Sprintf("Hi %s,\r\n\r\nYou still owe %.2f in %s. Please mark your payment in SplitWise once you have paid.\r\n", msg.UserName, msg.Amount, msg.GroupName)
+	mail := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", cfg.From, msg.Email, mime.QEncoding.Encode("utf-8", subject), body)
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	addr := cfg.Host + ":" + strconv.Itoa(cfg.Port)
+	return smtp.SendMail(addr, auth, fromAddress(cfg.From), []string{msg.Email}, []byte(mail))
+}
@@ -0,0 +1,95 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+const day = 24 * time.Hour
+
+// Job sends scheduled reminders to unpaid members of groups with an enabled schedule.
+type Job struct {
+	interval time.Duration
+}
+
+func NewJob(cfg config.Reminders) *Job {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &Job{interval: interval}
+}
+
+// Run checks the schedules every interval until ctx is cancelled.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		j.runOnce(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *Job) runOnce(ctx context.Context, now time.Time) {
+	var schedules []models.ReminderSchedule
+	if err := db.GetDb().Where("enabled = ?", true).Find(&schedules).Error; err != nil {
+		log.Error("Failed to fetch reminder schedules", zap.Error(err))
+		return
+	}
+
+	for _, schedule := range schedules {
+		var group models.Group
+		if err := db.GetDb().Where("id = ? AND status <> ?", schedule.GroupID, "DONE").First(&group).Error; err != nil {
+			continue // settled or deleted
+		}
+		var members []models.GroupMember
+		if err := db.GetDb().Where("group_id = ? AND has_paid = ?", group.ID, false).Find(&members).Error; err != nil {
+			log.Error("Failed to fetch unpaid members", zap.Uint("groupId", group.ID), zap.Error(err))
+			continue
+		}
+		for _, member := range members {
+			due, err := claimDue(schedule, member, now)
+			if err != nil {
+				log.Error("Failed to check reminder schedule", zap.Uint("memberId", member.ID), zap.Error(err))
+				continue
+			}
+			if !due {
+				continue // not yet, or another instance sends it
+			}
+			if _, err := Send(ctx, group, member, models.ReminderScheduled, 0, schedule.Channels); err != nil {
+				log.Error("Failed to send scheduled reminder", zap.Uint("memberId", member.ID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// claimDue reports whether the member should get a scheduled reminder now and, if so, claims it.
+func claimDue(schedule models.ReminderSchedule, member models.GroupMember, now time.Time) (bool, error) {
+	last, err := lastReminder(member.ID, models.ReminderScheduled)
+	if err != nil {
+		return false, err
+	}
+	var dueAt time.Time
+	switch {
+	case last == nil:
+		dueAt = member.CreatedAt.Add(time.Duration(schedule.FirstAfterDays) * day)
+	case schedule.RepeatEveryDays <= 0:
+		return false, nil
+	default:
+		dueAt = last.CreatedAt.Add(time.Duration(schedule.RepeatEveryDays) * day)
+	}
+	if now.Before(dueAt) {
+		return false, nil
+	}
+	return claim(member.ID, models.ReminderScheduled, last, now)
+}
@@ -0,0 +1,155 @@
+package reminder
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"gorm.io/gorm"
+)
+
+// countingChannel counts the reminders sent through it. Each send takes delay, long enough for
+// other instances to look at the member before the reminder is recorded.
+type countingChannel struct {
+	delay time.Duration
+	mu    sync.Mutex
+	sent  int
+}
+
+func (c *countingChannel) Name() string { return "counting" }
+
+func (c *countingChannel) Send(context.Context, Message) error {
+	time.Sleep(c.delay)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent++
+	return nil
+}
+
+// unpaidMember opens a database with an enabled schedule and one member who joined joinedAgo ago.
+func unpaidMember(t *testing.T, joinedAgo time.Duration) (*gorm.DB, models.GroupMember) {
+	t.Helper()
+	conn := dbtest.Open(t, &models.User{}, &models.Group{}, &models.GroupMember{}, &models.ReminderSchedule{}, &models.Reminder{}, &models.ReminderClaim{})
+	user := models.User{Name: "Asha", Email: "asha@example.com"}
+	if err := conn.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	group := models.Group{Name: "Trip"}
+	if err := conn.Create(&group).Error; err != nil {
+		t.Fatal(err)
+	}
+	schedule := models.ReminderSchedule{GroupID: group.ID, Enabled: true, FirstAfterDays: 3, RepeatEveryDays: 7, Channels: []string{"counting"}}
+	if err := conn.Create(&schedule).Error; err != nil {
+		t.Fatal(err)
+	}
+	member := models.GroupMember{GroupID: group.ID, UserID: user.ID, SplitAmount: 25, CreatedAt: time.Now().Add(-joinedAgo)}
+	if err := conn.Create(&member).Error; err != nil {
+		t.Fatal(err)
+	}
+	return conn, member
+}
+
+func TestClaimDue(t *testing.T) {
+	schedule := models.ReminderSchedule{FirstAfterDays: 3, RepeatEveryDays: 7}
+	now := time.Now()
+
+	t.Run("not yet", func(t *testing.T) {
+		_, member := unpaidMember(t, 2*day)
+		if due, err := claimDue(schedule, member, now); err != nil || due {
+			t.Errorf("claimDue() = %v, %v, want not due before FirstAfterDays", due, err)
+		}
+	})
+
+	t.Run("claimed once", func(t *testing.T) {
+		conn, member := unpaidMember(t, 4*day)
+		if due, err := claimDue(schedule, member, now); err != nil || !due {
+			t.Fatalf("claimDue() = %v, %v, want the first reminder", due, err)
+		}
+		// Another instance checks the member before the reminder is recorded.
+		if due, err := claimDue(schedule, member, now); err != nil || due {
+			t.Errorf("second claimDue() = %v, %v, want the reminder left to the first caller", due, err)
+		}
+		// The claim goes stale when its reminder never gets recorded.
+		later := now.Add(claimTimeout + time.Second)
+		if due, err := claimDue(schedule, member, later); err != nil || !due {
+			t.Errorf("claimDue() after the claim timeout = %v, %v, want the reminder again", due, err)
+		}
+
+		sent := models.Reminder{GroupID: member.GroupID, MemberID: member.ID, Kind: models.ReminderScheduled, CreatedAt: now.Add(-8 * day)}
+		if err := conn.Create(&sent).Error; err != nil {
+			t.Fatal(err)
+		}
+		if due, err := claimDue(schedule, member, now); err != nil || !due {
+			t.Errorf("claimDue() after a repeat period = %v, %v, want the next reminder", due, err)
+		}
+	})
+
+	t.Run("no repeat", func(t *testing.T) {
+		conn, member := unpaidMember(t, 30*day)
+		sent := models.Reminder{GroupID: member.GroupID, MemberID: member.ID, Kind: models.ReminderScheduled, CreatedAt: now.Add(-20 * day)}
+		if err := conn.Create(&sent).Error; err != nil {
+			t.Fatal(err)
+		}
+		if due, err := claimDue(models.ReminderSchedule{FirstAfterDays: 3}, member, now); err != nil || due {
+			t.Errorf("claimDue() = %v, %v, want a single reminder", due, err)
+		}
+	})
+}
+
+// TestRunOnceInstances runs the job of several instances at the same time; the member is reminded once.
+func TestRunOnceInstances(t *testing.T) {
+	conn, member := unpaidMember(t, 4*day)
+	ch := &countingChannel{delay: 50 * time.Millisecond}
+	RegisterChannel(ch)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewJob(config.Reminders{}).runOnce(context.Background(), time.Now())
+		}()
+	}
+	wg.Wait()
+
+	var count int64
+	if err := conn.Model(&models.Reminder{}).Where("member_id = ?", member.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if ch.sent != 1 || count != 1 {
+		t.Errorf("sent %d reminders and recorded %d, want one", ch.sent, count)
+	}
+}
+
+func TestClaimNudge(t *testing.T) {
+	config.SetConfig(config.Config{Reminders: config.Reminders{NudgeCooldown: time.Hour}})
+	conn, member := unpaidMember(t, day)
+
+	if next, err := ClaimNudge(member.ID); err != nil || !next.IsZero() {
+		t.Fatalf("ClaimNudge() = %v, %v, want the nudge", next, err)
+	}
+	// A second owner nudges before the first nudge is recorded.
+	if next, err := ClaimNudge(member.ID); err != nil || next.IsZero() {
+		t.Errorf("second ClaimNudge() = %v, %v, want a retry time", next, err)
+	}
+
+	sent := models.Reminder{GroupID: member.GroupID, MemberID: member.ID, Kind: models.ReminderNudge}
+	if err := conn.Create(&sent).Error; err != nil {
+		t.Fatal(err)
+	}
+	next, err := ClaimNudge(member.ID)
+	if err != nil || next.Before(sent.CreatedAt.Add(time.Hour)) || next.After(sent.CreatedAt.Add(time.Hour)) {
+		t.Errorf("ClaimNudge() after a nudge = %v, %v, want the end of the cooldown", next, err)
+	}
+
+	if err := conn.Model(&models.Reminder{}).Where("id = ?", sent.ID).Update("created_at", time.Now().Add(-2*time.Hour)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if next, err := ClaimNudge(member.ID); err != nil || !next.IsZero() {
+		t.Errorf("ClaimNudge() after the cooldown = %v, %v, want the nudge", next, err)
+	}
+}
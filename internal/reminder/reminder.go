@@ -0,0 +1,160 @@
+package reminder
+
+import (
+	"context"
+	e "errors"
+	"net/mail"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultFirstAfterDays  = 3
+	defaultRepeatEveryDays = 7
+)
+
+// DefaultSchedule is the schedule of a group that has not configured one. It is disabled.
+func DefaultSchedule(groupID uint) models.ReminderSchedule {
+	channels := config.GetConfig().Reminders.DefaultChannels
+	if len(channels) == 0 {
+		channels = []string{"inapp"}
+	}
+	return models.ReminderSchedule{
+		GroupID:         groupID,
+		FirstAfterDays:  defaultFirstAfterDays,
+		RepeatEveryDays: defaultRepeatEveryDays,
+		Channels:        channels,
+	}
+}
+
+// ScheduleFor returns the schedule of the group, or the default one when none is stored.
+func ScheduleFor(groupID uint) (models.ReminderSchedule, error) {
+	var schedule models.ReminderSchedule
+	err := db.GetDb().Where("group_id = ?", groupID).First(&schedule).Error
+	if e.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultSchedule(groupID), nil
+	}
+	return schedule, err
+}
+
+// Send reminds an unpaid member through each channel and records one reminder per channel.
+// Failures of a channel are recorded and do not stop the other channels.
+func Send(ctx context.Context, group models.Group, member models.GroupMember, kind string, sentBy uint, channelNames []string) ([]models.Reminder, error) {
+	var user models.User
+	if err := db.GetDb().Select("id", "name", "email").Where("id = ?", member.UserID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	msg := Message{
+		UserID:    user.ID,
+		UserName:  user.Name,
+		Email:     user.Email,
+		GroupID:   group.ID,
+		GroupName: group.Name,
+		Amount:    member.SplitAmount,
+		Kind:      kind,
+		SentBy:    sentBy,
+	}
+
+	reminders := make([]models.Reminder, 0, len(channelNames))
+	for _, name := range channelNames {
+		reminder := models.Reminder{
+			GroupID:  group.ID,
+			MemberID: member.ID,
+			UserID:   member.UserID,
+			Kind:     kind,
+			SentBy:   sentBy,
+			Channel:  name,
+			Amount:   member.SplitAmount,
+			Status:   models.ReminderSent,
+		}
+		ch, ok := channel(name)
+		var err error
+		if !ok {
+			err = e.New("unknown reminder channel")
+		} else {
+			err = ch.Send(ctx, msg)
+		}
+		if err != nil {
+			log.Warn("Failed to send reminder", zap.String("channel", name), zap.Uint("groupId", group.ID), zap.Uint("userId", member.UserID), zap.Error(err))
+			reminder.Status = models.ReminderFailed
+			reminder.Error = err.Error()
+		}
+		if err := db.GetDb().Create(&reminder).Error; err != nil {
+			return reminders, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+// ClaimNudge takes the member's next nudge for the caller and returns the zero time, or returns when
+// the member may be nudged again. Of two owners nudging at once only one gets the claim.
+func ClaimNudge(memberID uint) (time.Time, error) {
+	cooldown := config.GetConfig().Reminders.NudgeCooldown
+	if cooldown <= 0 {
+		return time.Time{}, nil
+	}
+	now := time.Now()
+	last, err := lastReminder(memberID, models.ReminderNudge)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if last != nil {
+		if next := last.CreatedAt.Add(cooldown); next.After(now) {
+			return next, nil
+		}
+	}
+	ok, err := claim(memberID, models.ReminderNudge, last, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !ok {
+		return now.Add(cooldown), nil
+	}
+	return time.Time{}, nil
+}
+
+// lastReminder returns the member's latest reminder of the kind, or nil if there is none.
+func lastReminder(memberID uint, kind string) (*models.Reminder, error) {
+	var last []models.Reminder
+	err := db.GetDb().Where("member_id = ? AND kind = ?", memberID, kind).Order("created_at DESC, id DESC").Limit(1).Find(&last).Error
+	if err != nil || len(last) == 0 {
+		return nil, err
+	}
+	return &last[0], nil
+}
+
+// claimTimeout is how long a claim holds when its reminder never gets recorded, e.g. because the
+// instance sending it stopped.
+const claimTimeout = 10 * time.Minute
+
+// claim takes the reminder of the kind that follows last and reports whether the caller got it.
+func claim(memberID uint, kind string, last *models.Reminder, now time.Time) (bool, error) {
+	c := models.ReminderClaim{MemberID: memberID, Kind: kind, ClaimedAt: now}
+	if last != nil {
+		c.PreviousID = last.ID
+	}
+	res := db.GetDb().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "member_id"}, {Name: "kind"}, {Name: "previous_id"}},
+		DoUpdates: clause.Assignments(map[string]any{"claimed_at": now}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lt{Column: clause.Column{Table: "reminder_claims", Name: "claimed_at"}, Value: now.Add(-claimTimeout)},
+		}},
+	}).Create(&c)
+	return res.RowsAffected > 0, res.Error
+}
+
+func fromAddress(from string) string {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return from
+	}
+	return addr.Address
+}
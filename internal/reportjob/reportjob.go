@@ -0,0 +1,69 @@
+// Package reportjob generates reports in the background with a bounded pool of workers.
+package reportjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/jobqueue"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+// Result is a generated report.
+type Result struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// Generator builds the report a job asks for. It must give up when ctx is done.
+type Generator func(ctx context.Context, job models.ReportJob) (Result, error)
+
+var queue = jobqueue.New[models.ReportJob]("report")
+
+// Wake makes an idle worker of this instance look at the queue now instead of at its next poll.
+func Wake() { queue.Wake() }
+
+// Cancel cancels a pending or running job and reports whether it was still unfinished.
+func Cancel(jobID uint) (bool, error) { return queue.Cancel(jobID) }
+
+// Pool runs report jobs with a fixed number of workers.
+type Pool struct {
+	cfg      config.ReportJobs
+	generate Generator
+}
+
+func New(cfg config.ReportJobs, generate Generator) *Pool {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = 5 * time.Minute
+	}
+	if cfg.ResultTTL <= 0 {
+		cfg.ResultTTL = 24 * time.Hour
+	}
+	return &Pool{cfg: cfg, generate: generate}
+}
+
+// Run starts the workers and expires old reports until ctx is cancelled.
+func (p *Pool) Run(ctx context.Context) {
+	queue.Run(ctx, jobqueue.Options{
+		Workers:      p.cfg.Workers,
+		PollInterval: p.cfg.PollInterval,
+		JobTimeout:   p.cfg.JobTimeout,
+		ResultTTL:    p.cfg.ResultTTL,
+	}, p.handle)
+}
+
+func (p *Pool) handle(ctx context.Context, job models.ReportJob) (map[string]any, error) {
+	result, err := p.generate(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"file_name": result.FileName, "content_type": result.ContentType, "data": result.Data}, nil
+}
@@ -0,0 +1,29 @@
+package reportjob
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/models"
+)
+
+func TestNewDefaults(t *testing.T) {
+	p := New(config.ReportJobs{}, nil)
+	if p.cfg.Workers != 4 || p.cfg.PollInterval != 5*time.Second || p.cfg.JobTimeout != 5*time.Minute || p.cfg.ResultTTL != 24*time.Hour {
+		t.Errorf("New() defaults = %+v", p.cfg)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	p := New(config.ReportJobs{}, func(context.Context, models.ReportJob) (Result, error) {
+		return Result{FileName: "report.csv", ContentType: "text/csv", Data: []byte("a,b\n")}, nil
+	})
+	got, err := p.handle(context.Background(), models.ReportJob{})
+	want := map[string]any{"file_name": "report.csv", "content_type": "text/csv", "data": []byte("a,b\n")}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("handle() = %v, %v, want %v", got, err, want)
+	}
+}
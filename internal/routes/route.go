@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/go-chi/chi"
 	mChi "github.com/go-chi/chi/middleware"
+	"github.com/mohdjishin/SplitWise/internal/graph"
 	"github.com/mohdjishin/SplitWise/internal/handlers"
 	"github.com/mohdjishin/SplitWise/internal/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -15,23 +16,52 @@ func NewRouter() (r *chi.Mux) {
 		mChi.Recoverer,
 		mChi.Logger,
 		mChi.RequestID,
-		mChi.RealIP,
+		middleware.ClientIP,
 		mChi.Heartbeat("/ping"),
+		middleware.Localize,
 	)
 
 	r.Post("/auth/register", handlers.Register)
 	r.Post("/auth/login", handlers.Login)
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
+	r.Get("/attachments/{attachmentId}/download", handlers.DownloadAttachment)
+	r.With(middleware.AuthMiddleware).Handle("/graphql", graph.Handler())
 
 	r.Route("/v1", func(r chi.Router) {
 		r.Use(middleware.AuthMiddleware)
 
 		r.Route("/groups", func(r chi.Router) {
 			r.Post("/", handlers.CreateGroupWithBill)
+			r.Get("/{id}", handlers.GetGroup)
 			r.Delete("/{id}", handlers.DeleteGroup)
 			r.Get("/owned", handlers.ListOwnedGroups)
 			r.Post("/{id}/addMembers", handlers.AddUsersToGroup)
 			r.Get("/member-groups", handlers.ListMemberGroups)
+			r.Get("/{id}/audit", handlers.ListGroupAuditLogs)
+			r.Get("/{id}/events", handlers.StreamGroupEvents)
+			r.Get("/{id}/activity", handlers.GetGroupActivity)
+			r.Get("/{id}/comments", handlers.ListGroupComments)
+			r.Post("/{id}/comments", handlers.CreateGroupComment)
+			r.Patch("/{id}/comments/{commentId}", handlers.UpdateComment)
+			r.Delete("/{id}/comments/{commentId}", handlers.DeleteComment)
+			r.Patch("/{id}/bills/{billId}", handlers.UpdateBill)
+			r.Get("/{id}/bills/{billId}/comments", handlers.ListBillComments)
+			r.Post("/{id}/bills/{billId}/comments", handlers.CreateBillComment)
+			r.Post("/{id}/bills/{billId}/attachments", handlers.UploadBillAttachment)
+			r.Post("/{id}/payments/attachments", handlers.UploadPaymentAttachment)
+			r.Get("/{id}/attachments", handlers.ListAttachments)
+			r.Get("/{id}/attachments/{attachmentId}/url", handlers.GetAttachmentURL)
+			r.Delete("/{id}/attachments/{attachmentId}", handlers.DeleteAttachment)
+			r.Get("/{id}/reminders", handlers.ListReminders)
+			r.Get("/{id}/reminders/schedule", handlers.GetReminderSchedule)
+			r.Put("/{id}/reminders/schedule", handlers.UpdateReminderSchedule)
+			r.Post("/{id}/members/{userId}/nudge", handlers.NudgeMember)
+			r.Get("/{id}/budgets", handlers.ListBudgets)
+			r.Put("/{id}/budgets", handlers.SetBudget)
+			r.Delete("/{id}/budgets/{budgetId}", handlers.DeleteBudget)
+			r.Get("/{id}/report-template", handlers.GetGroupReportTemplate)
+			r.Put("/{id}/report-template", handlers.SetGroupReportTemplate)
+			r.Delete("/{id}/report-template", handlers.DeleteGroupReportTemplate)
 		})
 
 		r.Route("/payments", func(r chi.Router) {
@@ -42,8 +72,68 @@ func NewRouter() (r *chi.Mux) {
 
 		r.Route("/report", func(r chi.Router) {
 			r.Post("/", handlers.GetGroupReport)
+			r.Post("/me", handlers.GetMemberReport)
 			r.Get("/{id}", handlers.GenerateSingleGroupReport)
 		})
+
+		r.Route("/reports", func(r chi.Router) {
+			r.Post("/", handlers.CreateReportJob)
+			r.Get("/", handlers.ListReportJobs)
+			r.Get("/{jobId}", handlers.GetReportJob)
+			r.Get("/{jobId}/download", handlers.DownloadReportJob)
+			r.Post("/{jobId}/cancel", handlers.CancelReportJob)
+		})
+
+		r.Route("/report-templates", func(r chi.Router) {
+			r.Get("/", handlers.ListReportTemplates)
+			r.Get("/defaults/{kind}", handlers.GetDefaultReportTemplate)
+			r.Get("/{kind}", handlers.GetReportTemplate)
+			r.Put("/{kind}", handlers.SetReportTemplate)
+			r.Delete("/{kind}", handlers.DeleteReportTemplate)
+		})
+
+		r.Route("/me", func(r chi.Router) {
+			r.Get("/", handlers.GetProfile)
+			r.Put("/privacy", handlers.UpdatePrivacy)
+			r.Get("/logins", handlers.ListLoginEvents)
+			r.Get("/preferences", handlers.GetPreferences)
+			r.Put("/preferences", handlers.UpdatePreferences)
+			r.Get("/export", handlers.ExportPersonalData)
+			r.Get("/export/{jobId}", handlers.GetExportStatus)
+			r.Get("/export/{jobId}/download", handlers.DownloadExport)
+		})
+
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handlers.ListNotifications)
+			r.Post("/read-all", handlers.MarkAllNotificationsRead)
+			r.Post("/{id}/read", handlers.MarkNotificationRead)
+			r.Get("/preferences", handlers.GetNotificationPreferences)
+			r.Put("/preferences", handlers.UpdateNotificationPreferences)
+		})
+
+		r.Route("/categories", func(r chi.Router) {
+			r.Get("/", handlers.ListCategories)
+			r.Post("/", handlers.CreateCategory)
+			r.Delete("/{id}", handlers.DeleteCategory)
+		})
+
+		r.Get("/analytics/spending", handlers.GetSpendingAnalytics)
+
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Post("/", handlers.CreateWebhook)
+			r.Get("/", handlers.ListWebhooks)
+			r.Get("/{id}", handlers.GetWebhook)
+			r.Patch("/{id}", handlers.UpdateWebhook)
+			r.Delete("/{id}", handlers.DeleteWebhook)
+			r.Post("/{id}/ping", handlers.PingWebhook)
+			r.Get("/{id}/deliveries", handlers.ListWebhookDeliveries)
+			r.Post("/{id}/deliveries/{deliveryId}/redeliver", handlers.RedeliverWebhookDelivery)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.AdminOnly)
+			r.Get("/audit", handlers.ListAuditLogs)
+		})
 	})
 	return
 }
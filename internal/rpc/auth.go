@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+
+	mChi "github.com/go-chi/chi/middleware"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/middleware"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// public are the services answering without a token, so load balancers and tools like grpcurl can
+// use them.
+var public = map[string]bool{
+	healthpb.Health_ServiceDesc.ServiceName:                    true,
+	reflectionv1.ServerReflection_ServiceDesc.ServiceName:      true,
+	reflectionv1alpha.ServerReflection_ServiceDesc.ServiceName: true,
+}
+
+func authUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func authStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate checks the bearer token of the authorization metadata, like middleware.AuthMiddleware
+// checks the Authorization header, and adds the user, the x-request-id metadata and the address of
+// the caller to the context.
+func authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if public[serviceName(fullMethod)] {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	userId, apiErr := middleware.Authenticate(first(md, "authorization"))
+	if apiErr != nil {
+		return ctx, apiStatus(ctx, codes.Unauthenticated, apiErr)
+	}
+	if id := first(md, "x-request-id"); id != "" {
+		ctx = context.WithValue(ctx, mChi.RequestIDKey, id)
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		ctx = middleware.WithClientIP(ctx, host)
+	}
+	return middleware.WithUserId(ctx, userId), nil
+}
+
+// caller returns the id of the authenticated user of a call.
+func caller(ctx context.Context) uint {
+	return uint(middleware.UserIdFrom(ctx))
+}
+
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(ctx, info.FullMethod, p)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(ss.Context(), info.FullMethod, p)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recovered logs a panic of a call and answers it with an internal error, as mChi.Recoverer does for
+// HTTP requests.
+func recovered(ctx context.Context, fullMethod string, p any) error {
+	log.Error("Panic in gRPC call", zap.String("method", fullMethod), zap.Any("panic", p), zap.ByteString("stack", debug.Stack()))
+	return apiStatus(ctx, codes.Internal, errors.ErrInternalError)
+}
+
+// contextStream is a server stream with the authenticated context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func serviceName(fullMethod string) string {
+	for i := 1; i < len(fullMethod); i++ {
+		if fullMethod[i] == '/' {
+			return fullMethod[1:i]
+		}
+	}
+	return ""
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
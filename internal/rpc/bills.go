@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+
+	splitwisev1 "github.com/mohdjishin/SplitWise/api/splitwise/v1"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type billServer struct {
+	splitwisev1.UnimplementedBillServiceServer
+}
+
+func (billServer) ListBills(ctx context.Context, req *splitwisev1.ListBillsRequest) (*splitwisev1.ListBillsResponse, error) {
+	userId := caller(ctx)
+	detail, err := ledger.GroupDetail(userId, uint(req.GetGroupId()))
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.ListBillsResponse{Bills: groupBillsOf(detail.Bills)}, nil
+}
+
+func (billServer) UpdateBill(ctx context.Context, req *splitwisev1.UpdateBillRequest) (*splitwisev1.UpdateBillResponse, error) {
+	userId := caller(ctx)
+	bill, err := ledger.UpdateBill(ctx, userId, uint(req.GetGroupId()), uint(req.GetBillId()), dto.UpdateBillRequest{Name: req.Name, Category: req.Category})
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.UpdateBillResponse{Bill: billOf(bill)}, nil
+}
+
+func billOf(bill models.Bill) *splitwisev1.Bill {
+	return &splitwisev1.Bill{
+		Id:         uint64(bill.ID),
+		Name:       bill.Name,
+		Category:   bill.Category,
+		Amount:     bill.Amount,
+		Completed:  bill.Completed,
+		CreateTime: timestamppb.New(bill.CreatedAt),
+		Payments:   paymentsOf(bill.History),
+	}
+}
+
+func groupBillsOf(bills []dto.GroupBill) []*splitwisev1.Bill {
+	out := make([]*splitwisev1.Bill, len(bills))
+	for i, bill := range bills {
+		out[i] = &splitwisev1.Bill{
+			Id:         uint64(bill.ID),
+			Name:       bill.Name,
+			Category:   bill.Category,
+			Amount:     bill.Amount,
+			Completed:  bill.Completed,
+			CreateTime: timestamppb.New(bill.CreatedAt),
+			Payments:   paymentsOf(bill.History),
+		}
+	}
+	return out
+}
+
+func paymentsOf(history []models.BillHistory) []*splitwisev1.BillPayment {
+	out := make([]*splitwisev1.BillPayment, len(history))
+	for i, h := range history {
+		out[i] = &splitwisev1.BillPayment{
+			Id:        uint64(h.ID),
+			Amount:    h.Amount,
+			PayerId:   uint64(h.PaidByID),
+			PayerName: h.PaidBy,
+			PayTime:   timestamppb.New(h.PaidAt),
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	e "errors"
+	"net/http"
+
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details, whose reason is the code of the API error.
+const errorDomain = "splitwise"
+
+var languageMatcher = language.NewMatcher(errors.Languages)
+
+// statusCodes maps the HTTP statuses of ledger failures to gRPC codes.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusGone:                codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// statusOf turns the error of a ledger operation into a gRPC status.
+func statusOf(ctx context.Context, err error) error {
+	var failure *ledger.Error
+	if !e.As(err, &failure) {
+		log.Error("gRPC call failed", zap.Error(err))
+		return apiStatus(ctx, codes.Internal, errors.ErrInternalError)
+	}
+	code, ok := statusCodes[failure.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+	return apiStatus(ctx, code, failure.Err)
+}
+
+// apiStatus is a status with the message of an API error, translated into the language of the
+// accept-language metadata, and its code in an ErrorInfo detail.
+func apiStatus(ctx context.Context, code codes.Code, err error) error {
+	var apiErr *errors.Error
+	if !e.As(err, &apiErr) {
+		return status.Error(code, err.Error())
+	}
+	st := status.New(code, translate(ctx, apiErr.Message))
+	if detailed, derr := st.WithDetails(&errdetails.ErrorInfo{Reason: apiErr.Code, Domain: errorDomain}); derr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func translate(ctx context.Context, message string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	accepted, _, _ := language.ParseAcceptLanguage(first(md, "accept-language"))
+	_, i, confidence := languageMatcher.Match(accepted...)
+	if confidence == language.No || i == 0 {
+		return message
+	}
+	return errors.Translate(message, errors.Languages[i])
+}
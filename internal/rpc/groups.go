@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	splitwisev1 "github.com/mohdjishin/SplitWise/api/splitwise/v1"
+	"github.com/mohdjishin/SplitWise/internal/errors"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var groupStatuses = map[string]splitwisev1.GroupStatus{
+	"PENDING": splitwisev1.GroupStatus_GROUP_STATUS_PENDING,
+	"DONE":    splitwisev1.GroupStatus_GROUP_STATUS_DONE,
+}
+
+type groupServer struct {
+	splitwisev1.UnimplementedGroupServiceServer
+}
+
+func (groupServer) CreateGroup(ctx context.Context, req *splitwisev1.CreateGroupRequest) (*splitwisev1.CreateGroupResponse, error) {
+	userId := caller(ctx)
+	input := dto.CreateGroupWithBillRequest{GroupName: req.GetName()}
+	input.Bill.Name = req.GetBillName()
+	input.Bill.Amount = req.GetAmount()
+	input.Bill.Category = req.GetCategory()
+	group, bill, err := ledger.CreateGroup(ctx, userId, input)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.CreateGroupResponse{Group: groupOf(group), Bill: billOf(bill)}, nil
+}
+
+func (groupServer) GetGroup(ctx context.Context, req *splitwisev1.GetGroupRequest) (*splitwisev1.GetGroupResponse, error) {
+	userId := caller(ctx)
+	detail, err := ledger.GroupDetail(userId, uint(req.GetGroupId()))
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	resp := &splitwisev1.GetGroupResponse{
+		Group: groupOf(models.Group{
+			ID:                 detail.ID,
+			Name:               detail.Name,
+			Status:             detail.Status,
+			CreatedBy:          detail.CreatedBy,
+			CreatedAt:          detail.CreatedAt,
+			TotalAmount:        detail.TotalAmount,
+			PerUserSplitAmount: detail.PerUserSplitAmount,
+			PaidAmount:         detail.PaidAmount,
+		}),
+		Bills:   groupBillsOf(detail.Bills),
+		Members: membersOf(detail.Members),
+	}
+	return resp, nil
+}
+
+func (groupServer) ListGroups(ctx context.Context, req *splitwisev1.ListGroupsRequest) (*splitwisev1.ListGroupsResponse, error) {
+	userId := caller(ctx)
+	filter := ledger.GroupFilter{Owned: req.GetOwnedOnly(), Limit: int(req.GetPageSize())}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageSize {
+		return nil, apiStatus(ctx, codes.InvalidArgument, errors.ErrInvalidQueryParameter("page_size must be between 1 and "+strconv.Itoa(maxPageSize)))
+	}
+	if token := req.GetPageToken(); token != "" {
+		after, err := strconv.ParseUint(token, 10, 64)
+		if err != nil {
+			return nil, apiStatus(ctx, codes.InvalidArgument, errors.ErrInvalidQueryParameter("page_token is not a token of a previous page"))
+		}
+		filter.After = uint(after)
+	}
+	for name, status := range groupStatuses {
+		if req.GetStatus() == status {
+			filter.Status = name
+		}
+	}
+
+	// One more group than asked for tells whether there is a next page.
+	filter.Limit++
+	groups, err := ledger.Groups(userId, filter)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	resp := &splitwisev1.ListGroupsResponse{}
+	if len(groups) == filter.Limit {
+		groups = groups[:len(groups)-1]
+		resp.NextPageToken = strconv.FormatUint(uint64(groups[len(groups)-1].ID), 10)
+	}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, groupOf(group))
+	}
+	return resp, nil
+}
+
+func (groupServer) DeleteGroup(ctx context.Context, req *splitwisev1.DeleteGroupRequest) (*splitwisev1.DeleteGroupResponse, error) {
+	userId := caller(ctx)
+	if err := ledger.DeleteGroup(ctx, userId, uint(req.GetGroupId())); err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.DeleteGroupResponse{}, nil
+}
+
+func groupOf(group models.Group) *splitwisev1.Group {
+	return &splitwisev1.Group{
+		Id:                 uint64(group.ID),
+		Name:               group.Name,
+		Status:             groupStatuses[group.Status],
+		OwnerId:            uint64(group.CreatedBy),
+		CreateTime:         timestamppb.New(group.CreatedAt),
+		TotalAmount:        group.TotalAmount,
+		PerUserSplitAmount: group.PerUserSplitAmount,
+		PaidAmount:         group.PaidAmount,
+	}
+}
+
+// timestamp is the time, or nil when it is not set.
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
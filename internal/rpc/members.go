@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+
+	splitwisev1 "github.com/mohdjishin/SplitWise/api/splitwise/v1"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type membershipServer struct {
+	splitwisev1.UnimplementedMembershipServiceServer
+}
+
+func (membershipServer) AddMembers(ctx context.Context, req *splitwisev1.AddMembersRequest) (*splitwisev1.AddMembersResponse, error) {
+	userId := caller(ctx)
+	if _, err := ledger.AddMembers(ctx, userId, uint(req.GetGroupId()), req.GetEmails()); err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	detail, err := ledger.GroupDetail(userId, uint(req.GetGroupId()))
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.AddMembersResponse{Members: membersOf(detail.Members)}, nil
+}
+
+func (membershipServer) ListMembers(ctx context.Context, req *splitwisev1.ListMembersRequest) (*splitwisev1.ListMembersResponse, error) {
+	userId := caller(ctx)
+	detail, err := ledger.GroupDetail(userId, uint(req.GetGroupId()))
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.ListMembersResponse{Members: membersOf(detail.Members)}, nil
+}
+
+func memberOf(m dto.GroupMemberDetail) *splitwisev1.Member {
+	return &splitwisev1.Member{
+		UserId:      uint64(m.UserID),
+		Name:        m.Name,
+		Email:       m.Email,
+		Owner:       m.IsOwner,
+		SplitAmount: m.SplitAmount,
+		Paid:        m.HasPaid,
+		PayTime:     timestamp(m.PaidAt),
+		Remarks:     m.Remarks,
+		JoinTime:    timestamppb.New(m.JoinedAt),
+	}
+}
+
+func membersOf(members []dto.GroupMemberDetail) []*splitwisev1.Member {
+	out := make([]*splitwisev1.Member, len(members))
+	for i, m := range members {
+		out[i] = memberOf(m)
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+
+	splitwisev1 "github.com/mohdjishin/SplitWise/api/splitwise/v1"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type paymentServer struct {
+	splitwisev1.UnimplementedPaymentServiceServer
+}
+
+func (paymentServer) MarkPayment(ctx context.Context, req *splitwisev1.MarkPaymentRequest) (*splitwisev1.MarkPaymentResponse, error) {
+	userId := caller(ctx)
+	if _, err := ledger.MarkPayment(ctx, userId, uint(req.GetGroupId()), req.GetRemarks()); err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	detail, err := ledger.GroupDetail(userId, uint(req.GetGroupId()))
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	resp := &splitwisev1.MarkPaymentResponse{}
+	for _, m := range detail.Members {
+		if m.UserID == userId {
+			resp.Member = memberOf(m)
+		}
+	}
+	return resp, nil
+}
+
+func (paymentServer) ListPendingPayments(ctx context.Context, _ *splitwisev1.ListPendingPaymentsRequest) (*splitwisev1.ListPendingPaymentsResponse, error) {
+	userId := caller(ctx)
+	payments, total, err := ledger.PendingPayments(userId)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	resp := &splitwisev1.ListPendingPaymentsResponse{TotalAmount: total}
+	for _, p := range payments {
+		resp.Payments = append(resp.Payments, &splitwisev1.PendingPayment{
+			GroupId:    uint64(p.GroupID),
+			GroupName:  p.GroupName,
+			BillId:     uint64(p.BillID),
+			Amount:     p.Amount,
+			BillAmount: p.BillAmount,
+			CreateTime: timestamppb.New(p.CreatedAt),
+		})
+	}
+	return resp, nil
+}
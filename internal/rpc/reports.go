@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"context"
+
+	splitwisev1 "github.com/mohdjishin/SplitWise/api/splitwise/v1"
+	"github.com/mohdjishin/SplitWise/internal/ledger"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/models/dto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// chunkSize is how much of a report file is sent per message.
+const chunkSize = 64 << 10
+
+var reportTypes = map[string]splitwisev1.ReportType{
+	models.ReportKindGroups:    splitwisev1.ReportType_REPORT_TYPE_GROUPS,
+	models.ReportKindGroup:     splitwisev1.ReportType_REPORT_TYPE_GROUP,
+	models.ReportKindStatement: splitwisev1.ReportType_REPORT_TYPE_STATEMENT,
+}
+
+var reportStatuses = map[string]splitwisev1.ReportStatus{
+	models.ReportJobPending:    splitwisev1.ReportStatus_REPORT_STATUS_PENDING,
+	models.ReportJobProcessing: splitwisev1.ReportStatus_REPORT_STATUS_PROCESSING,
+	models.ReportJobDone:       splitwisev1.ReportStatus_REPORT_STATUS_DONE,
+	models.ReportJobFailed:     splitwisev1.ReportStatus_REPORT_STATUS_FAILED,
+	models.ReportJobCancelled:  splitwisev1.ReportStatus_REPORT_STATUS_CANCELLED,
+	models.ReportJobExpired:    splitwisev1.ReportStatus_REPORT_STATUS_EXPIRED,
+}
+
+type reportServer struct {
+	splitwisev1.UnimplementedReportServiceServer
+}
+
+func (reportServer) CreateReport(ctx context.Context, req *splitwisev1.CreateReportRequest) (*splitwisev1.CreateReportResponse, error) {
+	userId := caller(ctx)
+	input := dto.CreateReportJobRequest{
+		Format:   req.GetFormat(),
+		GroupID:  uint(req.GetGroupId()),
+		Comments: req.GetComments(),
+		Charts:   req.Charts,
+	}
+	for kind, t := range reportTypes {
+		if req.GetType() == t {
+			input.Type = kind
+		}
+	}
+	if from := req.GetFrom(); from != "" {
+		input.From = &from
+	}
+	if to := req.GetTo(); to != "" {
+		input.To = &to
+	}
+	job, err := ledger.QueueReport(userId, input)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.CreateReportResponse{Report: reportOf(job)}, nil
+}
+
+func (reportServer) GetReport(ctx context.Context, req *splitwisev1.GetReportRequest) (*splitwisev1.GetReportResponse, error) {
+	userId := caller(ctx)
+	job, err := ledger.ReportJob(userId, uint(req.GetReportId()), false)
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.GetReportResponse{Report: reportOf(job)}, nil
+}
+
+func (reportServer) CancelReport(ctx context.Context, req *splitwisev1.CancelReportRequest) (*splitwisev1.CancelReportResponse, error) {
+	userId := caller(ctx)
+	job, err := ledger.CancelReport(userId, uint(req.GetReportId()))
+	if err != nil {
+		return nil, statusOf(ctx, err)
+	}
+	return &splitwisev1.CancelReportResponse{Report: reportOf(job)}, nil
+}
+
+func (reportServer) DownloadReport(req *splitwisev1.DownloadReportRequest, stream splitwisev1.ReportService_DownloadReportServer) error {
+	ctx := stream.Context()
+	userId := caller(ctx)
+	job, err := ledger.ReportFile(userId, uint(req.GetReportId()))
+	if err != nil {
+		return statusOf(ctx, err)
+	}
+	data := job.Data
+	msg := &splitwisev1.DownloadReportResponse{FileName: job.FileName, ContentType: job.ContentType}
+	for {
+		n := min(len(data), chunkSize)
+		msg.Data = data[:n]
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+		data = data[n:]
+		if len(data) == 0 {
+			return nil
+		}
+		msg = &splitwisev1.DownloadReportResponse{}
+	}
+}
+
+func reportOf(job models.ReportJob) *splitwisev1.Report {
+	return &splitwisev1.Report{
+		Id:           uint64(job.ID),
+		Type:         reportTypes[job.Kind],
+		Format:       job.Format,
+		Status:       reportStatuses[job.Status],
+		FileName:     job.FileName,
+		Error:        job.Error,
+		CreateTime:   timestamppb.New(job.CreatedAt),
+		StartTime:    timestamp(job.StartedAt),
+		CompleteTime: timestamp(job.CompletedAt),
+		ExpireTime:   timestamp(job.ExpiresAt),
+	}
+}
@@ -0,0 +1,115 @@
+// Package rpc serves the gRPC API for internal services. It runs on its own port next to the HTTP
+// server, authenticates calls with the same bearer tokens and shares the business logic of the
+// REST API through the ledger package.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	splitwisev1 "github.com/mohdjishin/SplitWise/api/splitwise/v1"
+	"github.com/mohdjishin/SplitWise/config"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+type Server struct {
+	addr       string
+	grpcServer *grpc.Server
+	health     *health.Server
+}
+
+// NewServer registers the SplitWise services along with health checking and server reflection. It
+// serves TLS when the config names a certificate and key, which it must to listen beyond this machine.
+func NewServer(cfg config.GRPC) (*Server, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("grpc.host must be set along with grpc.port")
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(recoverUnary, authUnary),
+		grpc.ChainStreamInterceptor(recoverStream, authStream),
+	}
+	switch {
+	case cfg.CertFile != "" && cfg.KeyFile != "":
+		creds, err := credentials.NewServerTLSFromFile(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load gRPC TLS certificate: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	case cfg.CertFile != "" || cfg.KeyFile != "":
+		return nil, fmt.Errorf("grpc.certFile and grpc.keyFile must be set together")
+	case !isLoopback(cfg.Host):
+		return nil, fmt.Errorf("grpc.host %s accepts connections from other machines, which needs grpc.certFile and grpc.keyFile so that tokens are not sent in plaintext", cfg.Host)
+	}
+
+	s := &Server{
+		addr:       net.JoinHostPort(cfg.Host, cfg.Port),
+		grpcServer: grpc.NewServer(opts...),
+		health:     health.NewServer(),
+	}
+	splitwisev1.RegisterGroupServiceServer(s.grpcServer, groupServer{})
+	splitwisev1.RegisterBillServiceServer(s.grpcServer, billServer{})
+	splitwisev1.RegisterMembershipServiceServer(s.grpcServer, membershipServer{})
+	splitwisev1.RegisterPaymentServiceServer(s.grpcServer, paymentServer{})
+	splitwisev1.RegisterReportServiceServer(s.grpcServer, reportServer{})
+	healthpb.RegisterHealthServer(s.grpcServer, s.health)
+	reflection.Register(s.grpcServer)
+
+	for name := range s.grpcServer.GetServiceInfo() {
+		s.health.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	return s, nil
+}
+
+// isLoopback reports whether host only accepts connections from this machine.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Start serves until Shutdown is called.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	log.Info("Starting gRPC server on " + s.addr)
+	if err := s.grpcServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	log.Info("gRPC server stopped gracefully")
+	return nil
+}
+
+// Shutdown reports the services as not serving and waits for calls in progress to finish, cutting
+// them off after a few seconds.
+func (s *Server) Shutdown() {
+	log.Info("Shutting down gRPC server...")
+	s.health.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Warn("gRPC calls still running after shutdown timeout, stopping", zap.Duration("timeout", shutdownTimeout))
+		s.grpcServer.Stop()
+	}
+}
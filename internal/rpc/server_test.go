@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/mohdjishin/SplitWise/config"
+)
+
+func TestNewServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.GRPC
+		wantErr bool
+	}{
+		{"loopback", config.GRPC{Port: "9090", Host: "127.0.0.1"}, false},
+		{"localhost", config.GRPC{Port: "9090", Host: "localhost"}, false},
+		{"ipv6 loopback", config.GRPC{Port: "9090", Host: "::1"}, false},
+		{"no host", config.GRPC{Port: "9090"}, true},
+		{"all interfaces without tls", config.GRPC{Port: "9090", Host: "0.0.0.0"}, true},
+		{"service name without tls", config.GRPC{Port: "9090", Host: "splitwise"}, true},
+		{"certificate without key", config.GRPC{Port: "9090", Host: "127.0.0.1", CertFile: "cert.pem"}, true},
+		{"missing certificate", config.GRPC{Port: "9090", Host: "0.0.0.0", CertFile: "missing.pem", KeyFile: "missing.key"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewServer(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && s.addr == "" {
+				t.Error("server has no address")
+			}
+		})
+	}
+}
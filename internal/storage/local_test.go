@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	e "errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalPathEscape(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "attachments")
+	l, err := NewLocal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, key := range []string{"", "/", "..", "../secret", "groups/../../secret", "groups/..", `..\secret`} {
+		t.Run(key, func(t *testing.T) {
+			if err := l.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+				t.Errorf("Put(%q) succeeded, want the key refused", key)
+			}
+			if _, err := l.Get(ctx, key); err == nil || e.Is(err, ErrNotFound) {
+				t.Errorf("Get(%q) = %v, want the key refused", key, err)
+			}
+			if err := l.Delete(ctx, key); err == nil {
+				t.Errorf("Delete(%q) succeeded, want the key refused", key)
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(dir, "secret")); !e.Is(err, os.ErrNotExist) {
+		t.Errorf("a file was written outside the root: %v", err)
+	}
+
+	// An absolute key stays below the root.
+	if err := l.Put(ctx, "/etc/receipt.txt", strings.NewReader("x"), 1, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "etc", "receipt.txt")); err != nil {
+		t.Errorf("absolute key not stored below the root: %v", err)
+	}
+}
+
+func TestLocalRoundTrip(t *testing.T) {
+	l, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRoundTrip(t, l)
+}
+
+// testRoundTrip stores, reads and deletes an object through s.
+func testRoundTrip(t *testing.T, s Storage) {
+	t.Helper()
+	ctx := context.Background()
+	const key = "groups/3/bills/5/receipt.txt"
+
+	if err := s.Put(ctx, key, strings.NewReader("paid in full"), 12, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "paid in full" {
+		t.Errorf("Get() = %q, %v, want the stored object", data, err)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, key); !e.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("second Delete() = %v, want nothing to do", err)
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mohdjishin/SplitWise/config"
+)
+
+// TestS3RoundTrip runs against a real S3-compatible server, e.g.
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	SPLITWISE_TEST_S3_ENDPOINT=localhost:9000 go test ./internal/storage
+//
+// The access and secret keys default to MinIO's minioadmin.
+func TestS3RoundTrip(t *testing.T) {
+	endpoint := os.Getenv("SPLITWISE_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("SPLITWISE_TEST_S3_ENDPOINT is not set")
+	}
+	cfg := config.S3Storage{
+		Endpoint:  endpoint,
+		AccessKey: envOr("SPLITWISE_TEST_S3_ACCESS_KEY", "minioadmin"),
+		SecretKey: envOr("SPLITWISE_TEST_S3_SECRET_KEY", "minioadmin"),
+		Bucket:    envOr("SPLITWISE_TEST_S3_BUCKET", "splitwise-test"),
+	}
+	s, err := NewS3(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRoundTrip(t, s)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
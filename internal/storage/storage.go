@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/mohdjishin/SplitWise/config"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+var ErrNotFound = e.New("object not found")
+
+// Storage stores binary objects such as receipt attachments.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New builds the storage backend selected by the configuration.
+func New(ctx context.Context, cfg config.Storage) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		path := cfg.LocalPath
+		if path == "" {
+			path = "./data/attachments"
+		}
+		return NewLocal(path)
+	case "s3":
+		return NewS3(ctx, cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+var (
+	instance Storage
+	once     sync.Once
+)
+
+// SetStorage replaces the default storage backend.
+func SetStorage(s Storage) {
+	instance = s
+}
+
+// GetStorage returns the storage backend configured through config.json.
+func GetStorage() Storage {
+	once.Do(func() {
+		if instance != nil {
+			return
+		}
+		var err error
+		instance, err = New(context.Background(), config.GetConfig().Storage)
+		if err != nil {
+			log.Fatal("failed to initialise storage", zap.Error(err))
+		}
+	})
+	return instance
+}
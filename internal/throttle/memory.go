@@ -0,0 +1,87 @@
+package throttle
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps attempts in process memory. It is only suitable for a single instance.
+type MemoryStore struct {
+	mu      sync.Mutex
+	window  time.Duration
+	records map[string]*list.Element
+	order   *list.List // of *entry, least recent failure first
+}
+
+type entry struct {
+	key string
+	rec Record
+}
+
+func NewMemoryStore(window time.Duration) *MemoryStore {
+	return &MemoryStore{window: window, records: make(map[string]*list.Element), order: list.New()}
+}
+
+func (s *MemoryStore) Reserve(_ context.Context, keys []string, at time.Time, allow func([]Record) bool) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict(at)
+	recs := make([]Record, len(keys))
+	for i, key := range keys {
+		if el, ok := s.records[key]; ok {
+			recs[i] = el.Value.(*entry).rec
+		}
+		if at.Sub(recs[i].LastFailure) > s.window {
+			recs[i].Failures = 0
+		}
+	}
+	if !allow(recs) {
+		return recs, nil
+	}
+	for i, key := range keys {
+		recs[i].Failures++
+		recs[i].LastFailure = at
+		if el, ok := s.records[key]; ok {
+			el.Value.(*entry).rec = recs[i]
+			s.order.MoveToBack(el)
+		} else {
+			s.records[key] = s.order.PushBack(&entry{key: key, rec: recs[i]})
+		}
+	}
+	return recs, nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.records[key]; ok && el.Value.(*entry).rec.Failures > 0 {
+		el.Value.(*entry).rec.Failures--
+	}
+	return nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.records[key]; ok {
+		s.order.Remove(el)
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// evict drops records that have been quiet for a long time so the map does not grow forever. Records
+// are kept in the order of their last failure, so only the stale ones at the front are looked at.
+func (s *MemoryStore) evict(now time.Time) {
+	for el := s.order.Front(); el != nil; el = s.order.Front() {
+		e := el.Value.(*entry)
+		if now.Sub(e.rec.LastFailure) <= 24*time.Hour+s.window {
+			return
+		}
+		s.order.Remove(el)
+		delete(s.records, e.key)
+	}
+}
@@ -0,0 +1,73 @@
+package throttle
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresStore keeps attempts in the login_throttles table so that every instance shares them.
+type PostgresStore struct {
+	db     *gorm.DB
+	window time.Duration
+}
+
+func NewPostgresStore(db *gorm.DB, window time.Duration) *PostgresStore {
+	return &PostgresStore{db: db, window: window}
+}
+
+// Reserve locks the rows of keys with SELECT ... FOR UPDATE, creating them first if needed, so that
+// concurrent attempts on the same key wait for each other.
+func (s *PostgresStore) Reserve(ctx context.Context, keys []string, at time.Time, allow func([]Record) bool) ([]Record, error) {
+	recs := make([]Record, len(keys))
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		rows := make([]models.LoginThrottle, len(keys))
+		for i, key := range keys {
+			rows[i] = models.LoginThrottle{ThrottleKey: key, LastFailure: at}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return err
+		}
+		for i, key := range keys {
+			var row models.LoginThrottle
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("throttle_key = ?", key).First(&row).Error; err != nil {
+				return err
+			}
+			recs[i] = Record{Failures: row.Failures, LastFailure: row.LastFailure}
+			if at.Sub(row.LastFailure) > s.window {
+				recs[i].Failures = 0
+			}
+		}
+		if !allow(recs) {
+			return nil
+		}
+		for i, key := range keys {
+			recs[i].Failures++
+			recs[i].LastFailure = at
+			if err := tx.Model(&models.LoginThrottle{}).Where("throttle_key = ?", key).Updates(map[string]any{
+				"failures":     recs[i].Failures,
+				"last_failure": at,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+func (s *PostgresStore) Release(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Model(&models.LoginThrottle{}).
+		Where("throttle_key = ? AND failures > 0", key).
+		Update("failures", gorm.Expr("failures - 1")).Error
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("throttle_key = ?", key).Delete(&models.LoginThrottle{}).Error
+}
@@ -0,0 +1,219 @@
+package throttle
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+)
+
+// Record is the failed-attempt state kept for a single key.
+type Record struct {
+	Failures    int
+	LastFailure time.Time
+}
+
+// Store keeps failed login attempts. Reserve must check and count as one step, so that
+// concurrent attempts on the same key, from this or another instance, are all counted.
+type Store interface {
+	// Reserve passes the records of keys to allow and, if it accepts them, counts a failure for each
+	// key before any other attempt on them is checked. It returns the records it ends with.
+	Reserve(ctx context.Context, keys []string, at time.Time, allow func([]Record) bool) ([]Record, error)
+	// Release takes back a failure counted by Reserve for an attempt that succeeded.
+	Release(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy describes how failures of one kind of key are punished.
+type Policy struct {
+	FreeAttempts     int           // failures allowed before any backoff applies
+	BaseDelay        time.Duration // first backoff delay, doubled on every further failure
+	MaxDelay         time.Duration // upper bound of the backoff delay
+	LockoutThreshold int           // failures after which the key is locked out
+	LockoutDuration  time.Duration // how long a lockout lasts
+}
+
+// BlockedUntil returns the time until which a key with the given record may not try again.
+func (p Policy) BlockedUntil(rec Record) time.Time {
+	if rec.Failures == 0 {
+		return time.Time{}
+	}
+	if p.LockoutThreshold > 0 && rec.Failures >= p.LockoutThreshold {
+		return rec.LastFailure.Add(p.LockoutDuration)
+	}
+	if rec.Failures <= p.FreeAttempts {
+		return time.Time{}
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < rec.Failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return rec.LastFailure.Add(delay)
+}
+
+// Locked reports whether the record has reached the lockout threshold.
+func (p Policy) Locked(rec Record) bool {
+	return p.LockoutThreshold > 0 && rec.Failures >= p.LockoutThreshold
+}
+
+// Decision is the outcome of a throttling check.
+type Decision struct {
+	Allowed    bool
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+// Guard applies per-account and per-IP policies on top of a Store.
+type Guard struct {
+	store   Store
+	account Policy
+	ip      Policy
+	now     func() time.Time
+}
+
+func NewGuard(store Store, account, ip Policy) *Guard {
+	return &Guard{store: store, account: account, ip: ip, now: time.Now}
+}
+
+// Attempt is a login attempt counted by Begin.
+type Attempt struct {
+	Decision
+	LocksOut bool // failing this attempt locks the account or the IP out
+	account  string
+	ip       string
+}
+
+// Begin tells whether a login for the given account and IP may be attempted now. An allowed attempt
+// is counted as a failure right away, so concurrent requests cannot all pass the check before any
+// of them fails; Succeed takes it back.
+func (g *Guard) Begin(ctx context.Context, account, ip string) (Attempt, error) {
+	attempt := Attempt{account: account, ip: ip}
+	recs, err := g.store.Reserve(ctx, []string{accountKey(account), ipKey(ip)}, g.now(), func(recs []Record) bool {
+		attempt.Decision = g.decide(recs[0], recs[1])
+		return attempt.Allowed
+	})
+	if err != nil {
+		return Attempt{}, err
+	}
+	if attempt.Allowed {
+		attempt.LocksOut = g.account.Locked(recs[0]) || g.ip.Locked(recs[1])
+	}
+	return attempt, nil
+}
+
+// Succeed clears the failures of the account and takes back the failure counted for the IP. Earlier
+// IP failures are kept so that an attacker cannot reset their budget by logging into an account of
+// their own.
+func (g *Guard) Succeed(ctx context.Context, attempt Attempt) error {
+	if err := g.store.Reset(ctx, accountKey(attempt.account)); err != nil {
+		return err
+	}
+	return g.store.Release(ctx, ipKey(attempt.ip))
+}
+
+func (g *Guard) decide(accountRec, ipRec Record) Decision {
+	now := g.now()
+	decision := Decision{Allowed: true}
+
+	for _, check := range []struct {
+		policy Policy
+		rec    Record
+	}{{g.account, accountRec}, {g.ip, ipRec}} {
+		until := check.policy.BlockedUntil(check.rec)
+		if !until.After(now) {
+			continue
+		}
+		decision.Allowed = false
+		if check.policy.Locked(check.rec) {
+			decision.Locked = true
+		}
+		if wait := until.Sub(now); wait > decision.RetryAfter {
+			decision.RetryAfter = wait
+		}
+	}
+	return decision
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+var (
+	guardInstance *Guard
+	once          sync.Once
+)
+
+// SetGuard replaces the default guard, e.g. to share a custom store.
+func SetGuard(g *Guard) {
+	guardInstance = g
+}
+
+// GetGuard returns the guard configured through config.json.
+func GetGuard() *Guard {
+	once.Do(func() {
+		if guardInstance != nil {
+			return
+		}
+		cfg := config.GetConfig().LoginThrottle
+		window := cfg.Window
+		if window <= 0 {
+			window = 15 * time.Minute
+		}
+
+		var store Store
+		switch cfg.Store {
+		case "postgres":
+			store = NewPostgresStore(db.GetDb(), window)
+		case "", "memory":
+			store = NewMemoryStore(window)
+		default:
+			log.Warn("Unknown login throttle store, falling back to memory", zap.String("store", cfg.Store))
+			store = NewMemoryStore(window)
+		}
+
+		guardInstance = NewGuard(store,
+			policyFromConfig(cfg.Account, Policy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Minute, LockoutThreshold: 10, LockoutDuration: 15 * time.Minute}),
+			policyFromConfig(cfg.IP, Policy{FreeAttempts: 10, BaseDelay: time.Second, MaxDelay: time.Minute, LockoutThreshold: 50, LockoutDuration: 15 * time.Minute}),
+		)
+	})
+	return guardInstance
+}
+
+func policyFromConfig(c config.ThrottlePolicy, defaults Policy) Policy {
+	p := Policy{
+		FreeAttempts:     c.FreeAttempts,
+		BaseDelay:        c.BaseDelay,
+		MaxDelay:         c.MaxDelay,
+		LockoutThreshold: c.LockoutThreshold,
+		LockoutDuration:  c.LockoutDuration,
+	}
+	if p.FreeAttempts <= 0 {
+		p.FreeAttempts = defaults.FreeAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaults.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaults.MaxDelay
+	}
+	if p.LockoutThreshold <= 0 {
+		p.LockoutThreshold = defaults.LockoutThreshold
+	}
+	if p.LockoutDuration <= 0 {
+		p.LockoutDuration = defaults.LockoutDuration
+	}
+	return p
+}
@@ -0,0 +1,220 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestPolicyBlockedUntil(t *testing.T) {
+	backoff := Policy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 8 * time.Second, LockoutThreshold: 6, LockoutDuration: 15 * time.Minute}
+	noLockout := Policy{FreeAttempts: 1, BaseDelay: time.Second, MaxDelay: 8 * time.Second}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		failures int
+		want     time.Time
+		locked   bool
+	}{
+		{"no failures", backoff, 0, time.Time{}, false},
+		{"within free attempts", backoff, 3, time.Time{}, false},
+		{"first delay", backoff, 4, t0.Add(time.Second), false},
+		{"doubled delay", backoff, 5, t0.Add(2 * time.Second), false},
+		{"lockout", backoff, 6, t0.Add(15 * time.Minute), true},
+		{"past lockout", backoff, 9, t0.Add(15 * time.Minute), true},
+		{"delay below cap", noLockout, 5, t0.Add(8 * time.Second), false},
+		{"delay capped", noLockout, 40, t0.Add(8 * time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := Record{Failures: tt.failures, LastFailure: t0}
+			if got := tt.policy.BlockedUntil(rec); !got.Equal(tt.want) {
+				t.Errorf("BlockedUntil() = %v, want %v", got, tt.want)
+			}
+			if got := tt.policy.Locked(rec); got != tt.locked {
+				t.Errorf("Locked() = %v, want %v", got, tt.locked)
+			}
+		})
+	}
+}
+
+// testGuard returns a guard over a memory store with a clock the test moves by hand.
+func testGuard(account, ip Policy) (*Guard, *time.Time) {
+	now := t0
+	g := NewGuard(NewMemoryStore(15*time.Minute), account, ip)
+	g.now = func() time.Time { return now }
+	return g, &now
+}
+
+var lenient = Policy{FreeAttempts: 100, BaseDelay: time.Second, MaxDelay: time.Second, LockoutThreshold: 1000, LockoutDuration: time.Minute}
+
+func TestGuardBegin(t *testing.T) {
+	g, now := testGuard(Policy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Minute, LockoutThreshold: 4, LockoutDuration: 10 * time.Minute}, lenient)
+	ctx := context.Background()
+
+	steps := []struct {
+		name     string
+		advance  time.Duration
+		want     Decision
+		locksOut bool
+	}{
+		{"first attempt", 0, Decision{Allowed: true}, false},
+		{"second attempt", 0, Decision{Allowed: true}, false},
+		{"last free attempt", 0, Decision{Allowed: true}, false},
+		{"backoff", 0, Decision{RetryAfter: time.Second}, false},
+		{"after backoff", time.Second, Decision{Allowed: true}, true},
+		{"locked", time.Second, Decision{Locked: true, RetryAfter: 10*time.Minute - time.Second}, false},
+		{"window passed", 16 * time.Minute, Decision{Allowed: true}, false},
+	}
+	for _, step := range steps {
+		*now = now.Add(step.advance)
+		attempt, err := g.Begin(ctx, " User@Example.com", "192.0.2.1")
+		if err != nil {
+			t.Fatalf("%s: Begin() error = %v", step.name, err)
+		}
+		if attempt.Decision != step.want {
+			t.Errorf("%s: decision = %+v, want %+v", step.name, attempt.Decision, step.want)
+		}
+		if attempt.LocksOut != step.locksOut {
+			t.Errorf("%s: LocksOut = %v, want %v", step.name, attempt.LocksOut, step.locksOut)
+		}
+	}
+}
+
+func TestGuardIPPolicy(t *testing.T) {
+	g, _ := testGuard(lenient, Policy{FreeAttempts: 1, BaseDelay: time.Minute, MaxDelay: time.Minute, LockoutThreshold: 10, LockoutDuration: time.Hour})
+	ctx := context.Background()
+
+	for _, account := range []string{"a@example.com", "b@example.com"} {
+		attempt, err := g.Begin(ctx, account, "192.0.2.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !attempt.Allowed {
+			t.Fatalf("attempt for %s refused, want the free attempt and the one after it on a new account", account)
+		}
+	}
+	attempt, err := g.Begin(ctx, "c@example.com", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempt.Allowed || attempt.RetryAfter != time.Minute {
+		t.Errorf("third account from one IP = %+v, want refused for a minute", attempt.Decision)
+	}
+	if attempt, _ := g.Begin(ctx, "c@example.com", "192.0.2.2"); !attempt.Allowed {
+		t.Errorf("another IP = %+v, want allowed", attempt.Decision)
+	}
+}
+
+func TestGuardSucceed(t *testing.T) {
+	g, _ := testGuard(Policy{FreeAttempts: 1, BaseDelay: time.Minute, MaxDelay: time.Minute, LockoutThreshold: 10, LockoutDuration: time.Hour}, lenient)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := g.Begin(ctx, "a@example.com", "192.0.2.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if attempt, _ := g.Begin(ctx, "a@example.com", "192.0.2.1"); attempt.Allowed {
+		t.Fatal("account not throttled after two failures")
+	}
+
+	// A successful login on another account does not reset the first one.
+	other, err := g.Begin(ctx, "b@example.com", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Succeed(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if attempt, _ := g.Begin(ctx, "a@example.com", "192.0.2.1"); attempt.Allowed {
+		t.Error("account unthrottled by a login to another account")
+	}
+
+	recs, _ := g.store.Reserve(ctx, []string{accountKey("b@example.com"), ipKey("192.0.2.1")}, t0, func([]Record) bool { return false })
+	if recs[0].Failures != 0 {
+		t.Errorf("account failures after success = %d, want 0", recs[0].Failures)
+	}
+	if recs[1].Failures != 2 {
+		t.Errorf("IP failures after success = %d, want the 2 failed attempts kept", recs[1].Failures)
+	}
+}
+
+func TestGuardConcurrentBegin(t *testing.T) {
+	g, _ := testGuard(Policy{FreeAttempts: 2, BaseDelay: time.Hour, MaxDelay: time.Hour, LockoutThreshold: 10, LockoutDuration: time.Hour}, lenient)
+	ctx := context.Background()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			attempt, err := g.Begin(ctx, "a@example.com", "192.0.2.1")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if attempt.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != 3 {
+		t.Errorf("%d concurrent attempts allowed, want the 3 before backoff", allowed)
+	}
+}
+
+func TestMemoryStoreEvict(t *testing.T) {
+	s := NewMemoryStore(15 * time.Minute)
+	ctx := context.Background()
+	all := func([]Record) bool { return true }
+	stale := 24*time.Hour + 15*time.Minute
+
+	for _, step := range []struct {
+		key string
+		at  time.Time
+	}{
+		{"a", t0},
+		{"b", t0.Add(time.Minute)},
+		{"a", t0.Add(2 * time.Minute)}, // a failed again, b is now the oldest
+		{"c", t0.Add(3 * time.Minute)},
+	} {
+		if _, err := s.Reserve(ctx, []string{step.key}, step.at, all); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Reset(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Reserve(ctx, []string{"d"}, t0.Add(time.Minute+stale+time.Second), all); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.records["b"]; ok {
+		t.Error("b is still kept after a day without failures")
+	}
+	if _, ok := s.records["a"]; !ok {
+		t.Error("a was evicted although it failed after b")
+	}
+	if len(s.records) != s.order.Len() || len(s.records) != 2 {
+		t.Errorf("%d records and %d in order, want a and d", len(s.records), s.order.Len())
+	}
+
+	if _, err := s.Reserve(ctx, []string{"d"}, t0.Add(2*stale), all); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.records["a"]; ok || len(s.records) != 1 {
+		t.Errorf("%d records left, want only d", len(s.records))
+	}
+}
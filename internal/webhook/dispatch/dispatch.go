@@ -0,0 +1,257 @@
+// Package dispatch queues domain events for webhooks and delivers them with retries.
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/db"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/webhook"
+	log "github.com/mohdjishin/SplitWise/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ping is the event type of test deliveries sent on request.
+const Ping = "webhook.ping"
+
+// Register queues a delivery for every active webhook subscribed to a published event.
+func Register() {
+	events.Subscribe(func(ev events.Event) {
+		if err := Enqueue(ev); err != nil {
+			log.Error("Failed to queue webhook deliveries", zap.String("type", ev.Type), zap.String("eventId", ev.ID), zap.Error(err))
+		}
+	})
+}
+
+// Enqueue stores a pending delivery of the event for each matching webhook.
+func Enqueue(ev events.Event) error {
+	var hooks []models.Webhook
+	query := db.GetDb().Where("active = ?", true)
+	if ev.GroupID != 0 {
+		query = query.Where("group_id IS NULL OR group_id = ?", ev.GroupID)
+	} else {
+		query = query.Where("group_id IS NULL")
+	}
+	if err := query.Find(&hooks).Error; err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		if !slices.Contains(hook.EventTypes, ev.Type) {
+			continue
+		}
+		if _, err := EnqueueTo(hook, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnqueueTo stores a pending delivery of the event for a single webhook.
+func EnqueueTo(hook models.Webhook, ev events.Event) (models.WebhookDelivery, error) {
+	if ev.ID == "" {
+		ev.ID = events.NewID()
+	}
+	if ev.OccurredAt.IsZero() {
+		ev.OccurredAt = time.Now()
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	delivery := models.WebhookDelivery{
+		WebhookID:     hook.ID,
+		EventID:       ev.ID,
+		EventType:     ev.Type,
+		Payload:       payload,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+	return delivery, db.GetDb().Create(&delivery).Error
+}
+
+// Redeliver queues a new delivery with the same payload as an earlier one. The original stays in the log.
+func Redeliver(original models.WebhookDelivery) (models.WebhookDelivery, error) {
+	originalID := original.ID
+	delivery := models.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now(),
+		RedeliveryOf:  &originalID,
+	}
+	return delivery, db.GetDb().Create(&delivery).Error
+}
+
+// Dispatcher polls the delivery queue and sends due deliveries.
+type Dispatcher struct {
+	cfg    config.Webhooks
+	client *http.Client
+}
+
+func New(cfg config.Webhooks) *Dispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = cfg.BaseBackoff
+	}
+	return &Dispatcher{cfg: cfg, client: newClient(cfg.Timeout, cfg.AllowPrivateAddresses)}
+}
+
+// Run delivers queued events until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		d.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) poll(ctx context.Context) {
+	deliveries, err := d.claim()
+	if err != nil {
+		log.Error("Failed to claim webhook deliveries", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery models.WebhookDelivery) {
+			defer wg.Done()
+			d.attempt(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+// claim picks due deliveries and pushes their next attempt past the request timeout, so other
+// instances polling the same table skip them while they are in flight.
+func (d *Dispatcher) claim() ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	now := time.Now()
+	err := db.GetDb().Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at").Limit(d.cfg.BatchSize).Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+		ids := make([]uint, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(2*d.cfg.Timeout)).Error
+	})
+	return deliveries, err
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery models.WebhookDelivery) {
+	var hook models.Webhook
+	if err := db.GetDb().Where("id = ?", delivery.WebhookID).First(&hook).Error; err != nil {
+		d.finish(delivery, models.DeliveryFailed, 0, "webhook no longer exists")
+		return
+	}
+	if !hook.Active {
+		d.finish(delivery, models.DeliveryFailed, 0, "webhook is disabled")
+		return
+	}
+
+	status, err := d.send(ctx, hook, delivery)
+	delivery.Attempts++
+	switch {
+	case err == nil && status >= 200 && status < 300:
+		d.finish(delivery, models.DeliverySucceeded, status, "")
+		return
+	case err == nil:
+		err = fmt.Errorf("endpoint responded with status %d", status)
+	}
+
+	log.Warn("Webhook delivery failed", zap.Uint("deliveryId", delivery.ID), zap.Uint("webhookId", hook.ID), zap.Int("attempt", delivery.Attempts), zap.Error(err))
+	if delivery.Attempts >= d.cfg.MaxAttempts {
+		d.finish(delivery, models.DeliveryFailed, status, err.Error())
+		return
+	}
+	delivery.NextAttemptAt = time.Now().Add(d.backoff(delivery.Attempts))
+	d.finish(delivery, models.DeliveryPending, status, err.Error())
+}
+
+func (d *Dispatcher) send(ctx context.Context, hook models.Webhook, delivery models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SplitWise-Webhooks/1.0")
+	req.Header.Set(webhook.HeaderEvent, delivery.EventType)
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	webhook.SetHeaders(req.Header, hook.Secret, time.Now(), delivery.Payload)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	// The body is not kept: the receiver may be anything the URL points at, so only the status is logged.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) finish(delivery models.WebhookDelivery, status string, responseStatus int, errMsg string) {
+	now := time.Now()
+	updates := map[string]any{
+		"status":          status,
+		"attempts":        delivery.Attempts,
+		"last_attempt_at": now,
+		"response_status": responseStatus,
+		"error":           errMsg,
+	}
+	if status == models.DeliveryPending {
+		updates["next_attempt_at"] = delivery.NextAttemptAt
+	}
+	if err := db.GetDb().Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error; err != nil {
+		log.Error("Failed to update webhook delivery", zap.Uint("deliveryId", delivery.ID), zap.Error(err))
+	}
+}
+
+// backoff returns the wait before the next attempt: BaseBackoff doubled for every failed attempt, capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.cfg.BaseBackoff
+	for i := 1; i < attempts && wait < d.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.cfg.MaxBackoff)
+}
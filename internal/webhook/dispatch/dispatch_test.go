@@ -0,0 +1,294 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+	"github.com/mohdjishin/SplitWise/internal/db/dbtest"
+	"github.com/mohdjishin/SplitWise/internal/events"
+	"github.com/mohdjishin/SplitWise/internal/models"
+	"github.com/mohdjishin/SplitWise/internal/webhook"
+	"gorm.io/gorm"
+)
+
+const testSecret = "whsec_test"
+
+// receiver is a webhook endpoint that checks signatures and answers with the queued statuses, then 204.
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+type receivedRequest struct {
+	deliveryID string
+	event      events.Event
+	verifyErr  error
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	rcv := &receiver{statuses: statuses}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := receivedRequest{
+			deliveryID: r.Header.Get(webhook.HeaderDelivery),
+			verifyErr:  webhook.Verify(testSecret, r.Header, body, 0, time.Now()),
+		}
+		_ = json.Unmarshal(body, &req.event)
+
+		rcv.mu.Lock()
+		defer rcv.mu.Unlock()
+		rcv.requests = append(rcv.requests, req)
+		status := http.StatusNoContent
+		if len(rcv.statuses) > 0 {
+			status, rcv.statuses = rcv.statuses[0], rcv.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (rcv *receiver) received() []receivedRequest {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return append([]receivedRequest(nil), rcv.requests...)
+}
+
+// setup opens a test database with a webhook pointing at rcv and returns a dispatcher allowed to reach it.
+func setup(t *testing.T, rcv *receiver, cfg config.Webhooks) (*Dispatcher, *gorm.DB, models.Webhook) {
+	conn := dbtest.Open(t, &models.Webhook{}, &models.WebhookDelivery{})
+	hook := models.Webhook{URL: rcv.URL, EventTypes: []string{events.BillCreated}, Secret: testSecret, Active: true}
+	if err := conn.Create(&hook).Error; err != nil {
+		t.Fatal(err)
+	}
+	cfg.AllowPrivateAddresses = true // httptest listens on loopback
+	return New(cfg), conn, hook
+}
+
+func reload(t *testing.T, conn *gorm.DB, id uint) models.WebhookDelivery {
+	t.Helper()
+	var delivery models.WebhookDelivery
+	if err := conn.First(&delivery, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return delivery
+}
+
+// makeDue moves the next attempt of a delivery into the past, as if its backoff had passed.
+func makeDue(t *testing.T, conn *gorm.DB, id uint) {
+	t.Helper()
+	if err := conn.Model(&models.WebhookDelivery{}).Where("id = ?", id).Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := New(config.Webhooks{BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{5, 5 * time.Minute},
+		{20, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSendSignsDelivery(t *testing.T) {
+	rcv := newReceiver(t)
+	d := New(config.Webhooks{AllowPrivateAddresses: true})
+	hook := models.Webhook{URL: rcv.URL, Secret: testSecret}
+	delivery := models.WebhookDelivery{ID: 7, EventType: events.BillCreated, Payload: []byte(`{"id":"ev1","type":"bill.created"}`)}
+
+	status, err := d.send(context.Background(), hook, delivery)
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("send() = %d, %v, want 204", status, err)
+	}
+	got := rcv.received()
+	if len(got) != 1 || got[0].verifyErr != nil || got[0].deliveryID != "7" || got[0].event.ID != "ev1" {
+		t.Errorf("receiver got %+v, want one signed request for delivery 7 of event ev1", got)
+	}
+}
+
+func TestSendRefusesLoopback(t *testing.T) {
+	rcv := newReceiver(t)
+	d := New(config.Webhooks{})
+	_, err := d.send(context.Background(), models.Webhook{URL: rcv.URL, Secret: testSecret}, models.WebhookDelivery{Payload: []byte(`{}`)})
+	if !errors.Is(err, ErrAddressBlocked) {
+		t.Errorf("send() to %s = %v, want %v", rcv.URL, err, ErrAddressBlocked)
+	}
+	if n := len(rcv.received()); n != 0 {
+		t.Errorf("receiver got %d requests, want none", n)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	d, conn, hook := setup(t, rcv, config.Webhooks{MaxAttempts: 5, BaseBackoff: time.Minute, MaxBackoff: time.Hour})
+	queued, err := EnqueueTo(hook, events.Event{Type: events.BillCreated, GroupID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt, wantBackoff := range []time.Duration{time.Minute, 2 * time.Minute} {
+		before := time.Now()
+		d.poll(context.Background())
+		delivery := reload(t, conn, queued.ID)
+		if delivery.Status != models.DeliveryPending || delivery.Attempts != attempt+1 {
+			t.Fatalf("after failure %d: status %s with %d attempts, want PENDING with %d", attempt+1, delivery.Status, delivery.Attempts, attempt+1)
+		}
+		if delivery.ResponseStatus < 500 || delivery.Error == "" {
+			t.Errorf("after failure %d: response %d, error %q, want the 5xx recorded", attempt+1, delivery.ResponseStatus, delivery.Error)
+		}
+		if next := delivery.NextAttemptAt; next.Before(before.Add(wantBackoff)) || next.After(time.Now().Add(wantBackoff)) {
+			t.Errorf("after failure %d: next attempt in %v, want %v", attempt+1, next.Sub(before), wantBackoff)
+		}
+
+		// Not due yet
+		d.poll(context.Background())
+		if n := len(rcv.received()); n != attempt+1 {
+			t.Fatalf("delivery sent %d times before its backoff passed, want %d", n, attempt+1)
+		}
+		makeDue(t, conn, queued.ID)
+	}
+
+	d.poll(context.Background())
+	delivery := reload(t, conn, queued.ID)
+	if delivery.Status != models.DeliverySucceeded || delivery.Attempts != 3 || delivery.ResponseStatus != http.StatusNoContent || delivery.Error != "" {
+		t.Errorf("after success: %+v, want SUCCEEDED after 3 attempts with 204", delivery)
+	}
+	got := rcv.received()
+	for i, req := range got {
+		if req.verifyErr != nil {
+			t.Errorf("request %d: %v", i+1, req.verifyErr)
+		}
+		if req.deliveryID != strconv.FormatUint(uint64(queued.ID), 10) || req.event.ID != queued.EventID {
+			t.Errorf("request %d is delivery %s of event %s, want %d of %s", i+1, req.deliveryID, req.event.ID, queued.ID, queued.EventID)
+		}
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+	d, conn, hook := setup(t, rcv, config.Webhooks{MaxAttempts: 2, BaseBackoff: time.Minute})
+	queued, err := EnqueueTo(hook, events.Event{Type: events.BillCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.poll(context.Background())
+	makeDue(t, conn, queued.ID)
+	d.poll(context.Background())
+	delivery := reload(t, conn, queued.ID)
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 2 || delivery.Error != "endpoint responded with status 500" {
+		t.Errorf("after max attempts: %+v, want FAILED after 2 attempts", delivery)
+	}
+
+	makeDue(t, conn, queued.ID)
+	d.poll(context.Background())
+	if n := len(rcv.received()); n != 2 {
+		t.Errorf("failed delivery sent %d times, want 2", n)
+	}
+}
+
+func TestDisabledWebhook(t *testing.T) {
+	rcv := newReceiver(t)
+	d, conn, hook := setup(t, rcv, config.Webhooks{})
+	queued, err := EnqueueTo(hook, events.Event{Type: events.BillCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Model(&hook).Update("active", false).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	d.poll(context.Background())
+	if delivery := reload(t, conn, queued.ID); delivery.Status != models.DeliveryFailed || delivery.Error != "webhook is disabled" {
+		t.Errorf("delivery to a disabled webhook: %+v, want FAILED", delivery)
+	}
+	if n := len(rcv.received()); n != 0 {
+		t.Errorf("disabled webhook got %d requests, want none", n)
+	}
+}
+
+func TestRedeliver(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError)
+	d, conn, hook := setup(t, rcv, config.Webhooks{MaxAttempts: 1})
+	queued, err := EnqueueTo(hook, events.Event{Type: events.BillCreated, GroupID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.poll(context.Background())
+	original := reload(t, conn, queued.ID)
+	if original.Status != models.DeliveryFailed {
+		t.Fatalf("original delivery is %s, want FAILED", original.Status)
+	}
+
+	redelivery, err := Redeliver(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redelivery.ID == original.ID || redelivery.RedeliveryOf == nil || *redelivery.RedeliveryOf != original.ID {
+		t.Fatalf("Redeliver() = %+v, want a new delivery pointing at %d", redelivery, original.ID)
+	}
+	d.poll(context.Background())
+
+	if got := reload(t, conn, redelivery.ID); got.Status != models.DeliverySucceeded || got.Attempts != 1 {
+		t.Errorf("redelivery: %+v, want SUCCEEDED after 1 attempt", got)
+	}
+	if got := reload(t, conn, original.ID); got.Status != models.DeliveryFailed || got.Attempts != original.Attempts {
+		t.Errorf("original after redelivery: %+v, want it left as it was", got)
+	}
+	got := rcv.received()
+	if len(got) != 2 {
+		t.Fatalf("receiver got %d requests, want 2", len(got))
+	}
+	if got[1].verifyErr != nil || got[1].event.ID != original.EventID || got[1].deliveryID != strconv.FormatUint(uint64(redelivery.ID), 10) {
+		t.Errorf("redelivered request %+v, want event %s signed as delivery %d", got[1], original.EventID, redelivery.ID)
+	}
+}
+
+func TestEnqueueMatchesSubscriptions(t *testing.T) {
+	rcv := newReceiver(t)
+	_, conn, hook := setup(t, rcv, config.Webhooks{})
+	otherGroup := uint(2)
+	for _, other := range []models.Webhook{
+		{URL: rcv.URL, EventTypes: []string{events.PaymentMarked}, Secret: testSecret, Active: true},
+		{URL: rcv.URL, EventTypes: []string{events.BillCreated}, Secret: testSecret, Active: false},
+		{URL: rcv.URL, EventTypes: []string{events.BillCreated}, Secret: testSecret, Active: true, GroupID: &otherGroup},
+	} {
+		if err := conn.Create(&other).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Enqueue(events.Event{Type: events.BillCreated, GroupID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	var deliveries []models.WebhookDelivery
+	if err := conn.Find(&deliveries).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].WebhookID != hook.ID {
+		t.Errorf("queued %+v, want one delivery to webhook %d", deliveries, hook.ID)
+	}
+}
@@ -0,0 +1,133 @@
+package dispatch
+
+import (
+	e "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mohdjishin/SplitWise/config"
+)
+
+var (
+	ErrURLScheme      = e.New("webhook url must use http or https")
+	ErrURLHost        = e.New("webhook url must have a host")
+	ErrAddressBlocked = e.New("webhook url points to a private, loopback or link-local address")
+)
+
+// blockedPrefixes are ranges that netip.Addr has no predicate for but that never belong to a public
+// receiver.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, includes broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, embeds IPv4 addresses
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("2002::/16"),       // 6to4, embeds IPv4 addresses
+	netip.MustParsePrefix("fec0::/10"),       // deprecated site-local
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/32"),       // Teredo, embeds IPv4 addresses
+	netip.MustParsePrefix("::ffff:0:0:0/96"), // IPv4-translated
+}
+
+// CheckURL tells whether a webhook may be registered for raw. Host names are resolved when a
+// delivery is sent, where the dialer refuses blocked addresses, so only literal addresses are
+// checked here.
+func CheckURL(raw string) error {
+	return checkURL(raw, config.GetConfig().Webhooks.AllowPrivateAddresses)
+}
+
+func checkURL(raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrURLScheme
+	}
+	host := u.Hostname()
+	if host == "" {
+		return ErrURLHost
+	}
+	if allowPrivate {
+		return nil
+	}
+	if host = strings.TrimSuffix(strings.ToLower(host), "."); host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrAddressBlocked
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !allowedAddr(addr) {
+		return ErrAddressBlocked
+	}
+	return nil
+}
+
+// allowedAddr reports whether deliveries may be sent to addr.
+func allowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// guardConn is the dialer Control that refuses blocked addresses. It runs on the address actually
+// connected to, after DNS resolution, so a host name that resolves to an internal address, or is
+// changed to resolve to one after the webhook was registered, is refused too.
+func guardConn(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !allowedAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrAddressBlocked, addr)
+	}
+	return nil
+}
+
+// newClient returns the HTTP client deliveries are sent with. It ignores proxy settings, which would
+// make the dialer check the proxy instead of the receiver, and only follows redirects to http and https.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = guardConn
+	}
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return e.New("stopped after 10 redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrURLScheme
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package dispatch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url          string
+		allowPrivate bool
+		want         error
+	}{
+		{"https://hooks.example.com/splitwise", false, nil},
+		{"http://93.184.216.34:8080/hook", false, nil},
+		{"https://[2606:4700::1111]/hook", false, nil},
+		{"ftp://hooks.example.com/", false, ErrURLScheme},
+		{"file:///etc/passwd", false, ErrURLScheme},
+		{"https:///hook", false, ErrURLHost},
+		{"http://localhost:9090/", false, ErrAddressBlocked},
+		{"http://api.LOCALHOST./", false, ErrAddressBlocked},
+		{"http://127.0.0.1/", false, ErrAddressBlocked},
+		{"http://10.1.2.3/", false, ErrAddressBlocked},
+		{"http://192.168.0.10/", false, ErrAddressBlocked},
+		{"http://169.254.169.254/latest/meta-data/", false, ErrAddressBlocked},
+		{"http://100.64.0.1/", false, ErrAddressBlocked},
+		{"http://0.0.0.0/", false, ErrAddressBlocked},
+		{"http://[::1]/", false, ErrAddressBlocked},
+		{"http://[::ffff:127.0.0.1]/", false, ErrAddressBlocked},
+		{"http://[fe80::1]/", false, ErrAddressBlocked},
+		{"http://[fd00::1]/", false, ErrAddressBlocked},
+		{"http://[64:ff9b::a9fe:a9fe]/", false, ErrAddressBlocked},
+		{"http://localhost:9090/", true, nil},
+		{"http://127.0.0.1/", true, nil},
+		{"ftp://127.0.0.1/", true, ErrURLScheme},
+	}
+	for _, tt := range tests {
+		if err := checkURL(tt.url, tt.allowPrivate); !errors.Is(err, tt.want) {
+			t.Errorf("checkURL(%q, allowPrivate=%v) = %v, want %v", tt.url, tt.allowPrivate, err, tt.want)
+		}
+	}
+}
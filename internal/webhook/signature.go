@@ -0,0 +1,85 @@
+// Package webhook holds the wire format shared by the sender and receivers of SplitWise webhooks.
+// It has no dependencies on the rest of the application so receivers can import it on their own.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	e "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderEvent     = "X-SplitWise-Event"
+	HeaderDelivery  = "X-SplitWise-Delivery"
+	HeaderTimestamp = "X-SplitWise-Timestamp" // unix seconds
+	HeaderSignature = "X-SplitWise-Signature" // "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+)
+
+// DefaultTolerance is how far a delivery timestamp may be from the receiver's clock.
+const DefaultTolerance = 5 * time.Minute
+
+const (
+	signaturePrefix = "sha256="
+	secretPrefix    = "whsec_"
+	secretBytes     = 24
+)
+
+var (
+	ErrMissingSignature = e.New("missing webhook signature or timestamp")
+	ErrInvalidTimestamp = e.New("invalid webhook timestamp")
+	ErrTimestampTooOld  = e.New("webhook timestamp outside the allowed tolerance")
+	ErrInvalidSignature = e.New("invalid webhook signature")
+)
+
+// NewSecret returns a random signing secret for a new webhook.
+func NewSecret() string {
+	b := make([]byte, secretBytes)
+	_, _ = rand.Read(b)
+	return secretPrefix + hex.EncodeToString(b)
+}
+
+// Sign returns the signature header value for a payload sent at the given unix timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetHeaders adds the timestamp and signature headers for body to h.
+func SetHeaders(h http.Header, secret string, now time.Time, body []byte) {
+	ts := now.Unix()
+	h.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	h.Set(HeaderSignature, Sign(secret, ts, body))
+}
+
+// Verify checks the signature headers of a received delivery. Requests whose timestamp is further
+// than tolerance from now are rejected to limit replays; a zero tolerance uses DefaultTolerance.
+func Verify(secret string, h http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	tsHeader, sig := h.Get(HeaderTimestamp), h.Get(HeaderSignature)
+	if tsHeader == "" || sig == "" {
+		return ErrMissingSignature
+	}
+	ts, err := strconv.ParseInt(tsHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+	if diff := now.Sub(time.Unix(ts, 0)); diff > tolerance || diff < -tolerance {
+		return ErrTimestampTooOld
+	}
+	if !strings.HasPrefix(sig, signaturePrefix) || !hmac.Equal([]byte(sig), []byte(Sign(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_test"
+
+var now = time.Unix(1700000000, 0)
+
+func TestSign(t *testing.T) {
+	// printf '1700000000.{}' | openssl dgst -sha256 -hmac whsec_test
+	const want = "sha256=35495024f4ef3f94e5a93e22221544c4b75e9a42300cd965ab81cb85cd994e91"
+	if got := Sign(testSecret, now.Unix(), []byte("{}")); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"bill.created"}`)
+	signed := func(secret string, at time.Time, body []byte) http.Header {
+		h := http.Header{}
+		SetHeaders(h, secret, at, body)
+		return h
+	}
+	with := func(h http.Header, key, value string) http.Header {
+		h = h.Clone()
+		if value == "" {
+			h.Del(key)
+		} else {
+			h.Set(key, value)
+		}
+		return h
+	}
+	valid := signed(testSecret, now, body)
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		body      []byte
+		tolerance time.Duration
+		want      error
+	}{
+		{"valid", valid, body, 0, nil},
+		{"within tolerance", signed(testSecret, now.Add(-4*time.Minute), body), body, 0, nil},
+		{"clock ahead within tolerance", signed(testSecret, now.Add(4*time.Minute), body), body, 0, nil},
+		{"too old", signed(testSecret, now.Add(-6*time.Minute), body), body, 0, ErrTimestampTooOld},
+		{"too far ahead", signed(testSecret, now.Add(6*time.Minute), body), body, 0, ErrTimestampTooOld},
+		{"custom tolerance", signed(testSecret, now.Add(-6*time.Minute), body), body, 10 * time.Minute, nil},
+		{"no signature", with(valid, HeaderSignature, ""), body, 0, ErrMissingSignature},
+		{"no timestamp", with(valid, HeaderTimestamp, ""), body, 0, ErrMissingSignature},
+		{"bad timestamp", with(valid, HeaderTimestamp, "yesterday"), body, 0, ErrInvalidTimestamp},
+		{"other timestamp", with(valid, HeaderTimestamp, strconv.FormatInt(now.Unix()+1, 10)), body, 0, ErrInvalidSignature},
+		{"wrong secret", signed("whsec_other", now, body), body, 0, ErrInvalidSignature},
+		{"changed body", valid, []byte(`{"type":"bill.deleted"}`), 0, ErrInvalidSignature},
+		{"no prefix", with(valid, HeaderSignature, strings.TrimPrefix(valid.Get(HeaderSignature), "sha256=")), body, 0, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(testSecret, tt.header, tt.body, tt.tolerance, now); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestVerifyRequest signs a request the way the dispatcher does and checks it on a receiver.
+func TestVerifyRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := Verify(testSecret, r.Header, body, 0, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	send := func(secret, body string) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		SetHeaders(req.Header, secret, time.Now(), []byte(body))
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := send(testSecret, `{"id":"1"}`); status != http.StatusNoContent {
+		t.Errorf("signed request answered %d, want 204", status)
+	}
+	if status := send("whsec_other", `{"id":"1"}`); status != http.StatusUnauthorized {
+		t.Errorf("request signed with another secret answered %d, want 401", status)
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, b := NewSecret(), NewSecret()
+	if !strings.HasPrefix(a, "whsec_") || len(a) != len("whsec_")+2*secretBytes {
+		t.Errorf("NewSecret() = %q, want whsec_ and %d hex digits", a, 2*secretBytes)
+	}
+	if a == b {
+		t.Error("NewSecret() returned the same secret twice")
+	}
+}